@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+// runBatch streams many poems through the analyzer and writes one JSON result per line.
+func runBatch(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("haikuctl batch", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("file", "", "read poems from file instead of stdin")
+	format := fs.String("format", "stanza", "input format: stanza, lines, jsonl or csv")
+	tolerance := fs.Int("tolerant", 0, "allowed syllable deviation per line")
+	autosplit := fs.Bool("autosplit", false, "split single-line poems into 3 lines")
+	normalize := fs.String("normalize", "nfc", "Unicode normalization: nfc, nfkc or none")
+	workers := fs.Int("workers", 0, "number of concurrent analyzers (default: number of CPUs)")
+	buffer := fs.Int("buffer", 0, "maximum records in flight (default: 4 per worker)")
+	idField := fs.String("id-field", "", "column or field holding the record ID")
+	authorField := fs.String("author-field", "", "column or field holding the author")
+	poemField := fs.String("poem-field", "", "column or field holding the poem text")
+	dialectName := fs.String("dialect", "general-american", "pronunciation profile: general-american, rp, australian or southern-us")
+	dictFile := fs.String("dict", "", "syllable override dictionary (default: nearest .haikugo/syllables.tsv)")
+	rubricFile := fs.String("rubric", "", "scoring rubric (default: nearest .haikugo/rubric.tsv)")
+	exitCode := fs.Bool("exit-code", false, "exit 2 if any poem is invalid or fails to parse")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+
+	f, err := haikugo.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	form, err := haikugo.ParseNormalization(*normalize)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	dialect, err := haikugo.ParseDialect(*dialectName)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	overrides, err := loadOverrides(*dictFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	rubric, err := loadRubric(*rubricFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	r := stdin
+	if *file != "" {
+		fh, err := os.Open(*file)
+		if err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return exitError
+		}
+		defer fh.Close()
+		r = fh
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	opts := haikugo.StreamOptions{
+		Format:        f,
+		Mapping:       haikugo.FieldMapping{ID: *idField, Author: *authorField, Poem: *poemField},
+		Autosplit:     *autosplit,
+		Normalization: form,
+		Workers:       *workers,
+		Buffer:        *buffer,
+	}
+
+	enc := json.NewEncoder(stdout)
+	invalid := false
+	a := haikugo.NewAnalyzer(*tolerance)
+	a.SetOverrides(overrides)
+	a.SetDialect(dialect)
+	a.SetRubric(rubric)
+	err = a.AnalyzeStream(ctx, r, opts, func(res haikugo.RecordResult) error {
+		if res.Metrics == nil || !res.Metrics.Valid575 {
+			invalid = true
+		}
+		return enc.Encode(res)
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	if *exitCode && invalid {
+		return exitInvalid
+	}
+	return exitValid
+}
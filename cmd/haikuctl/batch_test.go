@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRunBatch(t *testing.T) {
+	input := sampleHaiku + "\nbroken\n\n" + sampleHaiku
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"batch", "--workers", "2", "--exit-code"}, strings.NewReader(input), &stdout, &stderr)
+	if code != exitInvalid {
+		t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, exitInvalid, stderr.String())
+	}
+
+	var ids []string
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		var res struct {
+			ID    string `json:"id"`
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &res); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		ids = append(ids, res.ID)
+		if (res.Error != "") != (res.ID == "2") {
+			t.Errorf("Record %s error = %q", res.ID, res.Error)
+		}
+	}
+
+	if strings.Join(ids, ",") != "1,2,3" {
+		t.Errorf("Got IDs %v, want [1 2 3]", ids)
+	}
+}
+
+func TestRunBatch_BadFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"batch", "--format", "xml"}, strings.NewReader(""), &stdout, &stderr); code != exitError {
+		t.Errorf("Exit code = %d, want %d", code, exitError)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+// runDedupe reports clusters of near-duplicate poems in a corpus file or
+// directory.
+func runDedupe(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("haikuctl dedupe", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var opts haikugo.SimilarityOptions
+	fs.Float64Var(&opts.Threshold, "threshold", haikugo.DefaultSimilarityThreshold, "edit similarity from 0 to 1 at which poems are near-duplicates")
+	fs.IntVar(&opts.ShingleSize, "shingle", haikugo.DefaultShingleSize, "words per shingle")
+	idField := fs.String("id-field", "", "column or field holding the record ID")
+	authorField := fs.String("author-field", "", "column or field holding the author")
+	poemField := fs.String("poem-field", "", "column or field holding the poem text")
+	asJSON := fs.Bool("json", false, "output clusters with their pairs as JSON")
+	exitCode := fs.Bool("exit-code", false, "exit 2 if any near-duplicates are found")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(stderr, "usage: haikuctl dedupe [flags] dir|file")
+		return exitError
+	}
+
+	records, err := haikugo.ReadCorpus(fs.Arg(0), haikugo.FieldMapping{ID: *idField, Author: *authorField, Poem: *poemField})
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+	for _, rec := range records {
+		if rec.Err != nil {
+			fmt.Fprintf(stderr, "warning: %s: %v\n", rec.ID, rec.Err)
+		}
+	}
+
+	clusters, err := haikugo.FindDuplicates(records, opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	if *asJSON {
+		if clusters == nil {
+			clusters = []haikugo.DuplicateCluster{}
+		}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(clusters); err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return exitError
+		}
+	} else {
+		printClusters(stdout, clusters)
+	}
+
+	if *exitCode && len(clusters) > 0 {
+		return exitInvalid
+	}
+	return exitValid
+}
+
+// printClusters writes each cluster's size and similarity followed by its
+// poems, one per line.
+func printClusters(w io.Writer, clusters []haikugo.DuplicateCluster) {
+	if len(clusters) == 0 {
+		fmt.Fprintln(w, "No near-duplicates found.")
+		return
+	}
+	for i, c := range clusters {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%d poems, similarity %.2f\n", len(c.Entries), c.Similarity)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, e := range c.Entries {
+			fmt.Fprintf(tw, "  %s\t%s\n", e.ID, strings.Join(e.Lines, " / "))
+		}
+		tw.Flush()
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+func TestRunDedupe(t *testing.T) {
+	t.Chdir(t.TempDir())
+	files := map[string]string{
+		"corpus/a.txt":   "an old silent pond\na frog jumps into the pond\nsplash silence again\n\nthe old temple bell\nrings over the quiet hills\na crow in the rain\n",
+		"corpus/b.jsonl": `{"id":"e7","author":"Anon","poem":["An old silent pond","a frog leaps into the pond","splash! silence again"]}` + "\n" + `{"id":"e8","poem":"short"}` + "\n",
+	}
+	for name, data := range files {
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		want       int
+		wantOut    []string
+		wantStderr string
+	}{
+		{"directory", []string{"dedupe", "corpus"}, exitValid, []string{"2 poems, similarity 0.95", "a.txt:1 ", "b.jsonl:e7 "}, "warning: b.jsonl:e8:"},
+		{"exit code", []string{"dedupe", "--exit-code", "corpus"}, exitInvalid, []string{"2 poems"}, ""},
+		{"strict threshold", []string{"dedupe", "--threshold", "0.99", "--exit-code", "corpus"}, exitValid, []string{"No near-duplicates found."}, ""},
+		{"single file", []string{"dedupe", "corpus/a.txt"}, exitValid, []string{"No near-duplicates found."}, ""},
+		{"no path", []string{"dedupe"}, exitError, nil, "usage:"},
+		{"missing path", []string{"dedupe", "missing"}, exitError, nil, "error:"},
+		{"bad threshold", []string{"dedupe", "--threshold", "2", "corpus"}, exitError, nil, "threshold"},
+		{"NaN threshold", []string{"dedupe", "--threshold", "NaN", "corpus"}, exitError, nil, "threshold"},
+		{"bad shingle", []string{"dedupe", "--shingle", "9", "corpus"}, exitError, nil, "shingle"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, strings.NewReader(""), &stdout, &stderr); code != tt.want {
+				t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, tt.want, stderr.String())
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("Output missing %q:\n%s", want, stdout.String())
+				}
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("Stderr = %q, want %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestRunDedupe_JSON(t *testing.T) {
+	t.Chdir(t.TempDir())
+	data := `{"id":"1","author":"Basho","poem":["an old silent pond","a frog jumps into the pond","splash silence again"]}` + "\n" +
+		`{"id":"2","author":"Anon","poem":["an old silent pond","a frog jumps in the pond","splash silence again"]}` + "\n"
+	if err := os.WriteFile("entries.jsonl", []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"dedupe", "--json", "entries.jsonl"}, strings.NewReader(""), &stdout, &stderr); code != exitValid {
+		t.Fatalf("Exit code = %d (stderr: %s)", code, stderr.String())
+	}
+	var clusters []haikugo.DuplicateCluster
+	if err := json.Unmarshal(stdout.Bytes(), &clusters); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(clusters) != 1 || len(clusters[0].Pairs) != 1 {
+		t.Fatalf("Clusters = %+v, want one pair", clusters)
+	}
+	if p := clusters[0].Pairs[0]; p.A != "1" || p.B != "2" || p.Similarity < 0.8 || clusters[0].Entries[1].Author != "Anon" {
+		t.Errorf("Cluster = %+v, want 1 and 2 with their authors", clusters[0])
+	}
+}
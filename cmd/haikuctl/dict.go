@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+// runDict manages the project's syllable override dictionary.
+func runDict(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "usage: haikuctl dict add|remove|list [flags] [word [count]]")
+		return exitError
+	}
+
+	action, args := args[0], args[1:]
+	flags := flag.NewFlagSet("haikuctl dict "+action, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	path := flags.String("dict", "", "dictionary file (default: nearest "+haikugo.DefaultDictionaryPath+")")
+	author := flags.String("author", "", "scope the entry to one author")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	args = flags.Args()
+
+	file := dictPath(*path)
+	d, err := haikugo.LoadDictionary(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		d, err = haikugo.NewDictionary(), nil
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	switch action {
+	case "add":
+		if len(args) != 2 {
+			fmt.Fprintln(stderr, "usage: haikuctl dict add [--author name] word count")
+			return exitError
+		}
+		n, err := strconv.Atoi(args[1])
+		if err == nil {
+			err = d.Set(args[0], n, *author)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return exitError
+		}
+
+	case "remove":
+		if len(args) != 1 {
+			fmt.Fprintln(stderr, "usage: haikuctl dict remove [--author name] word")
+			return exitError
+		}
+		if !d.Remove(args[0], *author) {
+			fmt.Fprintf(stderr, "error: no override for %q\n", args[0])
+			return exitError
+		}
+
+	case "list":
+		tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		for _, e := range d.Entries() {
+			if *author != "" && !strings.EqualFold(e.Author, strings.TrimSpace(*author)) {
+				continue
+			}
+			fmt.Fprintf(tw, "%s\t%d", e.Word, e.Syllables)
+			if e.Author != "" {
+				fmt.Fprintf(tw, "\t%s", e.Author)
+			}
+			fmt.Fprintln(tw)
+		}
+		tw.Flush()
+		return exitValid
+
+	default:
+		fmt.Fprintf(stderr, "error: unknown dict command %q\n", action)
+		return exitError
+	}
+
+	if err := d.Save(file); err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+	return exitValid
+}
+
+// dictPath returns the dictionary file to use: the given path, the nearest
+// project dictionary, or DefaultDictionaryPath in the working directory.
+func dictPath(path string) string {
+	return projectPath(path, haikugo.FindDictionary, haikugo.DefaultDictionaryPath)
+}
+
+// loadOverrides loads the override dictionary for analysis. Without an
+// explicit path a missing project dictionary is not an error.
+func loadOverrides(path string) (*haikugo.Dictionary, error) {
+	return loadProjectFile(path, haikugo.FindDictionary, haikugo.LoadDictionary)
+}
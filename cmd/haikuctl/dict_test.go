@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunDict(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	steps := []struct {
+		args []string
+		want int
+		out  string
+	}{
+		{[]string{"dict", "add", "Ouachita", "3"}, exitValid, ""},
+		{[]string{"dict", "add", "--author", "Issa", "hour", "2"}, exitValid, ""},
+		{[]string{"dict", "add", "pond"}, exitError, ""},
+		{[]string{"dict", "add", "pond", "zero"}, exitError, ""},
+		{[]string{"dict", "list"}, exitValid, "ouachita  3\nhour      2  Issa\n"},
+		{[]string{"dict", "list", "--author", "Issa"}, exitValid, "hour  2  Issa\n"},
+		{[]string{"dict", "list", "--author", "issa"}, exitValid, "hour  2  Issa\n"},
+		{[]string{"dict", "remove", "ouachita"}, exitValid, ""},
+		{[]string{"dict", "remove", "ouachita"}, exitError, ""},
+		{[]string{"dict", "remove", "--author", "ISSA", "hour"}, exitValid, ""},
+		{[]string{"dict", "add", "--author", "Issa", "hour", "2"}, exitValid, ""},
+		{[]string{"dict", "list"}, exitValid, "hour  2  Issa\n"},
+		{[]string{"dict", "frobnicate"}, exitError, ""},
+	}
+
+	for _, step := range steps {
+		var stdout, stderr bytes.Buffer
+		code := run(step.args, strings.NewReader(""), &stdout, &stderr)
+		if code != step.want {
+			t.Fatalf("%v: exit code = %d, want %d (stderr: %s)", step.args, code, step.want, stderr.String())
+		}
+		if step.out != "" && stdout.String() != step.out {
+			t.Errorf("%v: output = %q, want %q", step.args, stdout.String(), step.out)
+		}
+	}
+}
+
+func TestRun_Overrides(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"dict", "add", "--author", "issa", "hour", "2"}, strings.NewReader(""), &stdout, &stderr); code != exitValid {
+		t.Fatalf("dict add failed: %s", stderr.String())
+	}
+
+	input := "an hour of silence\na frog jumps into the pond\nsplash silence again\n"
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, "Syllables per line: [5 7 5]"},
+		{[]string{"--author", "issa"}, "Syllables per line: [6 7 5]"},
+	}
+
+	for _, tt := range tests {
+		stdout.Reset()
+		if code := run(tt.args, strings.NewReader(input), &stdout, &stderr); code != exitValid {
+			t.Fatalf("%v: exit code = %d (stderr: %s)", tt.args, code, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), tt.want) {
+			t.Errorf("%v: output missing %q:\n%s", tt.args, tt.want, stdout.String())
+		}
+	}
+
+	if code := run([]string{"--dict", "missing.tsv"}, strings.NewReader(input), &stdout, &stderr); code != exitError {
+		t.Errorf("Missing --dict file: exit code = %d, want %d", code, exitError)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+// runFind scans a prose document for accidental haiku.
+func runFind(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("haikuctl find", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("file", "", "read the document from file instead of stdin")
+	format := fs.String("format", "auto", "document format: auto, text, markdown or html")
+	crossSentences := fs.Bool("cross-sentences", false, "also find haiku that start or end mid-sentence")
+	asJSON := fs.Bool("json", false, "output haiku as JSON")
+	dialectName := fs.String("dialect", "general-american", "pronunciation profile: general-american, rp, australian or southern-us")
+	dictFile := fs.String("dict", "", "syllable override dictionary (default: nearest .haikugo/syllables.tsv)")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+
+	markup := haikugo.MarkupForPath(*file)
+	if *format != "auto" {
+		var err error
+		if markup, err = haikugo.ParseMarkup(*format); err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return exitError
+		}
+	}
+
+	dialect, err := haikugo.ParseDialect(*dialectName)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	overrides, err := loadOverrides(*dictFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	text, err := readInput(*file, fs.Args(), stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	a := haikugo.NewAnalyzer(0)
+	a.SetOverrides(overrides)
+	a.SetDialect(dialect)
+	found := a.FindAccidental(text, haikugo.AccidentalOptions{Markup: markup, CrossSentences: *crossSentences})
+
+	if *asJSON {
+		if found == nil {
+			found = []haikugo.Accidental{}
+		}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(found); err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return exitError
+		}
+		return exitValid
+	}
+
+	printAccidental(stdout, found)
+	return exitValid
+}
+
+// printAccidental writes each haiku found under its source line and column.
+func printAccidental(w io.Writer, found []haikugo.Accidental) {
+	if len(found) == 0 {
+		fmt.Fprintln(w, "No haiku found.")
+		return
+	}
+	for i, h := range found {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		note := ""
+		if !h.Sentence {
+			note = " (crosses sentences)"
+		}
+		fmt.Fprintf(w, "%d:%d%s\n", h.Span.Line, h.Span.Column, note)
+		for _, line := range h.Lines {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunFind(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	prose := "We met Dr. Smith at noon. An old silent pond. A frog jumps into the pond. Splash! Silence again.\n"
+	page := filepath.Join(dir, "column.html")
+	if err := os.WriteFile(page, []byte("<h1>Column</h1>\n<p>An <em>old</em> silent pond. A frog jumps into the pond. Splash! Silence again.</p>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+		want  int
+		out   []string
+	}{
+		{"sentences", []string{"find"}, prose, exitValid, []string{"1:27\n  An old silent pond.\n  A frog jumps into the pond.\n  Splash! Silence again.\n"}},
+		{"cross sentences", []string{"find", "--cross-sentences"}, prose, exitValid, []string{"1:1 (crosses sentences)\n  We met Dr. Smith\n"}},
+		{"html file", []string{"find", "--file", page}, "", exitValid, []string{"2:4\n  An old silent pond."}},
+		{"html as text", []string{"find", "--file", page, "--format", "text"}, "", exitValid, []string{"No haiku found."}},
+		{"json", []string{"find", "--json"}, prose, exitValid, []string{`"sentence": true`, `"start": 26`}},
+		{"json none", []string{"find", "--json"}, "Nothing here.", exitValid, []string{"[]"}},
+		{"bad format", []string{"find", "--format", "pdf"}, prose, exitError, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr); code != tt.want {
+				t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, tt.want, stderr.String())
+			}
+			for _, want := range tt.out {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("Output missing %q:\n%s", want, stdout.String())
+				}
+			}
+		})
+	}
+}
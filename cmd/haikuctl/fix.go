@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+// runFix suggests rewrites for the lines of a haiku that are off 5-7-5, or
+// for a single line with --target.
+func runFix(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("haikuctl fix", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("file", "", "read haiku from file instead of stdin")
+	asJSON := fs.Bool("json", false, "output suggestions as JSON")
+	target := fs.Int("target", 0, "fix a single line to this many syllables")
+	maxRewrites := fs.Int("max", 5, "most rewrites shown per line")
+	tolerance := fs.Int("tolerant", 0, "allowed syllable deviation per line")
+	autosplit := fs.Bool("autosplit", false, "split single-line input into 3 lines")
+	normalize := fs.String("normalize", "nfc", "Unicode normalization: nfc, nfkc or none")
+	dialectName := fs.String("dialect", "general-american", "pronunciation profile: general-american, rp, australian or southern-us")
+	dictFile := fs.String("dict", "", "syllable override dictionary (default: nearest .haikugo/syllables.tsv)")
+	author := fs.String("author", "", "apply this author's syllable overrides")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+
+	if *target < 0 || *maxRewrites < 1 {
+		fmt.Fprintln(stderr, "error: --target must not be negative and --max must be at least 1")
+		return exitError
+	}
+	if *target > 0 && *author != "" {
+		fmt.Fprintln(stderr, "error: --author applies only to a whole haiku, not with --target")
+		return exitError
+	}
+
+	form, err := haikugo.ParseNormalization(*normalize)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	dialect, err := haikugo.ParseDialect(*dialectName)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	overrides, err := loadOverrides(*dictFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	text, err := readInput(*file, fs.Args(), stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	a := haikugo.NewAnalyzer(*tolerance)
+	a.SetOverrides(overrides)
+	a.SetDialect(dialect)
+
+	var fixes []haikugo.LineFix
+	if *target > 0 {
+		line := strings.TrimSpace(haikugo.NormalizeText(text, form))
+		if strings.ContainsAny(line, "\r\n") {
+			fmt.Fprintln(stderr, "error: --target takes a single line")
+			return exitError
+		}
+		fixes = []haikugo.LineFix{a.FixLine(line, *target)}
+	} else {
+		h, err := haikugo.ParseHaikuWithOptions(text, haikugo.ParseOptions{Autosplit: *autosplit, Normalization: form})
+		if err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return exitError
+		}
+		h.SetAuthor(*author)
+		fixes = a.Fix(h)
+	}
+
+	for i := range fixes {
+		if len(fixes[i].Rewrites) > *maxRewrites {
+			fixes[i].Rewrites = fixes[i].Rewrites[:*maxRewrites]
+		}
+	}
+
+	if *asJSON {
+		if fixes == nil {
+			fixes = []haikugo.LineFix{}
+		}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(fixes); err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return exitError
+		}
+		return exitValid
+	}
+
+	printFixes(stdout, fixes)
+	return exitValid
+}
+
+// printFixes writes each line that is off its target followed by its
+// rewrites, one per line with the new count and the edits made.
+func printFixes(w io.Writer, fixes []haikugo.LineFix) {
+	if len(fixes) == 0 {
+		fmt.Fprintln(w, "All lines match 5-7-5.")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, f := range fixes {
+		noun := "syllables"
+		if f.Syllables == 1 {
+			noun = "syllable"
+		}
+		fmt.Fprintf(tw, "Line %d: %q has %d %s, want %d\n", f.Line, f.Text, f.Syllables, noun, f.Target)
+		switch {
+		case f.Syllables == f.Target:
+			fmt.Fprintln(tw, "  already on target")
+		case len(f.Rewrites) == 0:
+			fmt.Fprintln(tw, "  no suggestions")
+		}
+		for _, r := range f.Rewrites {
+			edits := make([]string, len(r.Edits))
+			for i, e := range r.Edits {
+				edits[i] = fmt.Sprintf("%s %q -> %q", e.Kind, e.From, e.To)
+			}
+			fmt.Fprintf(tw, "  %d\t%s\t%s\n", r.Syllables, r.Text, strings.Join(edits, "; "))
+		}
+	}
+	tw.Flush()
+}
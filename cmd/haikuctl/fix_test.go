@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunFix(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	poem := "an old silent pond\na frog jumps into the cold pond\nsplash"
+
+	tests := []struct {
+		name  string
+		args  []string
+		input string
+		want  int
+		out   []string
+	}{
+		{"haiku", []string{"fix"}, poem, exitValid, []string{
+			`Line 2: "a frog jumps into the cold pond" has 8 syllables, want 7`,
+			`  7  frog jumps into the cold pond  drop-article "a frog" -> "frog"`,
+			`Line 3: "splash" has 1 syllable, want 5`,
+		}},
+		{"valid", []string{"fix"}, "an old silent pond\na frog jumps into the pond\nsplash! silence again", exitValid, []string{"All lines match 5-7-5."}},
+		{"tolerant", []string{"fix", "--tolerant", "1"}, poem, exitValid, []string{"Line 3:"}},
+		{"target", []string{"fix", "--target", "7", "--max", "1"}, "I do not hear the quiet river", exitValid, []string{
+			`Line 1: "I do not hear the quiet river" has 9 syllables, want 7`,
+			`I don't hear quiet river  contract "do not" -> "don't"; drop-article "the quiet" -> "quiet"`,
+		}},
+		{"on target", []string{"fix", "--target", "2"}, "old pond", exitValid, []string{"already on target"}},
+		{"json", []string{"fix", "--json", "--target", "3"}, "old pond", exitValid, []string{`"kind": "add-article"`, `"to": "the old pond"`}},
+		{"json valid", []string{"fix", "--json"}, "an old silent pond\na frog jumps into the pond\nsplash! silence again", exitValid, []string{"[]"}},
+		{"multiline target", []string{"fix", "--target", "5"}, poem, exitError, nil},
+		{"author with target", []string{"fix", "--target", "5", "--author", "basho"}, "old pond", exitError, nil},
+		{"bad max", []string{"fix", "--max", "0"}, poem, exitError, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, strings.NewReader(tt.input), &stdout, &stderr); code != tt.want {
+				t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, tt.want, stderr.String())
+			}
+			for _, want := range tt.out {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("Output missing %q:\n%s", want, stdout.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+// runGenerate writes haiku generated from the grammar templates, or sampled
+// from a Markov model built by train.
+func runGenerate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("haikuctl generate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	season := fs.String("season", "", "season of the season word: spring, summer, autumn, winter or all (default: any)")
+	sense := fs.String("sense", "", "sense the images engage: sight, sound, touch, smell or taste")
+	model := fs.String("model", "", "sample from this Markov model instead of the templates")
+	maxOverlap := fs.Float64("max-overlap", haikugo.DefaultMaxOverlap, "with --model, reject poems sharing this share of their n-grams with a training poem")
+	count := fs.Int("count", 1, "number of poems")
+	seed := fs.Uint64("seed", 0, "random seed; the same seed gives the same poems (default: random)")
+	asJSON := fs.Bool("json", false, "output poems with their templates and analysis as JSON")
+	dialectName := fs.String("dialect", "general-american", "pronunciation profile: general-american, rp, australian or southern-us")
+	dictFile := fs.String("dict", "", "syllable override dictionary (default: nearest .haikugo/syllables.tsv)")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "error: unexpected argument %q\n", fs.Arg(0))
+		return exitError
+	}
+	if *model != "" && (*season != "" || *sense != "") {
+		fmt.Fprintln(stderr, "error: --season and --sense apply only to the templates, not --model")
+		return exitError
+	}
+	if *count < 1 {
+		fmt.Fprintln(stderr, "error: --count must be at least 1")
+		return exitError
+	}
+
+	seeded := false
+	fs.Visit(func(f *flag.Flag) { seeded = seeded || f.Name == "seed" })
+	if !seeded {
+		*seed = rand.Uint64()
+	}
+
+	dialect, err := haikugo.ParseDialect(*dialectName)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	overrides, err := loadOverrides(*dictFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	a := haikugo.NewAnalyzer(0)
+	a.SetOverrides(overrides)
+	a.SetDialect(dialect)
+	var poems []haikugo.Poem
+	if *model != "" {
+		var m *haikugo.MarkovModel
+		m, err = haikugo.LoadMarkovModel(*model)
+		if err == nil {
+			poems, err = a.GenerateMarkov(m, haikugo.MarkovOptions{Seed: *seed, Count: *count, MaxOverlap: maxOverlap})
+		}
+	} else {
+		poems, err = a.Generate(haikugo.GenerateOptions{Season: *season, Sense: *sense, Seed: *seed, Count: *count})
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(poems); err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return exitError
+		}
+		return exitValid
+	}
+
+	for i, p := range poems {
+		if i > 0 {
+			fmt.Fprintln(stdout)
+		}
+		for _, line := range p.Lines {
+			fmt.Fprintln(stdout, line)
+		}
+	}
+	return exitValid
+}
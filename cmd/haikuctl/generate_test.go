@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+func TestRunGenerate(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	tests := []struct {
+		name  string
+		args  []string
+		want  int
+		poems int
+	}{
+		{"count", []string{"generate", "--season", "autumn", "--count", "10", "--seed", "1"}, exitValid, 10},
+		{"default", []string{"generate"}, exitValid, 1},
+		{"sense", []string{"generate", "--sense", "sound", "--count", "3", "--seed", "2"}, exitValid, 3},
+		{"bad season", []string{"generate", "--season", "monsoon"}, exitError, 0},
+		{"bad sense", []string{"generate", "--sense", "hearing"}, exitError, 0},
+		{"bad count", []string{"generate", "--count", "0"}, exitError, 0},
+		{"argument", []string{"generate", "autumn"}, exitError, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, strings.NewReader(""), &stdout, &stderr); code != tt.want {
+				t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, tt.want, stderr.String())
+			}
+			if tt.poems == 0 {
+				return
+			}
+			poems := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n\n")
+			if len(poems) != tt.poems {
+				t.Fatalf("Output has %d poems, want %d:\n%s", len(poems), tt.poems, stdout.String())
+			}
+			for _, p := range poems {
+				if n := len(strings.Split(p, "\n")); n != 3 {
+					t.Errorf("Poem %q has %d lines, want 3", p, n)
+				}
+			}
+		})
+	}
+}
+
+func TestRunGenerate_Seed(t *testing.T) {
+	t.Chdir(t.TempDir())
+	output := func(args ...string) string {
+		var stdout, stderr bytes.Buffer
+		if code := run(append([]string{"generate"}, args...), strings.NewReader(""), &stdout, &stderr); code != exitValid {
+			t.Fatalf("Exit code = %d (stderr: %s)", code, stderr.String())
+		}
+		return stdout.String()
+	}
+
+	if first, again := output("--seed", "7", "--count", "3"), output("--seed", "7", "--count", "3"); first != again {
+		t.Errorf("--seed 7 gave\n%s\nthen\n%s", first, again)
+	}
+
+	var poems []haikugo.Poem
+	if err := json.Unmarshal([]byte(output("--json", "--season", "winter", "--seed", "7", "--count", "2")), &poems); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	for _, p := range poems {
+		if p.Season != haikugo.SeasonWinter || p.Metrics == nil || !p.Metrics.Valid575 || len(p.Templates) != 2 {
+			t.Errorf("JSON poem = %+v, want a valid winter poem with its templates", p)
+		}
+	}
+}
+
+func TestRunGenerate_Model(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("poems.txt", []byte(trainingCorpus), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"train", "--order", "1", "poems.txt"}, strings.NewReader(""), &stdout, &stderr); code != exitValid {
+		t.Fatalf("train exit code = %d (stderr: %s)", code, stderr.String())
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"json", []string{"generate", "--model", "markov.json", "--count", "2", "--seed", "4", "--json"}, exitValid},
+		{"copies allowed", []string{"generate", "--model", "markov.json", "--count", "2", "--seed", "4", "--max-overlap", "1", "--json"}, exitValid},
+		{"season", []string{"generate", "--model", "markov.json", "--season", "autumn"}, exitError},
+		{"missing model", []string{"generate", "--model", "missing.json"}, exitError},
+		{"bad overlap", []string{"generate", "--model", "markov.json", "--max-overlap", "2"}, exitError},
+		{"zero overlap", []string{"generate", "--model", "markov.json", "--max-overlap", "0"}, exitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, strings.NewReader(""), &stdout, &stderr); code != tt.want {
+				t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, tt.want, stderr.String())
+			}
+			if tt.want != exitValid {
+				return
+			}
+			var poems []haikugo.Poem
+			if err := json.Unmarshal(stdout.Bytes(), &poems); err != nil {
+				t.Fatalf("Invalid JSON: %v", err)
+			}
+			if len(poems) != 2 {
+				t.Fatalf("Got %d poems, want 2", len(poems))
+			}
+			for _, p := range poems {
+				if p.Metrics == nil || !p.Metrics.Valid575 || p.Templates != nil {
+					t.Errorf("JSON poem = %+v, want a valid poem without templates", p)
+				}
+			}
+		})
+	}
+}
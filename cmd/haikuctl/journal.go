@@ -0,0 +1,307 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+// dateLayout is the format of --since and --until.
+const dateLayout = "2006-01-02"
+
+// runJournal saves poems to the project journal and lists, searches and
+// shows its entries.
+func runJournal(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "usage: haikuctl journal add|list|search|show [flags] [args]")
+		return exitError
+	}
+
+	action, args := args[0], args[1:]
+	fs := flag.NewFlagSet("haikuctl journal "+action, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	path := fs.String("journal", "", "journal file (default: nearest "+haikugo.DefaultJournalPath+")")
+
+	switch action {
+	case "add":
+		return runJournalAdd(fs, path, args, stdin, stdout, stderr)
+	case "list", "search", "show":
+	default:
+		fmt.Fprintf(stderr, "error: unknown journal command %q\n", action)
+		return exitError
+	}
+
+	asJSON := fs.Bool("json", false, "output entries as JSON")
+
+	var q haikugo.JournalQuery
+	var since, until string
+	var valid, invalid bool
+	if action == "search" {
+		fs.StringVar(&q.Text, "text", "", "words that must start words of the poem, title, note or tags")
+		fs.StringVar(&q.Season, "season", "", "spring, summer, autumn, winter or all")
+		fs.StringVar(&q.Form, "form", "", "syllable pattern, such as 5-7-5")
+		fs.BoolVar(&valid, "valid", false, "only poems valid within their tolerance")
+		fs.BoolVar(&invalid, "invalid", false, "only poems that are not valid")
+		fs.StringVar(&q.Tag, "tag", "", "only entries with this tag")
+		fs.StringVar(&since, "since", "", "only entries saved on or after this date (YYYY-MM-DD)")
+		fs.StringVar(&until, "until", "", "only entries saved on or before this date (YYYY-MM-DD)")
+		fs.Float64Var(&q.MinScore, "min-score", 0, "lowest overall score")
+		fs.Float64Var(&q.MaxScore, "max-score", 0, "highest overall score (0 for no limit)")
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+
+	j, err := haikugo.OpenJournal(journalPath(*path))
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	var entries []*haikugo.JournalEntry
+	switch action {
+	case "list":
+		if fs.NArg() != 0 {
+			fmt.Fprintln(stderr, "usage: haikuctl journal list [--json]")
+			return exitError
+		}
+		entries = j.Entries()
+
+	case "search":
+		q.Text = strings.Join(append([]string{q.Text}, fs.Args()...), " ")
+		err := setValidity(&q, valid, invalid)
+		if err == nil {
+			q.Since, q.Until, err = parseDateRange(since, until)
+		}
+		if err == nil {
+			entries, err = j.Search(q)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return exitError
+		}
+
+	case "show":
+		if fs.NArg() != 1 {
+			fmt.Fprintln(stderr, "usage: haikuctl journal show [--json] id")
+			return exitError
+		}
+		e, ok := j.Get(fs.Arg(0))
+		if !ok {
+			fmt.Fprintf(stderr, "error: no journal entry %q\n", fs.Arg(0))
+			return exitError
+		}
+		if !*asJSON {
+			printJournalEntry(stdout, e)
+			return exitValid
+		}
+		entries = []*haikugo.JournalEntry{e}
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		var v any = entries
+		if action == "show" {
+			v = entries[0]
+		} else if entries == nil {
+			v = []*haikugo.JournalEntry{}
+		}
+		if err := enc.Encode(v); err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return exitError
+		}
+		return exitValid
+	}
+	printJournalEntries(stdout, entries)
+	return exitValid
+}
+
+// runJournalAdd analyzes a poem and saves it as a new entry or, with
+// --revise, as the next revision of an existing one.
+func runJournalAdd(fs *flag.FlagSet, path *string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	file := fs.String("file", "", "read the poem from file instead of stdin")
+	revise := fs.String("revise", "", "save as a new revision of this entry")
+	title := fs.String("title", "", "entry title")
+	author := fs.String("author", "", "poem author, also used for syllable overrides")
+	tags := fs.String("tags", "", "comma-separated tags")
+	note := fs.String("note", "", "note on this revision")
+	tolerance := fs.Int("tolerant", 0, "allowed syllable deviation per line")
+	autosplit := fs.Bool("autosplit", false, "split single-line input into 3 lines")
+	normalize := fs.String("normalize", "nfc", "Unicode normalization: nfc, nfkc or none")
+	dialectName := fs.String("dialect", "general-american", "pronunciation profile: general-american, rp, australian or southern-us")
+	dictFile := fs.String("dict", "", "syllable override dictionary (default: nearest .haikugo/syllables.tsv)")
+	rubricFile := fs.String("rubric", "", "scoring rubric (default: nearest .haikugo/rubric.tsv)")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+
+	text, err := readInput(*file, fs.Args(), stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+	form, err := haikugo.ParseNormalization(*normalize)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+	h, err := haikugo.ParseHaikuWithOptions(text, haikugo.ParseOptions{Autosplit: *autosplit, Normalization: form})
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+	dialect, err := haikugo.ParseDialect(*dialectName)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+	overrides, err := loadOverrides(*dictFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+	rubric, err := loadRubric(*rubricFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+	j, err := haikugo.OpenJournal(journalPath(*path))
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	// A revision keeps the author of its entry, whose syllable overrides
+	// apply to it unless --author names another.
+	if *revise != "" && *author == "" {
+		if prev, ok := j.Get(*revise); ok {
+			*author = prev.Author
+		}
+	}
+
+	a := haikugo.NewAnalyzer(*tolerance)
+	a.SetOverrides(overrides)
+	a.SetDialect(dialect)
+	a.SetRubric(rubric)
+	h.SetAuthor(*author)
+	d := a.Draft(h)
+	d.Title, d.Note = *title, *note
+	if *tags != "" {
+		d.Tags = strings.Split(*tags, ",")
+	}
+
+	var e *haikugo.JournalEntry
+	if *revise != "" {
+		e, err = j.Revise(*revise, d)
+	} else {
+		e, err = j.Add(d)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+	fmt.Fprintf(stdout, "Saved entry %s (revision %d)\n", e.ID, e.Latest().Number)
+	return exitValid
+}
+
+// journalPath returns the journal file to use: the given path, the nearest
+// project journal, or DefaultJournalPath in the working directory.
+func journalPath(path string) string {
+	return projectPath(path, haikugo.FindJournal, haikugo.DefaultJournalPath)
+}
+
+// setValidity sets the query's validity filter from --valid and --invalid.
+func setValidity(q *haikugo.JournalQuery, valid, invalid bool) error {
+	switch {
+	case valid && invalid:
+		return errors.New("--valid and --invalid are mutually exclusive")
+	case valid || invalid:
+		q.Valid = &valid
+	}
+	return nil
+}
+
+// parseDateRange parses --since and --until dates. The range includes the
+// whole of the until day.
+func parseDateRange(since, until string) (from, to time.Time, err error) {
+	if since != "" {
+		if from, err = time.Parse(dateLayout, since); err != nil {
+			return from, to, fmt.Errorf("invalid --since date %q (want YYYY-MM-DD)", since)
+		}
+	}
+	if until != "" {
+		if to, err = time.Parse(dateLayout, until); err != nil {
+			return from, to, fmt.Errorf("invalid --until date %q (want YYYY-MM-DD)", until)
+		}
+		to = to.AddDate(0, 0, 1)
+	}
+	return from, to, nil
+}
+
+// journalSummary returns the form, validity and score of a revision.
+func journalSummary(rev haikugo.JournalRevision) (form, valid, score string) {
+	m := rev.Metrics
+	if m == nil {
+		return "-", "-", "-"
+	}
+	valid = "invalid"
+	if m.Valid575 {
+		valid = "valid"
+	}
+	parts := make([]string, len(m.LineSyllables))
+	for i, n := range m.LineSyllables {
+		parts[i] = fmt.Sprint(n)
+	}
+	return strings.Join(parts, "-"), valid, fmt.Sprintf("%.1f", m.Score.Overall)
+}
+
+// printJournalEntries writes one row per entry: its ID, last saved date,
+// revision count, form, validity, score and poem.
+func printJournalEntries(w io.Writer, entries []*haikugo.JournalEntry) {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No journal entries found.")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, e := range entries {
+		rev := e.Latest()
+		form, valid, score := journalSummary(rev)
+		fmt.Fprintf(tw, "%s\t%s\tr%d\t%s\t%s\t%s\t%s\n", e.ID, e.Updated.Format(dateLayout), rev.Number, form, valid, score, strings.Join(rev.Lines, " / "))
+	}
+	tw.Flush()
+}
+
+// printJournalEntry writes an entry's metadata followed by each of its
+// revisions, newest first.
+func printJournalEntry(w io.Writer, e *haikugo.JournalEntry) {
+	fmt.Fprintf(w, "Entry %s", e.ID)
+	if e.Title != "" {
+		fmt.Fprintf(w, ": %s", e.Title)
+	}
+	fmt.Fprintln(w)
+	if e.Author != "" {
+		fmt.Fprintf(w, "Author: %s\n", e.Author)
+	}
+	if len(e.Tags) > 0 {
+		fmt.Fprintf(w, "Tags: %s\n", strings.Join(e.Tags, ", "))
+	}
+	for i := len(e.Revisions) - 1; i >= 0; i-- {
+		rev := e.Revisions[i]
+		form, valid, score := journalSummary(rev)
+		fmt.Fprintf(w, "\nRevision %d, %s: %s, %s, score %s\n", rev.Number, rev.Saved.Format("2006-01-02 15:04"), form, valid, score)
+		if rev.Note != "" {
+			fmt.Fprintf(w, "Note: %s\n", rev.Note)
+		}
+		for _, line := range rev.Lines {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+func TestRunJournal(t *testing.T) {
+	t.Chdir(t.TempDir())
+	today := time.Now().UTC().Format(dateLayout)
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format(dateLayout)
+
+	steps := []struct {
+		name       string
+		args       []string
+		stdin      string
+		want       int
+		wantOut    []string
+		wantStderr string
+	}{
+		{"add", []string{"journal", "add", "--title", "Old pond", "--tags", "classic, frogs", "--author", "Basho"}, "an old silent pond\na frog jumps into the pond\nsplash silence again", exitValid, []string{"Saved entry 1 (revision 1)"}, ""},
+		{"add second", []string{"journal", "add"}, "snow\non the frog\nsilent", exitValid, []string{"Saved entry 2 (revision 1)"}, ""},
+		{"revise", []string{"journal", "add", "--revise", "1", "--note", "quieter"}, "an old quiet pond\na frog leaps into the pond\nsplash! silence again", exitValid, []string{"Saved entry 1 (revision 2)"}, ""},
+		{"revise missing", []string{"journal", "add", "--revise", "9"}, "a\nb\nc", exitError, nil, `no journal entry "9"`},
+		{"list", []string{"journal", "list"}, "", exitValid, []string{"1  " + today + "  r2  5-7-5  valid", "an old quiet pond / a frog", "2  " + today + "  r1  1-3-2  invalid"}, ""},
+		{"search text", []string{"journal", "search", "quiet"}, "", exitValid, []string{"an old quiet pond"}, ""},
+		{"search filters", []string{"journal", "search", "--tag", "classic", "--form", "5-7-5", "--valid", "--since", today, "--until", today}, "", exitValid, []string{"an old quiet pond"}, ""},
+		{"search winter", []string{"journal", "search", "--season", "winter", "--invalid"}, "", exitValid, []string{"snow / on the frog"}, ""},
+		{"search none", []string{"journal", "search", "--since", tomorrow}, "", exitValid, []string{"No journal entries found."}, ""},
+		{"search both validities", []string{"journal", "search", "--valid", "--invalid"}, "", exitError, nil, "mutually exclusive"},
+		{"search bad date", []string{"journal", "search", "--until", "yesterday"}, "", exitError, nil, "--until"},
+		{"search bad season", []string{"journal", "search", "--season", "monsoon"}, "", exitError, nil, "error:"},
+		{"show", []string{"journal", "show", "1"}, "", exitValid, []string{"Entry 1: Old pond", "Author: Basho", "Tags: classic, frogs", "Revision 2", "Note: quieter", "  an old silent pond"}, ""},
+		{"show missing", []string{"journal", "show", "7"}, "", exitError, nil, "no journal entry"},
+		{"no action", []string{"journal"}, "", exitError, nil, "usage:"},
+		{"unknown action", []string{"journal", "drop"}, "", exitError, nil, "unknown journal command"},
+	}
+
+	for _, tt := range steps {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr); code != tt.want {
+				t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, tt.want, stderr.String())
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("Output missing %q:\n%s", want, stdout.String())
+				}
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("Stderr = %q, want %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestRunJournal_Revise(t *testing.T) {
+	t.Chdir(t.TempDir())
+	var stdout, stderr bytes.Buffer
+	steps := [][]string{
+		{"dict", "add", "--author", "Issa", "hour", "2"},
+		{"journal", "add", "--author", "Issa", "--autosplit", "the hour of dusk / a frog jumps into the pond / splash silence again"},
+		{"journal", "add", "--revise", "1", "--autosplit", "in the last hour / a frog jumps into the pond / splash silence again"},
+	}
+	for _, args := range steps {
+		if code := run(args, strings.NewReader(""), &stdout, &stderr); code != exitValid {
+			t.Fatalf("%v exit code = %d (stderr: %s)", args, code, stderr.String())
+		}
+	}
+
+	stdout.Reset()
+	if code := run([]string{"journal", "show", "--json", "1"}, strings.NewReader(""), &stdout, &stderr); code != exitValid {
+		t.Fatalf("Show exit code = %d (stderr: %s)", code, stderr.String())
+	}
+	var e haikugo.JournalEntry
+	if err := json.Unmarshal(stdout.Bytes(), &e); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, stdout.String())
+	}
+	for _, r := range e.Revisions {
+		if len(r.Lines) != 3 || r.Metrics.LineSyllables[0] != 5 {
+			t.Errorf("Revision %d = %q counted %v, want 3 lines with Issa's 2-syllable hour", r.Number, r.Lines, r.Metrics.LineSyllables)
+		}
+	}
+	if e.Author != "Issa" {
+		t.Errorf("Author = %q, want Issa", e.Author)
+	}
+}
+
+func TestRunJournal_JSON(t *testing.T) {
+	t.Chdir(t.TempDir())
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"journal", "search", "--json", "pond"}, strings.NewReader(""), &stdout, &stderr); code != exitValid || strings.TrimSpace(stdout.String()) != "[]" {
+		t.Fatalf("Empty search = %d, %q, want []", code, stdout.String())
+	}
+
+	if code := run([]string{"journal", "add", "--journal", "poems.jsonl", "--tags", "classic"}, strings.NewReader("an old silent pond\na frog jumps into the pond\nsplash silence again"), &stdout, &stderr); code != exitValid {
+		t.Fatalf("Add exit code = %d (stderr: %s)", code, stderr.String())
+	}
+	stdout.Reset()
+	if code := run([]string{"journal", "show", "--journal", "poems.jsonl", "--json", "1"}, strings.NewReader(""), &stdout, &stderr); code != exitValid {
+		t.Fatalf("Show exit code = %d (stderr: %s)", code, stderr.String())
+	}
+	var e haikugo.JournalEntry
+	if err := json.Unmarshal(stdout.Bytes(), &e); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if e.ID != "1" || len(e.Revisions) != 1 || e.Tags[0] != "classic" || e.Latest().Metrics == nil || !e.Latest().Metrics.Valid575 {
+		t.Errorf("Show JSON = %+v, want entry 1 with its analysis", e)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+// runLint checks a single haiku against the craft rules and exits non-zero
+// when a finding reaches the --fail-on severity.
+func runLint(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("haikuctl lint", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("file", "", "read haiku from file instead of stdin")
+	asJSON := fs.Bool("json", false, "output findings as JSON")
+	rulesFile := fs.String("rules", "", "rules file (default: nearest "+haikugo.DefaultLintConfigPath+")")
+	failOn := fs.String("fail-on", "error", "exit 2 when a finding is at least this severe: info, warning, error or off")
+	list := fs.Bool("list", false, "list the rules and their severities")
+	tolerance := fs.Int("tolerant", 0, "allowed syllable deviation per line")
+	autosplit := fs.Bool("autosplit", false, "split single-line input into 3 lines")
+	normalize := fs.String("normalize", "nfc", "Unicode normalization: nfc, nfkc or none")
+	dialectName := fs.String("dialect", "general-american", "pronunciation profile: general-american, rp, australian or southern-us")
+	dictFile := fs.String("dict", "", "syllable override dictionary (default: nearest .haikugo/syllables.tsv)")
+	author := fs.String("author", "", "apply this author's syllable overrides")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+
+	threshold, err := haikugo.ParseSeverity(*failOn)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	config, err := loadLintConfig(*rulesFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	if *list {
+		printRules(stdout, config)
+		return exitValid
+	}
+
+	form, err := haikugo.ParseNormalization(*normalize)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	text, err := readInput(*file, fs.Args(), stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	h, err := haikugo.ParseHaikuWithOptions(text, haikugo.ParseOptions{Autosplit: *autosplit, Normalization: form})
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	dialect, err := haikugo.ParseDialect(*dialectName)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	overrides, err := loadOverrides(*dictFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	a := haikugo.NewAnalyzer(*tolerance)
+	a.SetOverrides(overrides)
+	a.SetDialect(dialect)
+	h.SetAuthor(*author)
+	findings := haikugo.Lint(h, a.Analyze(h), config)
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(findings); err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return exitError
+		}
+	} else {
+		printFindings(stdout, findings)
+	}
+
+	if haikugo.MaxSeverity(findings).AtLeast(threshold) {
+		return exitInvalid
+	}
+	return exitValid
+}
+
+// printFindings writes one "line:column: severity: message [rule]" line per finding.
+func printFindings(w io.Writer, findings []haikugo.LintFinding) {
+	if len(findings) == 0 {
+		fmt.Fprintln(w, "No findings.")
+		return
+	}
+	for _, f := range findings {
+		fmt.Fprintf(w, "%d:%d: %s: %s [%s]\n", f.Span.Line, f.Span.Column, f.Severity, f.Message, f.Rule)
+	}
+}
+
+// printRules lists every rule with its configured severity.
+func printRules(w io.Writer, config *haikugo.LintConfig) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, r := range haikugo.LintRules() {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.ID, config.Severity(r), r.Message)
+	}
+	tw.Flush()
+}
+
+// loadLintConfig loads the rules file for linting. Without an explicit
+// path a missing project rules file is not an error.
+func loadLintConfig(path string) (*haikugo.LintConfig, error) {
+	return loadProjectFile(path, haikugo.FindLintConfig, haikugo.LoadLintConfig)
+}
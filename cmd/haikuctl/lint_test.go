@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunLint(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	poem := "I feel so sad\nmy heart is like a stone\nthe cold dark lonely night"
+	clean := "plum petals drifting\nover the temple courtyard\na child chasing one"
+
+	tests := []struct {
+		name  string
+		args  []string
+		input string
+		want  int
+		out   []string
+	}{
+		{"errors fail", []string{"lint"}, poem, exitInvalid, []string{
+			"1:1: error: line has 4 syllables, want 5 [syllable-mismatch]",
+			`1:11: warning: "sad" names an emotion instead of showing it [telling-emotion]`,
+			"2:13: info: simile;",
+		}},
+		{"clean", []string{"lint", "--fail-on", "info"}, clean, exitValid, []string{"No findings."}},
+		{"fail on off", []string{"lint", "--fail-on", "off"}, poem, exitValid, nil},
+		{"bad severity", []string{"lint", "--fail-on", "fatal"}, poem, exitError, nil},
+		{"json", []string{"lint", "--json"}, poem, exitInvalid, []string{`"rule": "telling-emotion"`, `"severity": "warning"`}},
+		{"list", []string{"lint", "--list"}, "", exitValid, []string{"syllable-mismatch   error    line does not match the 5-7-5 pattern"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, strings.NewReader(tt.input), &stdout, &stderr); code != tt.want {
+				t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, tt.want, stderr.String())
+			}
+			for _, want := range tt.out {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("Output missing %q:\n%s", want, stdout.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRunLint_RulesFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll(".haikugo", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	rules := "syllable-mismatch\toff\ntelling-emotion\terror\n"
+	if err := os.WriteFile(filepath.Join(".haikugo", "lint.tsv"), []byte(rules), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"lint"}, strings.NewReader("silent winter night\nthe old pond is sad tonight\nsnow"), &stdout, &stderr)
+	if code != exitInvalid {
+		t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, exitInvalid, stderr.String())
+	}
+	out := stdout.String()
+	if strings.Contains(out, "syllable-mismatch") {
+		t.Errorf("Disabled rule reported:\n%s", out)
+	}
+	if !strings.Contains(out, "error: \"sad\" names an emotion") {
+		t.Errorf("telling-emotion not raised to error:\n%s", out)
+	}
+
+	stdout.Reset()
+	if code := run([]string{"lint", "--rules", "missing.tsv"}, strings.NewReader(""), &stdout, &stderr); code != exitError {
+		t.Errorf("Missing --rules file exit code = %d, want %d", code, exitError)
+	}
+}
@@ -0,0 +1,361 @@
+// Command haikuctl analyzes and validates haiku from the command line.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+// version is set at build time via -ldflags.
+var version = "dev"
+
+// Exit codes used with --exit-code and by subcommands.
+const (
+	exitValid   = 0
+	exitError   = 1
+	exitInvalid = 2
+)
+
+// commands maps subcommand names to their entry points.
+var commands = map[string]func(args []string, stdin io.Reader, stdout, stderr io.Writer) int{
+	"batch":    runBatch,
+	"dedupe":   runDedupe,
+	"dict":     runDict,
+	"find":     runFind,
+	"fix":      runFix,
+	"generate": runGenerate,
+	"journal":  runJournal,
+	"lint":     runLint,
+	"stats":    runStats,
+	"train":    runTrain,
+	"words":    runWords,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run dispatches to a subcommand or performs single-poem analysis.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) > 0 {
+		if cmd, ok := commands[args[0]]; ok {
+			return cmd(args[1:], stdin, stdout, stderr)
+		}
+	}
+	return runAnalyze(args, stdin, stdout, stderr)
+}
+
+// runAnalyze analyzes a single haiku from a file, inline text or stdin.
+func runAnalyze(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("haikuctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("file", "", "read haiku from file instead of stdin")
+	asJSON := fs.Bool("json", false, "output JSON instead of human-readable text")
+	tolerance := fs.Int("tolerant", 0, "allowed syllable deviation per line")
+	exitCode := fs.Bool("exit-code", false, "exit 0 when valid, 2 when invalid, 1 on error")
+	autosplit := fs.Bool("autosplit", false, "split single-line input into 3 lines")
+	normalize := fs.String("normalize", "nfc", "Unicode normalization: nfc, nfkc or none")
+	explain := fs.Bool("explain", false, "show how each word's syllables were counted")
+	syllabify := fs.Bool("syllabify", false, "show each line divided into syllables")
+	meter := fs.Bool("meter", false, "show each line's stress pattern and meter")
+	scoreView := fs.Bool("score", false, "show the quality score and its sub-scores")
+	rubricFile := fs.String("rubric", "", "scoring rubric (default: nearest .haikugo/rubric.tsv)")
+	dialectName := fs.String("dialect", "general-american", "pronunciation profile: general-american, rp, australian or southern-us")
+	dictFile := fs.String("dict", "", "syllable override dictionary (default: nearest .haikugo/syllables.tsv)")
+	author := fs.String("author", "", "apply this author's syllable overrides")
+	showVersion := fs.Bool("version", false, "print version and exit")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+
+	if *showVersion {
+		fmt.Fprintf(stdout, "haikuctl %s\n", version)
+		return exitValid
+	}
+
+	form, err := haikugo.ParseNormalization(*normalize)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	text, err := readInput(*file, fs.Args(), stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	h, err := haikugo.ParseHaikuWithOptions(text, haikugo.ParseOptions{Autosplit: *autosplit, Normalization: form})
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	dialect, err := haikugo.ParseDialect(*dialectName)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	overrides, err := loadOverrides(*dictFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	rubric, err := loadRubric(*rubricFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	a := haikugo.NewAnalyzer(*tolerance)
+	a.SetOverrides(overrides)
+	a.SetDialect(dialect)
+	a.SetRubric(rubric)
+	h.SetAuthor(*author)
+	metrics := a.Analyze(h)
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(metrics); err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return exitError
+		}
+	} else {
+		switch {
+		case *syllabify:
+			printSyllabified(stdout, metrics)
+		case *meter:
+			printMeter(stdout, metrics)
+		case *scoreView:
+			printScore(stdout, metrics)
+		default:
+			printMetrics(stdout, metrics)
+		}
+		if *explain {
+			printExplain(stdout, metrics)
+		}
+	}
+
+	if *exitCode && !metrics.Valid575 {
+		return exitInvalid
+	}
+	return exitValid
+}
+
+// loadRubric loads the scoring rubric. Without an explicit path a missing
+// project rubric is not an error and the default weights are used.
+func loadRubric(path string) (*haikugo.Rubric, error) {
+	return loadProjectFile(path, haikugo.FindRubric, haikugo.LoadRubric)
+}
+
+// projectPath returns the given path, the nearest project file found by
+// find, or def in the working directory, for commands that write the file.
+func projectPath(path string, find func(dir string) (string, bool), def string) string {
+	if path != "" {
+		return path
+	}
+	if found, ok := find("."); ok {
+		return found
+	}
+	return def
+}
+
+// loadProjectFile loads the file at path or, without one, the nearest
+// project file found by find. A missing project file is not an error and
+// loads as the zero value.
+func loadProjectFile[T any](path string, find func(dir string) (string, bool), load func(path string) (T, error)) (T, error) {
+	if path == "" {
+		found, ok := find(".")
+		if !ok {
+			var zero T
+			return zero, nil
+		}
+		path = found
+	}
+	return load(path)
+}
+
+// readInput returns poem text from a file, inline arguments or stdin, in that order.
+// File and stdin input is transcoded to UTF-8 when another encoding is detected.
+func readInput(file string, inline []string, stdin io.Reader) (string, error) {
+	if file != "" {
+		content, err := os.ReadFile(file)
+		return haikugo.DecodeText(content), err
+	}
+	if len(inline) > 0 {
+		return strings.Join(inline, " "), nil
+	}
+	content, err := io.ReadAll(stdin)
+	if err != nil {
+		return "", err
+	}
+	if len(content) == 0 {
+		return "", fmt.Errorf("no input provided (use --file, inline text or stdin)")
+	}
+	return haikugo.DecodeText(content), nil
+}
+
+// printMetrics writes the human-readable analysis report.
+func printMetrics(w io.Writer, m *haikugo.Metrics) {
+	fmt.Fprintf(w, "Haiku (%d lines):\n", len(m.Lines))
+	for i, line := range m.Lines {
+		fmt.Fprintf(w, "%d: %s\n", i+1, line)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "Syllables per line: %v\n", m.LineSyllables)
+	fmt.Fprintf(w, "Words per line:     %v\n", m.LineWords)
+	fmt.Fprintf(w, "Total syllables:    %d\n", m.TotalSyllables)
+	fmt.Fprintf(w, "Total words:        %d (unique %d, lexical density %.2f)\n",
+		m.TotalWords, m.UniqueWords, m.LexicalDensity)
+	fmt.Fprintf(w, "Avg word length:    %.2f\n", m.AvgWordLen)
+	if len(m.AbstractWords) > 0 {
+		fmt.Fprintf(w, "Concreteness:       %.2f (abstract: %s)\n", m.Concreteness, strings.Join(m.AbstractWords, ", "))
+	} else {
+		fmt.Fprintf(w, "Concreteness:       %.2f\n", m.Concreteness)
+	}
+	if len(m.Senses) > 0 {
+		fmt.Fprintf(w, "Senses:             %s\n", strings.Join(m.Senses, ", "))
+	} else {
+		fmt.Fprintln(w, "Senses:             none")
+	}
+	if len(m.Grammar) > 0 {
+		lines := make([]string, len(m.Grammar))
+		for i, g := range m.Grammar {
+			lines[i] = fmt.Sprintf("%d: fragment", i+1)
+			if g.Clause {
+				lines[i] = fmt.Sprintf("%d: clause, %s", i+1, g.Tense)
+			}
+		}
+		fmt.Fprintf(w, "Grammar:            %s\n", strings.Join(lines, "; "))
+	}
+
+	if m.HasKireji {
+		fmt.Fprintf(w, "Kireji-like pause:  yes (%s)\n", strings.Join(m.KirejiHits, ", "))
+	} else {
+		fmt.Fprintln(w, "Kireji-like pause:  no")
+	}
+
+	if len(m.SeasonWords) > 0 {
+		fmt.Fprintf(w, "Season words:       %s\n", strings.Join(m.SeasonWords, ", "))
+	} else {
+		fmt.Fprintln(w, "Season words:       none")
+	}
+	if len(m.Cliches) > 0 {
+		cliches := make([]string, len(m.Cliches))
+		for i, c := range m.Cliches {
+			cliches[i] = fmt.Sprintf("%s (%s)", c.Text, c.Tier)
+		}
+		fmt.Fprintf(w, "Clichés:            %s\n", strings.Join(cliches, ", "))
+	} else {
+		fmt.Fprintln(w, "Clichés:            none")
+	}
+
+	var singSong []string
+	for i, lm := range m.Meter {
+		if lm.SingSong {
+			singSong = append(singSong, strconv.Itoa(i+1))
+		}
+	}
+	if len(singSong) > 0 {
+		fmt.Fprintf(w, "Sing-song lines:    %s (iambic run)\n", strings.Join(singSong, ", "))
+	}
+
+	if len(m.Sounds) > 0 {
+		fmt.Fprintln(w, "Sound devices:")
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, d := range m.Sounds {
+			words := make([]string, len(d.Words))
+			for i, sw := range d.Words {
+				words[i] = fmt.Sprintf("%s %d:%d", sw.Text, sw.Span.Line, sw.Span.Column)
+			}
+			fmt.Fprintf(tw, "  %s\t/%s/\t%s\n", d.Kind, d.Sound, strings.Join(words, ", "))
+		}
+		tw.Flush()
+	}
+	fmt.Fprintln(w)
+
+	status := "INVALID"
+	if m.Valid575 {
+		status = "VALID"
+	}
+	if m.Dialect != string(haikugo.DialectGeneralAmerican) {
+		fmt.Fprintf(w, "Structure: %s (tolerance ±%d, dialect %s)\n", status, m.Tolerance, m.Dialect)
+	} else {
+		fmt.Fprintf(w, "Structure: %s (tolerance ±%d)\n", status, m.Tolerance)
+	}
+	fmt.Fprintf(w, "Score:     %.1f/100\n", m.Score.Overall)
+}
+
+// printScore writes the overall score and each weighted sub-score with its reason.
+func printScore(w io.Writer, m *haikugo.Metrics) {
+	fmt.Fprintf(w, "Score: %.1f/100\n", m.Score.Overall)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, p := range m.Score.Parts {
+		fmt.Fprintf(tw, "  %s\t%.2f\t×%g\t%s\n", p.Criterion, p.Score, p.Weight, p.Reason)
+	}
+	tw.Flush()
+}
+
+// printSyllabified writes each line with its words divided into syllables.
+func printSyllabified(w io.Writer, m *haikugo.Metrics) {
+	for i, words := range m.Tokens {
+		parts := make([]string, len(words))
+		for j, t := range words {
+			parts[j] = t.Syllabified
+		}
+		fmt.Fprintf(w, "%d: %s (%d)\n", i+1, strings.Join(parts, " "), m.LineSyllables[i])
+	}
+}
+
+// printMeter writes each line's stress pattern with its dominant foot and
+// any sing-song iambic run.
+func printMeter(w io.Writer, m *haikugo.Metrics) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, lm := range m.Meter {
+		var notes []string
+		if lm.Foot != "" {
+			notes = append(notes, lm.Foot)
+		}
+		if lm.SingSong {
+			notes = append(notes, fmt.Sprintf("sing-song (%d iambs)", lm.IambicRun))
+		}
+
+		fmt.Fprintf(tw, "%d: %s", i+1, lm.Pattern)
+		if len(notes) > 0 {
+			fmt.Fprintf(tw, "\t%s", strings.Join(notes, ", "))
+		}
+		fmt.Fprintln(tw)
+	}
+	tw.Flush()
+}
+
+// printExplain writes the per-word syllable breakdown of each line.
+func printExplain(w io.Writer, m *haikugo.Metrics) {
+	for i, words := range m.Tokens {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Line %d (%d syllables):\n", i+1, m.LineSyllables[i])
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, t := range words {
+			count := strconv.Itoa(t.Syllables)
+			if t.MinSyllables != t.MaxSyllables {
+				count = fmt.Sprintf("%d (%d-%d)", t.Syllables, t.MinSyllables, t.MaxSyllables)
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%d:%d\t%s", t.Text, t.Syllabified, count, t.Method, t.Span.Line, t.Span.Column, t.POS)
+			fmt.Fprintln(tw)
+		}
+		tw.Flush()
+	}
+}
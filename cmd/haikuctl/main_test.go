@@ -0,0 +1,263 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+const sampleHaiku = "an old silent pond\na frog jumps into the pond\nsplash silence again\n"
+
+func TestRun_Analyze(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(nil, strings.NewReader(sampleHaiku), &stdout, &stderr)
+
+	if code != exitValid {
+		t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, exitValid, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, want := range []string{"Syllables per line: [5 7 5]", "Structure: VALID (tolerance ±0)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRun_JSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"--json", "--autosplit", "old pond / frog jumps in / splash!"}, strings.NewReader(""), &stdout, &stderr)
+
+	if code != exitValid {
+		t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, exitValid, stderr.String())
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &m); err != nil {
+		t.Fatalf("Output is not JSON: %v", err)
+	}
+	if m["valid_575"] != false {
+		t.Errorf("valid_575 = %v, want false", m["valid_575"])
+	}
+}
+
+func TestRun_ExitCode(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		input string
+		want  int
+	}{
+		{"valid", []string{"--exit-code"}, sampleHaiku, exitValid},
+		{"invalid", []string{"--exit-code"}, "old pond\nfrog jumps in\nsplash", exitInvalid},
+		{"parse error", []string{"--exit-code"}, "one line", exitError},
+		{"bad flag", []string{"--nope"}, sampleHaiku, exitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, strings.NewReader(tt.input), &stdout, &stderr); code != tt.want {
+				t.Errorf("Exit code = %d, want %d", code, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_Explain(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"--explain"}, strings.NewReader("the fire at 6 a.m.\na frog jumps into the pond\nsplash silence again\n"), &stdout, &stderr)
+
+	if code != exitValid {
+		t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, exitValid, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, want := range []string{"Line 1 (6 syllables):", "fire  fire   1 (1-2)  exception", "a.m.  ay em  2", "silence  si·lence  2  heuristic  3:8"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRun_ExplainWithOtherViews(t *testing.T) {
+	input := "an old silent pond\na frog jumps into the pond\nsplash silence again\n"
+	for _, view := range []string{"--syllabify", "--meter", "--score"} {
+		t.Run(view, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run([]string{view, "--explain"}, strings.NewReader(input), &stdout, &stderr); code != exitValid {
+				t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, exitValid, stderr.String())
+			}
+			if out := stdout.String(); !strings.Contains(out, "Line 3 (5 syllables):") {
+				t.Errorf("Output missing word breakdown:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestRun_Dialect(t *testing.T) {
+	input := "the fire at noon\na frog jumps into the pond\nsplash silence again\n"
+	tests := []struct {
+		args []string
+		want string
+		code int
+	}{
+		{nil, "Syllables per line: [4 7 5]", exitValid},
+		{[]string{"--dialect", "rp"}, "Structure: VALID (tolerance ±0, dialect rp)", exitValid},
+		{[]string{"--dialect", "klingon"}, "", exitError},
+	}
+
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		if code := run(tt.args, strings.NewReader(input), &stdout, &stderr); code != tt.code {
+			t.Fatalf("%v: exit code = %d, want %d (stderr: %s)", tt.args, code, tt.code, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), tt.want) {
+			t.Errorf("%v: output missing %q:\n%s", tt.args, tt.want, stdout.String())
+		}
+	}
+}
+
+func TestRun_Syllabify(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"--syllabify"}, strings.NewReader("in 1999\na frog jumps into the pond\nsplash silence again\n"), &stdout, &stderr)
+
+	if code != exitValid {
+		t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, exitValid, stderr.String())
+	}
+
+	want := "1: in nine·teen nine·ty-nine (6)\n2: a frog jumps in·to the pond (7)\n3: splash si·lence a·gain (5)\n"
+	if stdout.String() != want {
+		t.Errorf("Output = %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestRun_Meter(t *testing.T) {
+	input := "an old silent pond\nthe petals fall along the stream\nsplash silence again"
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--meter"}, strings.NewReader(input), &stdout, &stderr); code != exitValid {
+		t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, exitValid, stderr.String())
+	}
+
+	want := "1: x / / x /\n2: x / x / x / x /  iamb, sing-song (4 iambs)\n3: / / x x /\n"
+	if stdout.String() != want {
+		t.Errorf("Output = %q, want %q", stdout.String(), want)
+	}
+
+	stdout.Reset()
+	run(nil, strings.NewReader(input), &stdout, &stderr)
+	if !strings.Contains(stdout.String(), "Sing-song lines:    2 (iambic run)") {
+		t.Errorf("Report missing sing-song lines:\n%s", stdout.String())
+	}
+}
+
+func TestRun_Sounds(t *testing.T) {
+	input := "silver streams in spring\nthe moon at noon is bright\na light on the night"
+
+	var stdout, stderr bytes.Buffer
+	if code := run(nil, strings.NewReader(input), &stdout, &stderr); code != exitValid {
+		t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, exitValid, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, want := range []string{"Sound devices:", "alliteration    /s/     silver 1:1, streams 1:8, spring 1:19", "rhyme           /ay t/  bright 2:21, night 3:16"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRun_Imagery(t *testing.T) {
+	input := "love and sadness here\nthe beauty of cold rain\nsilence again"
+
+	var stdout, stderr bytes.Buffer
+	if code := run(nil, strings.NewReader(input), &stdout, &stderr); code != exitValid {
+		t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, exitValid, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, want := range []string{"(abstract: love, sadness, beauty)", "Senses:             sight, sound, touch"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRun_Grammar(t *testing.T) {
+	input := "the cold dark lonely night\na crow flew over the field\nsilence again"
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--explain"}, strings.NewReader(input), &stdout, &stderr); code != exitValid {
+		t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, exitValid, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, want := range []string{
+		"Grammar:            1: fragment; 2: clause, past; 3: fragment",
+		"2:8   VERB",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+
+	stdout.Reset()
+	if code := run([]string{"--json"}, strings.NewReader(input), &stdout, &stderr); code != exitValid {
+		t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, exitValid, stderr.String())
+	}
+	for _, want := range []string{`"kind": "adjective-overload"`, `"kind": "past-tense"`, `"pos": "VERB"`} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("JSON output missing %q:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestRun_Cliches(t *testing.T) {
+	input := "falling cherry blossoms\nthe leaves of autumn drifting\na still pond"
+
+	var stdout, stderr bytes.Buffer
+	if code := run(nil, strings.NewReader(input), &stdout, &stderr); code != exitValid {
+		t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, exitValid, stderr.String())
+	}
+
+	want := "Clichés:            falling cherry blossoms (high), leaves of autumn (high), still pond (medium)"
+	if !strings.Contains(stdout.String(), want) {
+		t.Errorf("Output missing %q:\n%s", want, stdout.String())
+	}
+}
+
+func TestRun_Score(t *testing.T) {
+	t.Chdir(t.TempDir())
+	input := "an old silent pond\na frog jumps into the pond\nsplash! silence again"
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--score"}, strings.NewReader(input), &stdout, &stderr); code != exitValid {
+		t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, exitValid, stderr.String())
+	}
+	for _, want := range []string{"Score: 76.9/100", "kigo          0.00  ×1  no season word", `originality   0.50  ×2  clichés: "frog jumps" (high)`} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("Output missing %q:\n%s", want, stdout.String())
+		}
+	}
+
+	if err := os.WriteFile("rubric.tsv", []byte("kigo\t0\noriginality\t0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stdout.Reset()
+	if code := run([]string{"--rubric", "rubric.tsv"}, strings.NewReader(input), &stdout, &stderr); code != exitValid {
+		t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, exitValid, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Score:     93.3/100") {
+		t.Errorf("Output missing reweighted score:\n%s", stdout.String())
+	}
+
+	if err := os.WriteFile("bad.tsv", []byte("rhyme\t1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if code := run([]string{"--rubric", "bad.tsv"}, strings.NewReader(input), &stdout, &stderr); code != exitError {
+		t.Errorf("Invalid rubric exit code = %d, want %d", code, exitError)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+// runStats reports aggregate statistics over a corpus file or directory.
+func runStats(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("haikuctl stats", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var opts haikugo.StatsOptions
+	opts.MaxTolerance = fs.Int("max-tolerance", haikugo.DefaultStatsMaxTolerance, "report validity for tolerances from 0 to n")
+	fs.IntVar(&opts.Top, "top", haikugo.DefaultStatsTop, "kigo, kireji and words to list (-1 for all)")
+	idField := fs.String("id-field", "", "column or field holding the record ID")
+	authorField := fs.String("author-field", "", "column or field holding the author")
+	poemField := fs.String("poem-field", "", "column or field holding the poem text")
+	dialectName := fs.String("dialect", "general-american", "pronunciation profile: general-american, rp, australian or southern-us")
+	dictFile := fs.String("dict", "", "syllable override dictionary (default: nearest .haikugo/syllables.tsv)")
+	asJSON := fs.Bool("json", false, "output the report as JSON")
+	asCSV := fs.Bool("csv", false, "output the report as CSV rows of section, value, count and fraction")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(stderr, "usage: haikuctl stats [flags] dir|file")
+		return exitError
+	}
+	if *asJSON && *asCSV {
+		fmt.Fprintln(stderr, "error: --json and --csv are mutually exclusive")
+		return exitError
+	}
+
+	dialect, err := haikugo.ParseDialect(*dialectName)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+	overrides, err := loadOverrides(*dictFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+	records, err := haikugo.ReadCorpus(fs.Arg(0), haikugo.FieldMapping{ID: *idField, Author: *authorField, Poem: *poemField})
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+	for _, rec := range records {
+		if rec.Err != nil {
+			fmt.Fprintf(stderr, "warning: %s: %v\n", rec.ID, rec.Err)
+		}
+	}
+
+	a := haikugo.NewAnalyzer(0)
+	a.SetOverrides(overrides)
+	a.SetDialect(dialect)
+	report, err := a.CorpusStats(records, opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	switch {
+	case *asJSON:
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(report)
+	case *asCSV:
+		err = writeStatsCSV(stdout, report)
+	default:
+		printStats(stdout, report)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+	return exitValid
+}
+
+// writeStatsCSV writes the report as rows of section, value, count and
+// fraction. Totals have no fraction, and author rows give the number of
+// poems as the count and the average lexical density as the fraction.
+func writeStatsCSV(w io.Writer, r haikugo.CorpusReport) error {
+	cw := csv.NewWriter(w)
+	row := func(section, value string, count int, fraction float64) {
+		cw.Write([]string{section, value, strconv.Itoa(count), strconv.FormatFloat(fraction, 'f', -1, 64)})
+	}
+	total := func(value string, count int) {
+		cw.Write([]string{"total", value, strconv.Itoa(count), ""})
+	}
+	counts := func(section string, cs []haikugo.CorpusCount) {
+		for _, c := range cs {
+			row(section, c.Value, c.Count, c.Fraction)
+		}
+	}
+
+	cw.Write([]string{"section", "value", "count", "fraction"})
+	total("poems", r.Poems)
+	total("errors", r.Errors)
+	total("words", r.Words)
+	total("unique_words", r.UniqueWords)
+	counts("pattern", r.Patterns)
+	for _, v := range r.Validity {
+		row("valid", strconv.Itoa(v.Tolerance), v.Valid, v.Fraction)
+	}
+	counts("season", r.Seasons)
+	counts("kigo", r.Kigo)
+	counts("kireji", r.Kireji)
+	counts("word", r.Vocabulary)
+	for _, a := range r.Authors {
+		row("author", a.Author, a.Poems, a.LexicalDensity)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// printStats writes the report as a text table per section.
+func printStats(w io.Writer, r haikugo.CorpusReport) {
+	fmt.Fprintf(w, "Poems: %d, errors: %d, words: %d (%d unique)\n", r.Poems, r.Errors, r.Words, r.UniqueWords)
+
+	section := func(title string, rows func(tw *tabwriter.Writer)) {
+		fmt.Fprintf(w, "\n%s\n", title)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		rows(tw)
+		tw.Flush()
+	}
+	counts := func(title string, cs []haikugo.CorpusCount) {
+		section(title, func(tw *tabwriter.Writer) {
+			if len(cs) == 0 {
+				fmt.Fprintln(tw, "  (none)")
+			}
+			for _, c := range cs {
+				fmt.Fprintf(tw, "  %s\t%d\t%.1f%%\n", c.Value, c.Count, 100*c.Fraction)
+			}
+		})
+	}
+
+	counts("Syllable patterns", r.Patterns)
+	section("Valid 5-7-5 by tolerance", func(tw *tabwriter.Writer) {
+		for _, v := range r.Validity {
+			fmt.Fprintf(tw, "  ±%d\t%d\t%.1f%%\n", v.Tolerance, v.Valid, 100*v.Fraction)
+		}
+	})
+	counts("Seasons", r.Seasons)
+	counts("Kigo", r.Kigo)
+	counts("Kireji", r.Kireji)
+	counts("Words", r.Vocabulary)
+	section("Authors (poems, lexical density)", func(tw *tabwriter.Writer) {
+		for _, a := range r.Authors {
+			fmt.Fprintf(tw, "  %s\t%d\t%.2f\n", a.Author, a.Poems, a.LexicalDensity)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+func TestRunStats(t *testing.T) {
+	t.Chdir(t.TempDir())
+	files := map[string]string{
+		"corpus/a.txt":   "an old silent pond\na frog jumps into the pond\nsplash silence again\n\nthe autumn moon shines\nover the pond a cold wind\nthe frog sleeps alone\n",
+		"corpus/b.jsonl": `{"id":"e1","author":"Issa","poem":["a cold winter night","the snow drifts into the pond","silence in the pines"]}` + "\n" + `{"id":"e2","poem":"short"}` + "\n",
+	}
+	for name, data := range files {
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		want       int
+		wantOut    []string
+		wantStderr string
+	}{
+		{"text", []string{"stats", "corpus"}, exitValid, []string{"Poems: 3, errors: 1, words: 41 (26 unique)", "5-7-5  3  100.0%", "±2  3  100.0%", "winter  2  66.7%", "cold    2  66.7%", "(none)", "the ", "Issa       1  0.86"}, "warning: b.jsonl:e2:"},
+		{"csv", []string{"stats", "--csv", "--top", "1", "corpus"}, exitValid, []string{"section,value,count,fraction\n", "total,poems,3,\n", "valid,0,3,1\n", "season,none,1,0.333\n", "word,the,7,0.171\n", "author,Issa,1,0.857\n"}, ""},
+		{"single file", []string{"stats", "--max-tolerance", "1", "corpus/a.txt"}, exitValid, []string{"Poems: 2, errors: 0", "±1"}, ""},
+		{"exact only", []string{"stats", "--csv", "--max-tolerance", "0", "corpus"}, exitValid, []string{"valid,0,3,1\nseason,"}, ""},
+		{"no path", []string{"stats"}, exitError, nil, "usage:"},
+		{"missing path", []string{"stats", "missing"}, exitError, nil, "error:"},
+		{"both formats", []string{"stats", "--json", "--csv", "corpus"}, exitError, nil, "mutually exclusive"},
+		{"bad tolerance", []string{"stats", "--max-tolerance", "9", "corpus"}, exitError, nil, "tolerance"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, strings.NewReader(""), &stdout, &stderr); code != tt.want {
+				t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, tt.want, stderr.String())
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("Output missing %q:\n%s", want, stdout.String())
+				}
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("Stderr = %q, want %q", stderr.String(), tt.wantStderr)
+			}
+			if strings.Contains(strings.Join(tt.args, " "), "--csv") && tt.want == exitValid {
+				if _, err := csv.NewReader(&stdout).ReadAll(); err != nil {
+					t.Errorf("Invalid CSV: %v", err)
+				}
+			}
+		})
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"stats", "--json", "corpus"}, strings.NewReader(""), &stdout, &stderr); code != exitValid {
+		t.Fatalf("Exit code = %d (stderr: %s)", code, stderr.String())
+	}
+	var r haikugo.CorpusReport
+	if err := json.Unmarshal(stdout.Bytes(), &r); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if r.Poems != 3 || r.Errors != 1 || len(r.Authors) != 2 || r.Authors[0].Author != haikugo.UnknownAuthor {
+		t.Errorf("JSON report = %+v", r)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+// runTrain builds a Markov model from a haiku corpus and saves it for
+// generate --model.
+func runTrain(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("haikuctl train", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	order := fs.Int("order", haikugo.DefaultMarkovOrder, fmt.Sprintf("words of context, %d to %d", haikugo.MinMarkovOrder, haikugo.MaxMarkovOrder))
+	out := fs.String("out", "markov.json", "model file to write")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(stderr, "usage: haikuctl train [--order n] [--out file] dir|file")
+		return exitError
+	}
+
+	m, err := haikugo.TrainMarkovCorpus(fs.Arg(0), *order)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+	if err := m.Save(*out); err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	fmt.Fprintf(stdout, "Trained an order-%d model on %d poems: %s\n", m.Order, len(m.Poems), *out)
+	return exitValid
+}
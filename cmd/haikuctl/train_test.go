@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+// trainingCorpus is a small stanza corpus whose lines recombine.
+const trainingCorpus = `an old silent pond
+a frog jumps into the pond
+splash silence again
+
+the autumn moon shines
+over the pond a cold wind
+the frog sleeps alone
+
+a cold winter night
+the snow falls into the pond
+silence in the pines
+
+the old temple bell
+rings over the quiet hills
+a crow in the rain
+
+spring rain on the roof
+a crow sleeps in the old pine
+the bell rings again
+`
+
+func TestRunTrain(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll("corpus", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join("corpus", "poems.txt"), []byte(trainingCorpus), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+		out  string
+	}{
+		{"default", []string{"train", "corpus"}, exitValid, "markov.json"},
+		{"order and out", []string{"train", "--order", "1", "--out", "models/first.json", "corpus"}, exitValid, "models/first.json"},
+		{"no corpus", []string{"train"}, exitError, ""},
+		{"missing corpus", []string{"train", "missing"}, exitError, ""},
+		{"bad order", []string{"train", "--order", "9", "corpus"}, exitError, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, strings.NewReader(""), &stdout, &stderr); code != tt.want {
+				t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, tt.want, stderr.String())
+			}
+			if tt.out == "" {
+				return
+			}
+			if !strings.Contains(stdout.String(), "on 5 poems: "+tt.out) {
+				t.Errorf("Output = %q, want it to report 5 poems and %s", stdout.String(), tt.out)
+			}
+			if _, err := haikugo.LoadMarkovModel(tt.out); err != nil {
+				t.Errorf("Saved model does not load: %v", err)
+			}
+		})
+	}
+}
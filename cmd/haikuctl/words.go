@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/thornzero/haikugo/pkg/haikugo"
+)
+
+// runWords looks up lexicon words by syllable count, stress, rhyme, season,
+// starting sound, part of speech or synonym.
+func runWords(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("haikuctl words", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var q haikugo.WordQuery
+	fs.IntVar(&q.Syllables, "syllables", 0, "syllable count")
+	fs.StringVar(&q.Stress, "stress", "", "stress pattern in scansion marks, e.g. /x")
+	fs.StringVar(&q.RhymesWith, "rhymes", "", "rhyme with this word")
+	fs.StringVar(&q.Season, "season", "", "kigo season: spring, summer, autumn, winter or all")
+	fs.StringVar(&q.StartsWith, "starts", "", "first sound in ARPAbet, e.g. s or sh")
+	fs.StringVar(&q.POS, "pos", "", "part of speech, e.g. noun or adj")
+	fs.StringVar(&q.Sense, "sense", "", "sense engaged: sight, sound, touch, smell or taste")
+	fs.StringVar(&q.SynonymOf, "like", "", "synonyms of this word")
+	fs.IntVar(&q.Limit, "max", 50, "most words shown; 0 shows all")
+	asJSON := fs.Bool("json", false, "output words as JSON")
+	dialectName := fs.String("dialect", "general-american", "pronunciation profile: general-american, rp, australian or southern-us")
+	dictFile := fs.String("dict", "", "syllable override dictionary (default: nearest .haikugo/syllables.tsv)")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "error: unexpected argument %q\n", fs.Arg(0))
+		return exitError
+	}
+
+	dialect, err := haikugo.ParseDialect(*dialectName)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	overrides, err := loadOverrides(*dictFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	a := haikugo.NewAnalyzer(0)
+	a.SetOverrides(overrides)
+	a.SetDialect(dialect)
+	words, err := a.FindWords(q)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	if *asJSON {
+		if words == nil {
+			words = []haikugo.WordEntry{}
+		}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(words); err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return exitError
+		}
+		return exitValid
+	}
+
+	printWords(stdout, words)
+	return exitValid
+}
+
+// printWords writes one word per line with its syllables, stress, tags and season.
+func printWords(w io.Writer, words []haikugo.WordEntry) {
+	if len(words) == 0 {
+		fmt.Fprintln(w, "No words found.")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, e := range words {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n", e.Word, e.Syllables, e.Stress, strings.ToLower(strings.Join(e.Tags, ",")), e.Season)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunWords(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+		out  []string
+	}{
+		{"rhyme", []string{"words", "--rhymes", "moon", "--syllables", "1", "--pos", "noun"}, exitValid, []string{"dune   1  /  noun", "spoon  1  /  noun"}},
+		{"synonym", []string{"words", "--like", "quiet", "--syllables", "2"}, exitValid, []string{"silent  2  /x  adj"}},
+		{"season", []string{"words", "--season", "winter", "--max", "1"}, exitValid, []string{"bare  1  /  adj  winter"}},
+		{"json", []string{"words", "--json", "--rhymes", "june", "--season", "all"}, exitValid, []string{`"word": "moon"`, `"rhyme": "uw n"`}},
+		{"sense", []string{"words", "--sense", "sound", "--syllables", "3", "--pos", "noun"}, exitValid, []string{"cicada    3  x/x  noun  summer"}},
+		{"none", []string{"words", "--like", "frog"}, exitValid, []string{"No words found."}},
+		{"json none", []string{"words", "--json", "--like", "frog"}, exitValid, []string{"[]"}},
+		{"bad season", []string{"words", "--season", "monsoon"}, exitError, nil},
+		{"bad sense", []string{"words", "--sense", "hearing"}, exitError, nil},
+		{"bad stress", []string{"words", "--stress", "10"}, exitError, nil},
+		{"argument", []string{"words", "moon"}, exitError, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, strings.NewReader(""), &stdout, &stderr); code != tt.want {
+				t.Fatalf("Exit code = %d, want %d (stderr: %s)", code, tt.want, stderr.String())
+			}
+			for _, want := range tt.out {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("Output missing %q:\n%s", want, stdout.String())
+				}
+			}
+		})
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/thornzero/haikugo
 
 go 1.24.5
+
+require golang.org/x/text v0.34.0
@@ -0,0 +1,210 @@
+// Package analyzer provides the accidental haiku finder for prose.
+package analyzer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// sentenceEnds are the runes that can end a sentence.
+const sentenceEnds = ".!?…"
+
+// sentenceClosers are the quotes and brackets that may follow a sentence's
+// final punctuation.
+const sentenceClosers = "\"')]”’"
+
+// sentence is a byte range of prose and the paragraph it belongs to.
+type sentence struct {
+	start, end int
+	paragraph  int
+}
+
+// splitSentences segments text into sentences. A sentence ends at '.', '!',
+// '?' or '…', with any closing quotes, when followed by whitespace and a
+// word that does not start in lower case, and at a blank line, which also
+// starts a new paragraph. Abbreviations and initials such as "Dr." or "J."
+// do not end a sentence, and neither do decimal points.
+func splitSentences(text string) []sentence {
+	var result []sentence
+	start, paragraph := -1, 0
+	flush := func(end int) {
+		if start >= 0 {
+			end = start + len(strings.TrimRightFunc(text[start:end], unicode.IsSpace))
+			result = append(result, sentence{start: start, end: end, paragraph: paragraph})
+			start = -1
+		}
+	}
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case r == '\n':
+			j := i + size
+			for j < len(text) && strings.IndexByte(" \t\r", text[j]) >= 0 {
+				j++
+			}
+			if j < len(text) && text[j] == '\n' {
+				flush(i)
+				paragraph++
+				i = j
+				continue
+			}
+
+		case start < 0 && !unicode.IsSpace(r):
+			start = i
+		}
+
+		if !strings.ContainsRune(sentenceEnds, r) {
+			i += size
+			continue
+		}
+		j := i + size
+		for j < len(text) {
+			next, n := utf8.DecodeRuneInString(text[j:])
+			if !strings.ContainsRune(sentenceEnds+sentenceClosers, next) {
+				break
+			}
+			j += n
+		}
+		if endsSentence(text, i, j) {
+			flush(j)
+		}
+		i = j
+	}
+	flush(len(text))
+	return result
+}
+
+// endsSentence reports whether the punctuation at text[mark:after] ends a
+// sentence.
+func endsSentence(text string, mark, after int) bool {
+	if after < len(text) {
+		r, _ := utf8.DecodeRuneInString(text[after:])
+		if !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	rest := strings.TrimLeftFunc(text[after:], unicode.IsSpace)
+	if r, _ := utf8.DecodeRuneInString(rest); unicode.IsLower(r) {
+		return false
+	}
+	if text[mark] != '.' {
+		return true
+	}
+
+	wordStart := strings.LastIndexFunc(text[:mark], func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '.'
+	}) + 1
+	word := strings.ToLower(text[wordStart : mark+1])
+	if _, ok := abbreviations[word]; ok || word == "st." {
+		return false
+	}
+	// An initial, as in "J. Smith".
+	return utf8.RuneCountInString(word) != 2
+}
+
+// proseWord is a token of prose with its syllable count and position among
+// sentences and paragraphs.
+type proseWord struct {
+	start, end int
+	syllables  int
+	paragraph  int
+	first      bool // starts its sentence
+	last       bool // ends its sentence
+}
+
+// FindAccidental returns the runs of consecutive words in prose that split
+// exactly into 5-7-5 syllables on word boundaries, counted with the
+// analyzer's dialect and global overrides. Runs start and end on sentence
+// boundaries unless crossSentences is set, and never cross paragraphs; the
+// search resumes after each run found, so runs do not overlap. prose was
+// extracted from source, with offsets mapping its bytes back as for
+// haiku.SpanAt, and the spans locate each run in source.
+func (a *Analyzer) FindAccidental(source, prose string, offsets []int, crossSentences bool) []haiku.Accidental {
+	lookup := a.lookup("")
+	var words []proseWord
+	for _, s := range splitSentences(prose) {
+		tokens := Tokenize(prose[s.start:s.end])
+		for j, tok := range tokens {
+			n, _, _, _ := explainCount(tok, lookup, a.dialect)
+			words = append(words, proseWord{
+				start:     s.start + tok.Start,
+				end:       s.start + tok.End,
+				syllables: n,
+				paragraph: s.paragraph,
+				first:     j == 0,
+				last:      j == len(tokens)-1,
+			})
+		}
+	}
+
+	var result []haiku.Accidental
+	for i := 0; i < len(words); {
+		if !crossSentences && !words[i].first {
+			i++
+			continue
+		}
+		ends, found := accidentalRun(words, i, crossSentences)
+		if !found {
+			i++
+			continue
+		}
+
+		h := haiku.Accidental{Sentence: words[i].first && words[ends[2]].last}
+		lineStart, end := words[i].start, 0
+		for k, last := range ends {
+			end = withTrailingPunct(prose, words[last].end)
+			h.Lines = append(h.Lines, strings.Join(strings.Fields(prose[lineStart:end]), " "))
+			if k < 2 {
+				lineStart = words[last+1].start
+			}
+		}
+		h.Span = haiku.SpanAt(source, offsets, words[i].start, end)
+		result = append(result, h)
+		i = ends[2] + 1
+	}
+	return result
+}
+
+// accidentalRun looks for a run of words starting at words[i] whose
+// syllables add up to 5, 12 and 17 at the ends of its three lines, returning
+// the index of each line's last word. Without crossSentences the run must
+// end a sentence.
+func accidentalRun(words []proseWord, i int, crossSentences bool) ([3]int, bool) {
+	var ends [3]int
+	targets := []int{5, 12, 17}
+	line, sum := 0, 0
+	for j := i; j < len(words) && words[j].paragraph == words[i].paragraph; j++ {
+		sum += words[j].syllables
+		if sum > targets[line] {
+			return ends, false
+		}
+		if sum < targets[line] {
+			continue
+		}
+		if line < 2 {
+			ends[line] = j
+			line++
+		} else if crossSentences || words[j].last {
+			ends[line] = j
+			return ends, true
+		}
+	}
+	return ends, false
+}
+
+// withTrailingPunct extends a word ending at end over the punctuation and
+// closing quotes that directly follow it.
+func withTrailingPunct(text string, end int) int {
+	for end < len(text) {
+		r, size := utf8.DecodeRuneInString(text[end:])
+		if unicode.IsSpace(r) || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			break
+		}
+		end += size
+	}
+	return end
+}
@@ -0,0 +1,102 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"An old pond. A frog jumps!", []string{"An old pond.", "A frog jumps!"}},
+		{"We met Dr. Smith and J. Doe. They left.", []string{"We met Dr. Smith and J. Doe.", "They left."}},
+		{"It cost 3.5 yen. Cheap.", []string{"It cost 3.5 yen.", "Cheap."}},
+		{"\"Who?\" she asked. \"Me.\" Then rain…", []string{"\"Who?\" she asked.", "\"Me.\"", "Then rain…"}},
+		{"e.g. the pond, i.e. water", []string{"e.g. the pond, i.e. water"}},
+		{"A heading\n\nText without a stop\nwraps here", []string{"A heading", "Text without a stop\nwraps here"}},
+		{"  \n\n ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			var result []string
+			for _, s := range splitSentences(tt.text) {
+				result = append(result, tt.text[s.start:s.end])
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("splitSentences(%q) = %q, want %q", tt.text, result, tt.expected)
+			}
+		})
+	}
+
+	paragraphs := []int{}
+	for _, s := range splitSentences("One. Two.\n \nThree.") {
+		paragraphs = append(paragraphs, s.paragraph)
+	}
+	if !reflect.DeepEqual(paragraphs, []int{0, 0, 1}) {
+		t.Errorf("paragraphs = %v, want [0 0 1]", paragraphs)
+	}
+}
+
+func TestFindAccidental(t *testing.T) {
+	prose := "We met Dr. Smith at noon. An old silent pond. A frog jumps into the pond. Splash! Silence again.\n\n" +
+		"The report is due."
+
+	tests := []struct {
+		name           string
+		crossSentences bool
+		expected       [][]string
+		sentence       []bool
+	}{
+		{"sentences", false, [][]string{
+			{"An old silent pond.", "A frog jumps into the pond.", "Splash! Silence again."},
+		}, []bool{true}},
+		{"cross sentences", true, [][]string{
+			{"We met Dr. Smith", "at noon. An old silent pond.", "A frog jumps into"},
+		}, []bool{false}},
+	}
+
+	a := New(0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found := a.FindAccidental(prose, prose, nil, tt.crossSentences)
+			var lines [][]string
+			var sentence []bool
+			for _, h := range found {
+				lines = append(lines, h.Lines)
+				sentence = append(sentence, h.Sentence)
+			}
+			if !reflect.DeepEqual(lines, tt.expected) || !reflect.DeepEqual(sentence, tt.sentence) {
+				t.Errorf("FindAccidental() = %q %v, want %q %v", lines, sentence, tt.expected, tt.sentence)
+			}
+		})
+	}
+}
+
+func TestFindAccidental_Span(t *testing.T) {
+	source := "<p>Intro.</p>\n<p>An old silent pond. A frog jumps into the pond. Splash! Silence again.</p>"
+	prose := "Intro.\n\nAn old silent pond. A frog jumps into the pond. Splash! Silence again.\n\n"
+	offsets := make([]int, 0, len(prose)+1)
+	for i := 3; i < 9; i++ {
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, 13, 14)
+	for i := 17; i < 17+len(prose)-10; i++ {
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(source)-4, len(source)-4, len(source))
+
+	found := New(0).FindAccidental(source, prose, offsets, false)
+	if len(found) != 1 {
+		t.Fatalf("FindAccidental() = %+v, want one haiku", found)
+	}
+	span := found[0].Span
+	if got := source[span.Start:span.End]; got != "An old silent pond. A frog jumps into the pond. Splash! Silence again." {
+		t.Errorf("Span %+v covers %q", span, got)
+	}
+	if span.Line != 2 || span.Column != 4 {
+		t.Errorf("Span position = %d:%d, want 2:4", span.Line, span.Column)
+	}
+}
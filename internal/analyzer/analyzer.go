@@ -4,17 +4,23 @@ package analyzer
 import (
 	"strings"
 
+	"github.com/thornzero/haikugo/internal/dict"
 	"github.com/thornzero/haikugo/internal/haiku"
+	"github.com/thornzero/haikugo/internal/score"
 )
 
 // Analyzer provides methods for analyzing haiku poems.
 type Analyzer struct {
 	tolerance int
+	overrides *dict.Dictionary
+	dialect   Dialect
+	rubric    *score.Rubric
 }
 
 // New creates a new Analyzer with the specified syllable tolerance.
+// Syllables are counted for General American unless SetDialect is called.
 func New(tolerance int) *Analyzer {
-	return &Analyzer{tolerance: tolerance}
+	return &Analyzer{tolerance: tolerance, dialect: DialectGeneralAmerican}
 }
 
 // Analyze performs comprehensive analysis of a haiku and returns metrics.
@@ -26,23 +32,43 @@ func (a *Analyzer) Analyze(h *haiku.Haiku) *haiku.Metrics {
 	m := &haiku.Metrics{
 		Lines:     h.Lines,
 		Tolerance: a.tolerance,
+		Dialect:   string(a.dialect),
 	}
 
 	m.LineSyllables = make([]int, 3)
 	m.LineWords = make([]int, 3)
+	m.Tokens = make([][]haiku.WordToken, 3)
+	m.Meter = make([]haiku.LineMeter, 3)
+	m.Grammar = make([]haiku.LineGrammar, 3)
 
 	var totalChars, totalLetters int
+	var findings []haiku.Diagnostic
 	uniqueWords := make(map[string]struct{})
 
 	// Analyze each line
 	for i, line := range h.Lines {
-		words := ExtractWords(line)
+		words := explainTokens(h, i, line, a.lookup(h.Author), a.dialect)
+		m.Tokens[i] = words
 		m.LineWords[i] = len(words)
+		m.LineSyllables[i] = lineSyllables(words)
 
-		for _, word := range words {
-			lowerWord := strings.ToLower(word)
+		meter, first, last := scanTokens(words)
+		m.Meter[i] = meter
+		if meter.SingSong {
+			findings = append(findings, tokenDiagnostic(h, i, line, KindIambicRun, first, last))
+		}
+
+		m.Grammar[i] = profileLine(words)
+		findings = append(findings, grammarDiagnostics(h, i, line, words, m.Grammar[i])...)
+
+		for _, c := range findCliches(h, i, line, words) {
+			m.Cliches = append(m.Cliches, c)
+			findings = append(findings, haiku.Diagnostic{Kind: KindCliche, Text: c.Text, Span: c.Span})
+		}
+
+		for _, w := range words {
+			lowerWord := strings.ToLower(w.Text)
 			uniqueWords[lowerWord] = struct{}{}
-			m.LineSyllables[i] += CountSyllables(lowerWord)
 			totalLetters += len([]rune(lowerWord))
 		}
 
@@ -60,12 +86,21 @@ func (a *Analyzer) Analyze(h *haiku.Haiku) *haiku.Metrics {
 	}
 
 	// Detect literary elements
+	m.Sounds = findSounds(m.Tokens)
+	scoreImagery(m)
 	fullText := strings.Join(h.Lines, " ")
 	m.HasKireji, m.KirejiHits = DetectKireji(fullText)
 	m.SeasonWords = DetectSeasonWords(fullText)
 
+	// Map findings back to source positions
+	m.LineSources = h.LineSources
+	m.Diagnostics = append(locateAll(h, KindKireji, m.KirejiHits), locateAll(h, KindKigo, m.SeasonWords)...)
+	m.Diagnostics = append(m.Diagnostics, findings...)
+	sortDiagnostics(m.Diagnostics)
+
 	// Validate 5-7-5 structure
 	m.Valid575 = a.IsValid575(m.LineSyllables)
+	m.Score = scoreHaiku(m, a.rubric)
 
 	return m
 }
@@ -86,6 +121,18 @@ func (a *Analyzer) IsValid575(syllables []int) bool {
 	return true
 }
 
+// CountLine counts the syllables of a line as Analyze does, with the
+// analyzer's dialect and global overrides.
+func (a *Analyzer) CountLine(line string) int {
+	lookup := a.lookup("")
+	total := 0
+	for _, tok := range Tokenize(line) {
+		n, _, _, _ := explainCount(tok, lookup, a.dialect)
+		total += n
+	}
+	return total
+}
+
 // SetTolerance updates the syllable tolerance for validation.
 func (a *Analyzer) SetTolerance(tolerance int) {
 	a.tolerance = tolerance
@@ -96,6 +143,48 @@ func (a *Analyzer) GetTolerance() int {
 	return a.tolerance
 }
 
+// SetOverrides sets the user dictionary whose syllable counts take
+// precedence over every built-in counter. A nil dictionary disables overrides.
+func (a *Analyzer) SetOverrides(d *dict.Dictionary) {
+	a.overrides = d
+}
+
+// GetOverrides returns the user override dictionary, or nil if none is set.
+func (a *Analyzer) GetOverrides() *dict.Dictionary {
+	return a.overrides
+}
+
+// SetDialect selects the dialect profile used for syllable counting.
+func (a *Analyzer) SetDialect(d Dialect) {
+	a.dialect = d
+}
+
+// GetDialect returns the dialect profile used for syllable counting.
+func (a *Analyzer) GetDialect() Dialect {
+	return a.dialect
+}
+
+// SetRubric sets the rubric weighting the sub-scores of Metrics.Score.
+// A nil rubric uses the default weights.
+func (a *Analyzer) SetRubric(r *score.Rubric) {
+	a.rubric = r
+}
+
+// GetRubric returns the scoring rubric, or nil if the default weights are used.
+func (a *Analyzer) GetRubric() *score.Rubric {
+	return a.rubric
+}
+
+// lookup returns the override lookup for author, or nil when no dictionary is set.
+func (a *Analyzer) lookup(author string) lookupFunc {
+	if a.overrides == nil {
+		return nil
+	}
+	return func(word string) (int, bool) {
+		return a.overrides.Lookup(word, author)
+	}
+}
+
 // abs returns the absolute value of an integer.
 func abs(x int) int {
 	if x < 0 {
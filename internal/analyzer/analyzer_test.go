@@ -3,6 +3,7 @@ package analyzer
 import (
 	"testing"
 
+	"github.com/thornzero/haikugo/internal/dict"
 	"github.com/thornzero/haikugo/internal/haiku"
 )
 
@@ -108,6 +109,33 @@ func TestAnalyzer_IsValid575(t *testing.T) {
 	}
 }
 
+func TestAnalyzer_CountLine(t *testing.T) {
+	overrides := dict.New()
+	if err := overrides.Set("haiku", 3, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		line      string
+		overrides *dict.Dictionary
+		expected  int
+	}{
+		{"an old silent pond", nil, 5},
+		{"a frog jumps into the pond", nil, 7},
+		{"", nil, 0},
+		{"haiku", nil, 2},
+		{"haiku", overrides, 3},
+	}
+
+	for _, tt := range tests {
+		analyzer := New(0)
+		analyzer.SetOverrides(tt.overrides)
+		if got := analyzer.CountLine(tt.line); got != tt.expected {
+			t.Errorf("CountLine(%q) = %d, want %d", tt.line, got, tt.expected)
+		}
+	}
+}
+
 func TestAnalyzer_SetGetTolerance(t *testing.T) {
 	analyzer := New(0)
 
@@ -0,0 +1,210 @@
+// Package analyzer provides cliché detection against an embedded phrase corpus.
+package analyzer
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// Frequency tiers of overused phrases, reported in Cliche.Tier.
+const (
+	ClicheHigh   = "high"
+	ClicheMedium = "medium"
+	ClicheLow    = "low"
+)
+
+// clicheTiers lists the tiers from most to least overused.
+var clicheTiers = []string{ClicheHigh, ClicheMedium, ClicheLow}
+
+// clicheFunctionTags are the parts of speech skipped when matching phrases,
+// so that "leaves of autumn" matches "autumn leaves".
+var clicheFunctionTags = []string{TagDeterminer, TagAdposition, TagConjunction, TagParticle, TagPronoun, TagAuxiliary, TagInterjection}
+
+// irregularStems maps irregular inflections to the stem of their base form.
+var irregularStems = map[string]string{
+	"leaves": "leaf", "fell": "fall", "fallen": "fall", "geese": "goose",
+	"blew": "blow", "blown": "blow", "flew": "fly", "flown": "fly",
+	"sang": "sing", "sung": "sing", "shone": "shin", "froze": "freez", "frozen": "freez",
+}
+
+//go:embed data/cliches.txt
+var clicheData string
+
+// cliche is an overused phrase and the sorted stems of its content words.
+type cliche struct {
+	phrase string
+	tier   string
+	stems  []string
+}
+
+// cliches holds the phrase corpus. AddCliche extends it.
+var cliches = parseCliches(clicheData)
+
+// parseCliches reads the embedded phrase<TAB>tier list.
+func parseCliches(data string) []cliche {
+	var result []cliche
+	seen := make(map[string]string)
+	sc := bufio.NewScanner(strings.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != 2 {
+			panic(fmt.Sprintf("cliche data: invalid entry %q", line))
+		}
+		c, err := newCliche(fields[0], fields[1])
+		if err != nil {
+			panic(fmt.Sprintf("cliche data: %v", err))
+		}
+		key := strings.Join(c.stems, " ")
+		if other, ok := seen[key]; ok {
+			panic(fmt.Sprintf("cliche data: %q matches the same words as %q", c.phrase, other))
+		}
+		seen[key] = c.phrase
+		result = append(result, c)
+	}
+	return result
+}
+
+// newCliche prepares a phrase for matching.
+func newCliche(phrase, tier string) (cliche, error) {
+	phrase = strings.ToLower(strings.TrimSpace(phrase))
+	if !slices.Contains(clicheTiers, tier) {
+		return cliche{}, fmt.Errorf("phrase %q: unknown tier %q (want high, medium or low)", phrase, tier)
+	}
+	tokens := Tokenize(phrase)
+	stems, _ := contentStems(tokens, tagTokens(phrase, tokens))
+	if len(stems) == 0 {
+		return cliche{}, fmt.Errorf("phrase %q has no content words", phrase)
+	}
+	sort.Strings(stems)
+	return cliche{phrase: phrase, tier: tier, stems: stems}, nil
+}
+
+// AddCliche adds an overused phrase to the corpus with a frequency tier,
+// or changes the tier of a phrase that matches the same words.
+func AddCliche(phrase, tier string) error {
+	c, err := newCliche(phrase, tier)
+	if err != nil {
+		return err
+	}
+	for i, existing := range cliches {
+		if slices.Equal(existing.stems, c.stems) {
+			cliches[i] = c
+			return nil
+		}
+	}
+	cliches = append(cliches, c)
+	return nil
+}
+
+// GetCliches returns the phrases of the corpus.
+func GetCliches() []string {
+	result := make([]string, len(cliches))
+	for i, c := range cliches {
+		result[i] = c.phrase
+	}
+	return result
+}
+
+// clicheStem reduces a word to a stem shared by its inflections:
+// "blossoms" and "blossom", "falling" and "falls", "fading" and "fade".
+func clicheStem(word string) string {
+	word = strings.ToLower(strings.ReplaceAll(word, "’", "'"))
+	word = strings.TrimSuffix(word, "'s")
+	if stem, ok := irregularStems[word]; ok {
+		return stem
+	}
+
+	switch a, ok := splitSuffix(word); {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		word = word[:len(word)-3] + "y"
+	case ok && (a.suffix == "s" || a.suffix == "es" || a.suffix == "ed" || a.suffix == "ing") && len(a.base) >= 3:
+		word = a.base
+		// "running" -> "run", but "falling" keeps its "ll".
+		if n := len(word); a.suffix != "s" && word[n-1] == word[n-2] && !strings.ContainsRune("lsfz", rune(word[n-1])) {
+			word = word[:n-1]
+		}
+	}
+	if len(word) > 3 {
+		word = strings.TrimSuffix(word, "e")
+	}
+	return word
+}
+
+// contentStems returns the stems of the tokens that are not function words,
+// with the index of each token.
+func contentStems(tokens []Token, tags []string) ([]string, []int) {
+	var stems []string
+	var idx []int
+	for j, t := range tokens {
+		if slices.Contains(clicheFunctionTags, tags[j]) {
+			continue
+		}
+		stems = append(stems, clicheStem(t.Text))
+		idx = append(idx, j)
+	}
+	return stems, idx
+}
+
+// findCliches matches line i against the corpus. A phrase matches a run of
+// consecutive content words with the same stems in any order; a match
+// inside a longer one ("cherry blossoms" in "cherry blossoms fall") is
+// dropped.
+func findCliches(h *haiku.Haiku, i int, line string, words []haiku.WordToken) []haiku.Cliche {
+	tokens := Tokenize(line)
+	tags := make([]string, len(words))
+	for j, w := range words {
+		tags[j] = w.POS
+	}
+	stems, idx := contentStems(tokens, tags)
+
+	type match struct {
+		first, last int
+		c           cliche
+	}
+	var matches []match
+	window := make([]string, 0, len(stems))
+	for _, c := range cliches {
+		for k := 0; k+len(c.stems) <= len(stems); k++ {
+			window = append(window[:0], stems[k:k+len(c.stems)]...)
+			sort.Strings(window)
+			if slices.Equal(window, c.stems) {
+				matches = append(matches, match{idx[k], idx[k+len(c.stems)-1], c})
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(a, b int) bool {
+		if matches[a].first != matches[b].first {
+			return matches[a].first < matches[b].first
+		}
+		return matches[a].last > matches[b].last
+	})
+
+	var result []haiku.Cliche
+	end := -1
+	for _, m := range matches {
+		if m.last <= end {
+			continue
+		}
+		end = m.last
+		start, stop := tokens[m.first].Start, tokens[m.last].End
+		result = append(result, haiku.Cliche{
+			Phrase: m.c.phrase,
+			Tier:   m.c.tier,
+			Text:   line[start:stop],
+			Span:   h.Locate(i, start, stop),
+		})
+	}
+	return result
+}
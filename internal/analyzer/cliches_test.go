@@ -0,0 +1,126 @@
+package analyzer
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+func TestClicheStem(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected string
+	}{
+		{"blossoms", "blossom"},
+		{"blossom", "blossom"},
+		{"falling", "fall"},
+		{"falls", "fall"},
+		{"fell", "fall"},
+		{"fading", "fad"},
+		{"fade", "fad"},
+		{"running", "run"},
+		{"leaves", "leaf"},
+		{"fireflies", "firefly"},
+		{"jumped", "jump"},
+		{"Winter's", "winter"},
+		{"stillness", "stillness"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if result := clicheStem(tt.word); result != tt.expected {
+				t.Errorf("clicheStem(%q) = %q, want %q", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindCliches(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected []string // phrase: text
+	}{
+		{"cherry blossoms fall", []string{"cherry blossoms fall: cherry blossoms fall"}},
+		{"falling cherry blossom", []string{"cherry blossoms fall: falling cherry blossom"}},
+		{"the leaves of autumn drift", []string{"autumn leaves: leaves of autumn"}},
+		{"a frog jumped into the old pond", []string{"frog jumps: frog jumped", "old pond: old pond"}},
+		{"winter's chill", []string{"winter chill: winter's chill"}},
+		{"cherry jam on the table", nil},
+		{"the pond is still", []string{"still pond: pond is still"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			h := haiku.FromSource(tt.line, []string{tt.line})
+			var result []string
+			for _, c := range findCliches(h, 0, tt.line, ExplainLine(tt.line)) {
+				result = append(result, c.Phrase+": "+c.Text)
+				if src := tt.line[c.Span.Start:c.Span.End]; src != c.Text {
+					t.Errorf("cliche %q maps to source text %q", c.Text, src)
+				}
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("findCliches(%q) = %q, want %q", tt.line, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCliches_MatchThemselves(t *testing.T) {
+	for _, c := range cliches {
+		h := haiku.FromSource(c.phrase, []string{c.phrase})
+		found := findCliches(h, 0, c.phrase, ExplainLine(c.phrase))
+		if len(found) != 1 || found[0].Phrase != c.phrase {
+			t.Errorf("corpus phrase %q matches %+v", c.phrase, found)
+		}
+	}
+}
+
+func TestParseCliches_Invalid(t *testing.T) {
+	for _, data := range []string{
+		"old pond",
+		"old pond\tvery high",
+		"the\thigh",
+		"old pond\thigh\npond of old\tlow",
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("parseCliches(%q) did not panic", data)
+				}
+			}()
+			parseCliches(data)
+		}()
+	}
+}
+
+func TestAddCliche(t *testing.T) {
+	saved := slices.Clone(cliches)
+	defer func() { cliches = saved }()
+
+	if err := AddCliche("Rusty Gate", ClicheLow); err != nil {
+		t.Fatalf("AddCliche() error = %v", err)
+	}
+	if err := AddCliche("pond of old", ClicheLow); err != nil {
+		t.Fatalf("AddCliche() error = %v", err)
+	}
+	if err := AddCliche("rusty gate", "rare"); err == nil {
+		t.Error("AddCliche() with unknown tier succeeded, want error")
+	}
+	if !slices.Contains(GetCliches(), "rusty gate") || len(GetCliches()) != len(saved)+1 {
+		t.Errorf("GetCliches() = %v, want the corpus plus rusty gate", GetCliches())
+	}
+
+	line := "the old pond by rusty gates"
+	found := findCliches(haiku.FromSource(line, []string{line}), 0, line, ExplainLine(line))
+	var result []string
+	for _, c := range found {
+		result = append(result, c.Phrase+" ("+c.Tier+")")
+	}
+	if strings.Join(result, ", ") != "pond of old (low), rusty gate (low)" {
+		t.Errorf("findCliches() = %v", result)
+	}
+}
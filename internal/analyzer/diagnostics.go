@@ -0,0 +1,81 @@
+// Package analyzer provides source-position mapping for analysis findings.
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// Diagnostic kinds produced by Analyze.
+const (
+	KindKireji            = "kireji"
+	KindKigo              = "kigo"
+	KindIambicRun         = "iambic-run"
+	KindAdjectiveOverload = "adjective-overload"
+	KindExcessArticles    = "excess-articles"
+	KindPastTense         = "past-tense"
+	KindCliche            = "cliche"
+)
+
+// tokenDiagnostic returns a diagnostic spanning tokens first through last
+// of line i.
+func tokenDiagnostic(h *haiku.Haiku, i int, line, kind string, first, last int) haiku.Diagnostic {
+	tokens := Tokenize(line)
+	start, end := tokens[first].Start, tokens[last].End
+	return haiku.Diagnostic{
+		Kind: kind,
+		Text: line[start:end],
+		Span: h.Locate(i, start, end),
+	}
+}
+
+// locateAll returns a diagnostic for every case-insensitive occurrence of
+// each needle in the haiku lines, mapped back to source positions.
+func locateAll(h *haiku.Haiku, kind string, needles []string) []haiku.Diagnostic {
+	var result []haiku.Diagnostic
+	for i, line := range h.Lines {
+		for _, needle := range needles {
+			for _, start := range indexAllFold(line, needle) {
+				result = append(result, haiku.Diagnostic{
+					Kind: kind,
+					Text: line[start : start+len(needle)],
+					Span: h.Locate(i, start, start+len(needle)),
+				})
+			}
+		}
+	}
+	return result
+}
+
+// sortDiagnostics orders diagnostics by source position, then kind.
+func sortDiagnostics(diags []haiku.Diagnostic) {
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].Span.Start != diags[j].Span.Start {
+			return diags[i].Span.Start < diags[j].Span.Start
+		}
+		return diags[i].Kind < diags[j].Kind
+	})
+}
+
+// indexAllFold returns the byte offsets of every case-insensitive,
+// non-overlapping occurrence of needle in s.
+func indexAllFold(s, needle string) []int {
+	if needle == "" {
+		return nil
+	}
+
+	var result []int
+	for i := 0; i+len(needle) <= len(s); {
+		if strings.EqualFold(s[i:i+len(needle)], needle) {
+			result = append(result, i)
+			i += len(needle)
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+func TestAnalyze_DiagnosticSpans(t *testing.T) {
+	source := "\n    Old pond—\n\n  a frog jumps in\nsplash! Autumn rain"
+	h := haiku.FromSource(source, []string{"Old pond—", "a frog jumps in", "splash! Autumn rain"})
+
+	m := New(0).Analyze(h)
+	if m == nil {
+		t.Fatal("Analyze returned nil")
+	}
+
+	if len(m.Diagnostics) == 0 {
+		t.Fatal("Expected diagnostics")
+	}
+
+	for _, d := range m.Diagnostics {
+		got := source[d.Span.Start:d.Span.End]
+		if got != d.Text {
+			t.Errorf("%s diagnostic %q maps to source text %q", d.Kind, d.Text, got)
+		}
+	}
+
+	var kinds []string
+	for _, d := range m.Diagnostics {
+		kinds = append(kinds, d.Kind+":"+d.Text)
+	}
+	want := []string{"cliche:Old pond", "kireji:—", "cliche:frog jumps", "kireji:!", "kigo:Autumn", "kigo:rain"}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Errorf("Diagnostics = %v, want %v", kinds, want)
+	}
+
+	if m.Diagnostics[0].Span.Line != 2 {
+		t.Errorf("First diagnostic on line %d, want 2", m.Diagnostics[0].Span.Line)
+	}
+}
+
+func TestIndexAllFold(t *testing.T) {
+	tests := []struct {
+		s, needle string
+		expected  []int
+	}{
+		{"Spring spring", "spring", []int{0, 7}},
+		{"café moon", "moon", []int{6}},
+		{"aaa", "aa", []int{0}},
+		{"abc", "", nil},
+		{"abc", "xyz", nil},
+	}
+
+	for _, tt := range tests {
+		result := indexAllFold(tt.s, tt.needle)
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("indexAllFold(%q, %q) = %v, want %v", tt.s, tt.needle, result, tt.expected)
+		}
+	}
+}
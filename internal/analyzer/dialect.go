@@ -0,0 +1,78 @@
+// Package analyzer provides dialect profiles for syllable counting.
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialect names a regional pronunciation profile used when counting syllables.
+type Dialect string
+
+// Supported dialect profiles. General American is the default and matches
+// the built-in exception dictionary.
+const (
+	DialectGeneralAmerican Dialect = "general-american"
+	DialectRP              Dialect = "rp"
+	DialectAustralian      Dialect = "australian"
+	DialectSouthernUS      Dialect = "southern-us"
+)
+
+// dialectAliases maps alternative names accepted by ParseDialect.
+var dialectAliases = map[string]Dialect{
+	"general-american": DialectGeneralAmerican, "ga": DialectGeneralAmerican, "us": DialectGeneralAmerican, "en-us": DialectGeneralAmerican,
+	"rp": DialectRP, "british": DialectRP, "uk": DialectRP, "en-gb": DialectRP,
+	"australian": DialectAustralian, "au": DialectAustralian, "en-au": DialectAustralian,
+	"southern-us": DialectSouthernUS, "southern": DialectSouthernUS,
+}
+
+// dialectSyllables holds each profile's counts where it departs from
+// General American. Non-rhotic RP keeps "fire" and "hour" as two syllables
+// and clips unstressed "-ary"; Australian smooths "fire" to one; Southern US
+// flattens "mirror" and "caramel" and breaks "oil" and "real" in two.
+var dialectSyllables = map[Dialect]map[string]int{
+	DialectGeneralAmerican: {},
+	DialectRP: {
+		"fire": 2, "fires": 2, "fired": 2, "hour": 2, "hours": 2, "our": 2,
+		"flower": 2, "flowers": 2, "power": 2, "tower": 2, "tired": 2,
+		"secretary": 3, "dictionary": 3, "military": 3, "library": 2,
+		"family": 3, "caramel": 3, "mirror": 2, "aluminium": 5,
+		"vegetable": 3, "comfortable": 3, "temperature": 3, "schedule": 2,
+	},
+	DialectAustralian: {
+		"fire": 1, "fires": 1, "fired": 1, "hour": 1, "hours": 1, "our": 1,
+		"flower": 1, "flowers": 1, "power": 1, "tower": 1, "tired": 1,
+		"secretary": 3, "dictionary": 3, "military": 3,
+		"family": 3, "caramel": 3, "mirror": 2, "aluminium": 5,
+		"vegetable": 3, "comfortable": 3, "temperature": 3, "schedule": 2,
+	},
+	DialectSouthernUS: {
+		"fire": 1, "fires": 1, "fired": 1, "tire": 1, "wire": 1, "hour": 1, "our": 1,
+		"flower": 1, "flowers": 1, "power": 1, "tower": 1, "tired": 1,
+		"caramel": 2, "mirror": 1, "family": 2, "oil": 2, "real": 2,
+	},
+}
+
+// Dialects returns the supported dialect profiles.
+func Dialects() []Dialect {
+	return []Dialect{DialectGeneralAmerican, DialectRP, DialectAustralian, DialectSouthernUS}
+}
+
+// ParseDialect returns the dialect with the given name or alias.
+// An empty name selects General American.
+func ParseDialect(name string) (Dialect, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return DialectGeneralAmerican, nil
+	}
+	if d, ok := dialectAliases[name]; ok {
+		return d, nil
+	}
+	return "", fmt.Errorf("unknown dialect %q (want general-american, rp, australian or southern-us)", name)
+}
+
+// lookup returns the dialect's count for word, if it departs from General American.
+func (d Dialect) lookup(word string) (int, bool) {
+	n, ok := dialectSyllables[d][word]
+	return n, ok
+}
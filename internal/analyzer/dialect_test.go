@@ -0,0 +1,87 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/thornzero/haikugo/internal/dict"
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+func TestParseDialect(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Dialect
+		wantErr bool
+	}{
+		{"", DialectGeneralAmerican, false},
+		{"general-american", DialectGeneralAmerican, false},
+		{"RP", DialectRP, false},
+		{"en-gb", DialectRP, false},
+		{"au", DialectAustralian, false},
+		{"southern", DialectSouthernUS, false},
+		{"klingon", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDialect(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseDialect(%q) = %q, %v, want %q (error %v)", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestExplainCount_Dialect(t *testing.T) {
+	tests := []struct {
+		word     string
+		dialect  Dialect
+		want     int
+		min, max int
+		method   string
+	}{
+		{"fire", DialectGeneralAmerican, 1, 1, 2, MethodException},
+		{"fire", DialectRP, 2, 1, 2, MethodDialect},
+		{"fire", DialectSouthernUS, 1, 1, 2, MethodDialect},
+		{"secretary", DialectGeneralAmerican, 4, 4, 4, MethodHeuristic},
+		{"secretary", DialectRP, 3, 3, 4, MethodDialect},
+		{"mirror", DialectSouthernUS, 1, 1, 2, MethodDialect},
+		{"caramel", DialectSouthernUS, 2, 2, 3, MethodDialect},
+		{"oil", DialectSouthernUS, 2, 1, 2, MethodDialect},
+		{"family", DialectAustralian, 3, 2, 3, MethodDialect},
+		{"pond", DialectRP, 1, 1, 1, MethodHeuristic},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.dialect)+"/"+tt.word, func(t *testing.T) {
+			n, lo, hi, method := explainCount(Tokenize(tt.word)[0], nil, tt.dialect)
+			if n != tt.want || lo != tt.min || hi != tt.max || method != tt.method {
+				t.Errorf("explainCount(%q) = %d (%d-%d) %s, want %d (%d-%d) %s",
+					tt.word, n, lo, hi, method, tt.want, tt.min, tt.max, tt.method)
+			}
+		})
+	}
+}
+
+func TestAnalyzer_SetDialect(t *testing.T) {
+	a := New(0)
+	if a.GetDialect() != DialectGeneralAmerican {
+		t.Errorf("Default dialect = %q, want %q", a.GetDialect(), DialectGeneralAmerican)
+	}
+
+	h := haiku.NewHaiku([]string{"the fire at noon", "a frog jumps into the pond", "splash silence again"})
+	if m := a.Analyze(h); m.Dialect != string(DialectGeneralAmerican) || m.LineSyllables[0] != 4 {
+		t.Errorf("General American: dialect %q, line 1 = %d, want 4", m.Dialect, m.LineSyllables[0])
+	}
+
+	a.SetDialect(DialectRP)
+	if m := a.Analyze(h); m.Dialect != string(DialectRP) || m.LineSyllables[0] != 5 {
+		t.Errorf("RP: dialect %q, line 1 = %d, want 5", m.Dialect, m.LineSyllables[0])
+	}
+
+	// User overrides take precedence over the dialect profile.
+	d := dict.New()
+	d.Set("fire", 1, "")
+	a.SetOverrides(d)
+	if m := a.Analyze(h); m.LineSyllables[0] != 4 || m.Tokens[0][1].Method != MethodOverride {
+		t.Errorf("RP with override: line 1 = %d (%s), want 4 (override)", m.LineSyllables[0], m.Tokens[0][1].Method)
+	}
+}
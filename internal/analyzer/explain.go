@@ -0,0 +1,137 @@
+// Package analyzer provides per-token explanations of syllable counts.
+package analyzer
+
+import (
+	"slices"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// Counting methods reported for each token.
+const (
+	MethodOverride   = "override"
+	MethodDialect    = "dialect"
+	MethodException  = "exception"
+	MethodMorphology = "morphology"
+	MethodHeuristic  = "heuristic"
+)
+
+// methodOrder ranks counting methods from most to least certain.
+var methodOrder = []string{MethodOverride, MethodDialect, MethodException, MethodMorphology, MethodHeuristic}
+
+// lookupFunc returns a user override for a word, if one exists.
+type lookupFunc func(word string) (int, bool)
+
+// syllableVariants gives the alternative count of words whose pronunciation
+// commonly varies, e.g. "fire" is one syllable or two and "every" two or three.
+var syllableVariants = map[string]int{
+	"fire": 2, "fired": 2, "hour": 2, "our": 2, "poem": 1, "poems": 1,
+	"every": 3, "family": 3, "camera": 2, "chocolate": 2, "business": 3,
+	"flower": 1, "flowers": 1, "power": 1, "tower": 1, "heaven": 1,
+	"evening": 2, "different": 2, "orange": 1, "real": 2, "quiet": 1,
+	"prism": 1, "wild": 2, "towel": 1, "jewel": 1, "liar": 1,
+}
+
+// ExplainLine breaks a line into tokens and reports how each was counted.
+func ExplainLine(line string) []haiku.WordToken {
+	return explainTokens(nil, 0, line, nil, DialectGeneralAmerican)
+}
+
+// explainTokens counts the tokens of line i of h, consulting lookup for
+// overrides first and then the dialect profile. When h is nil, spans are
+// relative to the line itself.
+func explainTokens(h *haiku.Haiku, i int, line string, lookup lookupFunc, dialect Dialect) []haiku.WordToken {
+	tokens := Tokenize(line)
+	tags := tagTokens(line, tokens)
+	words := make([]haiku.WordToken, 0, len(tokens))
+
+	for j, tok := range tokens {
+		wt := haiku.WordToken{
+			Text:       tok.Text,
+			Normalized: tok.Spoken,
+			Kind:       string(tok.Kind),
+			POS:        tags[j],
+		}
+		wt.Syllables, wt.MinSyllables, wt.MaxSyllables, wt.Method = explainCount(tok, lookup, dialect)
+		wt.Syllabified = syllabifyToken(tok, wt.Syllables)
+		wt.Stress = stressMarks(tokenStress(tok, wt.Syllables))
+		if im, ok := tokenImagery(tok); ok {
+			wt.Concreteness, wt.Senses = im.rating, im.senses
+		}
+
+		if h != nil {
+			wt.Span = h.Locate(i, tok.Start, tok.End)
+		} else {
+			wt.Span = haiku.Span{Line: 1, Column: tok.Start + 1, Start: tok.Start, End: tok.End}
+		}
+		words = append(words, wt)
+	}
+
+	return words
+}
+
+// explainCount counts a token and records the syllable range and the
+// method used. An override of the whole token or of any of its words wins
+// over the built-in counters, and a dialect's count over the exception
+// dictionary. A token made of several words reports the least certain
+// method among them.
+func explainCount(tok Token, lookup lookupFunc, dialect Dialect) (n, lo, hi int, method string) {
+	if lookup != nil {
+		for _, key := range []string{tok.Text, tok.Spoken} {
+			if n, ok := lookup(key); ok {
+				return n, n, n, MethodOverride
+			}
+		}
+	}
+
+	var dlo, dhi int
+	n = countToken(tok, func(word string) int {
+		if lookup != nil {
+			if c, ok := lookup(word); ok {
+				method = lessCertain(method, MethodOverride)
+				return c
+			}
+		}
+
+		base, m := countWord(word)
+		c, ok := dialect.lookup(word)
+		if ok {
+			m = MethodDialect
+		} else {
+			c = base
+		}
+		method = lessCertain(method, m)
+
+		// The range spans the dialect's count, the General American one
+		// and any common variant.
+		alt, ok := syllableVariants[word]
+		if !ok {
+			alt = base
+		}
+		dlo += min(alt-c, base-c, 0)
+		dhi += max(alt-c, base-c, 0)
+		return c
+	})
+
+	if method == "" {
+		method = MethodHeuristic
+	}
+	return n, n + dlo, n + dhi, method
+}
+
+// lessCertain returns the less certain of two counting methods.
+func lessCertain(a, b string) string {
+	if slices.Index(methodOrder, a) > slices.Index(methodOrder, b) {
+		return a
+	}
+	return b
+}
+
+// lineSyllables sums the syllable counts of explained tokens.
+func lineSyllables(words []haiku.WordToken) int {
+	total := 0
+	for _, w := range words {
+		total += w.Syllables
+	}
+	return total
+}
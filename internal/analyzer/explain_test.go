@@ -0,0 +1,131 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thornzero/haikugo/internal/dict"
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+func TestExplainLine(t *testing.T) {
+	tests := []struct {
+		text       string
+		normalized string
+		syllables  int
+		min, max   int
+		method     string
+	}{
+		{"the", "the", 1, 1, 1, MethodException},
+		{"pond", "pond", 1, 1, 1, MethodHeuristic},
+		{"fire", "fire", 1, 1, 2, MethodException},
+		{"poem", "poem", 2, 1, 2, MethodException},
+		{"every", "every", 2, 2, 3, MethodException},
+		{"1999", "nineteen ninety-nine", 5, 5, 5, MethodHeuristic},
+		{"Dr.", "doctor", 2, 2, 2, MethodHeuristic},
+		{"didn't", "didn't", 2, 2, 2, MethodHeuristic},
+		{"jumped", "jumped", 1, 1, 1, MethodMorphology},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			words := ExplainLine(tt.text)
+			if len(words) != 1 {
+				t.Fatalf("ExplainLine(%q) returned %d tokens, want 1", tt.text, len(words))
+			}
+
+			w := words[0]
+			if w.Normalized != tt.normalized {
+				t.Errorf("Normalized = %q, want %q", w.Normalized, tt.normalized)
+			}
+			if w.Syllables != tt.syllables || w.MinSyllables != tt.min || w.MaxSyllables != tt.max {
+				t.Errorf("Syllables = %d (%d-%d), want %d (%d-%d)",
+					w.Syllables, w.MinSyllables, w.MaxSyllables, tt.syllables, tt.min, tt.max)
+			}
+			if w.Method != tt.method {
+				t.Errorf("Method = %q, want %q", w.Method, tt.method)
+			}
+		})
+	}
+}
+
+func TestAnalyze_Tokens(t *testing.T) {
+	source := "  an old silent pond\n  a frog jumps into the pond\n  splash! silence again\n"
+	lines := []string{"an old silent pond", "a frog jumps into the pond", "splash! silence again"}
+	h := haiku.FromSource(source, lines)
+
+	m := New(0).Analyze(h)
+	if len(m.Tokens) != 3 {
+		t.Fatalf("Tokens has %d lines, want 3", len(m.Tokens))
+	}
+
+	for i, words := range m.Tokens {
+		if len(words) != m.LineWords[i] {
+			t.Errorf("Line %d has %d tokens, want %d", i+1, len(words), m.LineWords[i])
+		}
+		if sum := lineSyllables(words); sum != m.LineSyllables[i] {
+			t.Errorf("Line %d token syllables sum to %d, want %d", i+1, sum, m.LineSyllables[i])
+		}
+		for _, w := range words {
+			if got := source[w.Span.Start:w.Span.End]; got != w.Text {
+				t.Errorf("Span of %q covers %q", w.Text, got)
+			}
+		}
+	}
+
+	silent := m.Tokens[0][2]
+	want := haiku.Span{Line: 1, Column: 10, Start: 9, End: 15}
+	if silent.Text != "silent" || silent.Span != want {
+		t.Errorf("Token = %q at %+v, want \"silent\" at %+v", silent.Text, silent.Span, want)
+	}
+}
+
+func TestAnalyze_Overrides(t *testing.T) {
+	d := dict.New()
+	d.Set("ouachita", 3, "")
+	d.Set("hour", 2, "basho")
+	d.Set("1999", 4, "")
+
+	a := New(0)
+	a.SetOverrides(d)
+
+	h := haiku.NewHaiku([]string{"Ouachita river", "in the hour of 1999", "didn't"})
+	m := a.Analyze(h)
+	if got := m.Tokens[0][0]; got.Syllables != 3 || got.Method != MethodOverride {
+		t.Errorf("Ouachita = %d (%s), want 3 (override)", got.Syllables, got.Method)
+	}
+	if got := m.Tokens[1][2]; got.Syllables != 1 || got.Method != MethodException {
+		t.Errorf("hour without author = %d (%s), want 1 (exception)", got.Syllables, got.Method)
+	}
+	if got := m.Tokens[1][4]; got.Syllables != 4 || got.Method != MethodOverride || got.Syllabified != "nine·teen ninety-nine" {
+		t.Errorf("1999 = %d (%s) %q, want 4 (override) in 4 parts", got.Syllables, got.Method, got.Syllabified)
+	}
+
+	h.Author = "Basho"
+	m = a.Analyze(h)
+	if got := m.Tokens[1][2]; got.Syllables != 2 || got.MaxSyllables != 2 || got.Method != MethodOverride {
+		t.Errorf("hour for basho = %+v, want 2 (override)", got)
+	}
+	if got := m.Tokens[1][2].Syllabified; got != "ho·ur" {
+		t.Errorf("hour for basho divided as %q, want ho·ur", got)
+	}
+	if m.LineSyllables[1] != 9 {
+		t.Errorf("LineSyllables[1] = %d, want 9", m.LineSyllables[1])
+	}
+}
+
+func TestAnalyze_DialectSyllabified(t *testing.T) {
+	a := New(0)
+	a.SetDialect(DialectRP)
+	m := a.Analyze(haiku.NewHaiku([]string{"the Hour of fire", "a poem", "every day"}))
+	for _, line := range m.Tokens {
+		for _, tok := range line {
+			if parts := strings.Count(tok.Syllabified, SyllableSeparator) + 1; parts != tok.Syllables {
+				t.Errorf("%q counts %d but divides as %q", tok.Text, tok.Syllables, tok.Syllabified)
+			}
+		}
+	}
+	if got := m.Tokens[0][1].Syllabified; got != "ho·ur" {
+		t.Errorf("Hour in RP divided as %q, want ho·ur", got)
+	}
+}
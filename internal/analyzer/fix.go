@@ -0,0 +1,302 @@
+// Package analyzer provides syllable-fix suggestions backed by an embedded thesaurus.
+package analyzer
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// Edit kinds reported in Edit.Kind.
+const (
+	EditDropArticle = "drop-article"
+	EditAddArticle  = "add-article"
+	EditContract    = "contract"
+	EditExpand      = "expand"
+	EditSynonym     = "synonym"
+)
+
+// editOrder ranks edit kinds from the least to the most change in meaning.
+var editOrder = []string{EditDropArticle, EditAddArticle, EditContract, EditExpand, EditSynonym}
+
+const (
+	// maxFixEdits is the most edits combined in one rewrite.
+	maxFixEdits = 2
+	// maxRewrites is the most rewrites suggested for a line.
+	maxRewrites = 10
+)
+
+// contractionPairs pairs expanded phrases with their contracted forms,
+// including the poetic elisions "o'er", "ne'er" and "e'en".
+var contractionPairs = [][2]string{
+	{"do not", "don't"}, {"does not", "doesn't"}, {"did not", "didn't"},
+	{"is not", "isn't"}, {"are not", "aren't"}, {"was not", "wasn't"},
+	{"were not", "weren't"}, {"has not", "hasn't"}, {"have not", "haven't"},
+	{"will not", "won't"}, {"cannot", "can't"}, {"could not", "couldn't"},
+	{"would not", "wouldn't"}, {"should not", "shouldn't"},
+	{"it is", "it's"}, {"that is", "that's"}, {"there is", "there's"},
+	{"what is", "what's"}, {"i am", "i'm"}, {"you are", "you're"},
+	{"we are", "we're"}, {"they are", "they're"}, {"i will", "i'll"},
+	{"you will", "you'll"}, {"i have", "i've"}, {"let us", "let's"},
+	{"over", "o'er"}, {"never", "ne'er"}, {"even", "e'en"},
+}
+
+//go:embed data/thesaurus.txt
+var thesaurusData string
+
+// thesaurus maps each word to its synonyms.
+var thesaurus = parseThesaurus(thesaurusData)
+
+// parseThesaurus reads the embedded groups of comma-separated synonyms.
+func parseThesaurus(data string) map[string][]string {
+	result := make(map[string][]string)
+	sc := bufio.NewScanner(strings.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var group []string
+		for _, w := range strings.Split(line, ",") {
+			w = strings.ToLower(strings.TrimSpace(w))
+			if w == "" || strings.ContainsFunc(w, unicode.IsSpace) {
+				panic(fmt.Sprintf("thesaurus data: invalid entry %q", line))
+			}
+			group = append(group, w)
+		}
+		if len(group) < 2 {
+			panic(fmt.Sprintf("thesaurus data: group %q has no synonyms", line))
+		}
+
+		for _, w := range group {
+			for _, syn := range group {
+				if syn != w && !slices.Contains(result[w], syn) {
+					result[w] = append(result[w], syn)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// FixLine suggests rewrites that bring line to target syllables. Rewrites
+// that reach the target come first, ranked by the fewest and least
+// intrusive edits, followed by those that only come closer. The line is
+// reported as line 1 and global syllable overrides apply.
+func (a *Analyzer) FixLine(line string, target int) haiku.LineFix {
+	lookup := a.lookup("")
+	return haiku.LineFix{
+		Line:      1,
+		Text:      line,
+		Syllables: lineSyllables(explainTokens(nil, 0, line, lookup, a.dialect)),
+		Target:    target,
+		Rewrites:  fixLine(line, target, lookup, a.dialect),
+	}
+}
+
+// Fix suggests rewrites for each line of h whose count is off 5-7-5 by
+// more than the tolerance.
+func (a *Analyzer) Fix(h *haiku.Haiku) []haiku.LineFix {
+	if !h.IsValid() {
+		return nil
+	}
+
+	expected := []int{5, 7, 5}
+	lookup := a.lookup(h.Author)
+	var fixes []haiku.LineFix
+	for i, line := range h.Lines {
+		n := lineSyllables(explainTokens(h, i, line, lookup, a.dialect))
+		if abs(n-expected[i]) <= a.tolerance {
+			continue
+		}
+		fixes = append(fixes, haiku.LineFix{
+			Line:      i + 1,
+			Text:      line,
+			Syllables: n,
+			Target:    expected[i],
+			Rewrites:  fixLine(line, expected[i], lookup, a.dialect),
+		})
+	}
+	return fixes
+}
+
+// fixLine searches rewrites of up to maxFixEdits edits, keeping only those
+// where every edit moves the count closer to target.
+func fixLine(line string, target int, lookup lookupFunc, dialect Dialect) []haiku.Rewrite {
+	count := func(text string) int {
+		return lineSyllables(explainTokens(nil, 0, text, lookup, dialect))
+	}
+	dist := func(n int) int { return abs(n - target) }
+
+	n := count(line)
+	if n == target {
+		return nil
+	}
+
+	seen := map[string]bool{line: true}
+	frontier := []haiku.Rewrite{{Text: line, Syllables: n}}
+	var rewrites []haiku.Rewrite
+	for depth := 0; depth < maxFixEdits && len(frontier) > 0; depth++ {
+		var next []haiku.Rewrite
+		for _, parent := range frontier {
+			for _, c := range lineEdits(parent.Text) {
+				if seen[c.text] {
+					continue
+				}
+				seen[c.text] = true
+				n := count(c.text)
+				if dist(n) >= dist(parent.Syllables) {
+					continue
+				}
+				r := haiku.Rewrite{Text: c.text, Syllables: n, Edits: append(slices.Clone(parent.Edits), c.edit)}
+				rewrites = append(rewrites, r)
+				if n != target {
+					next = append(next, r)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	sort.SliceStable(rewrites, func(i, j int) bool {
+		a, b := rewrites[i], rewrites[j]
+		if da, db := dist(a.Syllables), dist(b.Syllables); da != db {
+			return da < db
+		}
+		if len(a.Edits) != len(b.Edits) {
+			return len(a.Edits) < len(b.Edits)
+		}
+		return editRank(a.Edits) < editRank(b.Edits)
+	})
+	if len(rewrites) > maxRewrites {
+		rewrites = rewrites[:maxRewrites]
+	}
+	return rewrites
+}
+
+// editRank sums the positions of the edits' kinds in editOrder.
+func editRank(edits []haiku.Edit) int {
+	rank := 0
+	for _, e := range edits {
+		rank += slices.Index(editOrder, e.Kind)
+	}
+	return rank
+}
+
+// candidate is a line with one edit applied.
+type candidate struct {
+	text string
+	edit haiku.Edit
+}
+
+// lineEdits returns every single edit of text: dropping or adding an
+// article, contracting or expanding, and swapping a word for a synonym.
+func lineEdits(text string) []candidate {
+	tokens := Tokenize(text)
+	tags := tagTokens(text, tokens)
+	lower := make([]string, len(tokens))
+	for j, t := range tokens {
+		lower[j] = strings.ToLower(strings.ReplaceAll(t.Text, "’", "'"))
+	}
+
+	var result []candidate
+	replace := func(kind string, start, end int, to string) {
+		result = append(result, candidate{
+			text: text[:start] + to + text[end:],
+			edit: haiku.Edit{Kind: kind, From: text[start:end], To: to},
+		})
+	}
+
+	for j, t := range tokens {
+		// "the pond" -> "pond", keeping a capital at the start of the line.
+		if slices.Contains(articles, lower[j]) && j+1 < len(tokens) {
+			next := tokens[j+1]
+			replace(EditDropArticle, t.Start, next.End, matchCase(t.Text, next.Text))
+		}
+
+		// "old pond" -> "the old pond", before the whole noun phrase.
+		if tags[j] == TagNoun && (j+1 == len(tokens) || tags[j+1] != TagNoun) {
+			k := j
+			for k > 0 && (tags[k-1] == TagAdjective || tags[k-1] == TagNoun) {
+				k--
+			}
+			if k == 0 || !slices.Contains([]string{TagDeterminer, TagPronoun, TagNumeral}, tags[k-1]) {
+				phrase := text[tokens[k].Start:t.End]
+				to := "the " + phrase
+				if k == 0 && startsUpper(phrase) {
+					to = "The " + lowerFirst(phrase)
+				}
+				replace(EditAddArticle, tokens[k].Start, t.End, to)
+			}
+		}
+
+		for _, pair := range contractionPairs {
+			if lower[j] == pair[1] {
+				replace(EditExpand, t.Start, t.End, matchCase(t.Text, pair[0]))
+				continue
+			}
+			words := strings.Fields(pair[0])
+			if j+len(words) <= len(tokens) && slices.Equal(lower[j:j+len(words)], words) {
+				replace(EditContract, t.Start, tokens[j+len(words)-1].End, matchCase(t.Text, pair[1]))
+			}
+		}
+
+		if t.Kind == TokenWord {
+			for _, syn := range synonymsOf(lower[j]) {
+				replace(EditSynonym, t.Start, t.End, matchCase(t.Text, syn))
+			}
+		}
+	}
+	return result
+}
+
+// synonymsOf returns the synonyms of word, inflected to match a plural or
+// third-person "-s" when only the base form is in the thesaurus.
+func synonymsOf(word string) []string {
+	if syns, ok := thesaurus[word]; ok {
+		return syns
+	}
+	for _, suffix := range []string{"es", "s"} {
+		base, ok := strings.CutSuffix(word, suffix)
+		if !ok {
+			continue
+		}
+		syns, ok := thesaurus[base]
+		if !ok {
+			continue
+		}
+		result := make([]string, len(syns))
+		for i, s := range syns {
+			if endsSibilant(s) {
+				result[i] = s + "es"
+			} else {
+				result[i] = s + "s"
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// matchCase capitalizes s when src starts with an upper-case letter.
+func matchCase(src, s string) string {
+	if !startsUpper(src) {
+		return s
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// lowerFirst lower-cases the first letter of s.
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
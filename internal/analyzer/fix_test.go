@@ -0,0 +1,155 @@
+package analyzer
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+func TestParseThesaurus(t *testing.T) {
+	th := parseThesaurus("# comment\nquiet, silent, still\nsilence, hush\n\nstill, calm\n")
+	expected := map[string][]string{
+		"quiet":   {"silent", "still"},
+		"silent":  {"quiet", "still"},
+		"still":   {"quiet", "silent", "calm"},
+		"silence": {"hush"},
+		"hush":    {"silence"},
+		"calm":    {"still"},
+	}
+	if !reflect.DeepEqual(th, expected) {
+		t.Errorf("parseThesaurus() = %v, want %v", th, expected)
+	}
+
+	for _, data := range []string{"lonely", "deep night, midnight", "pond,,pool"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("parseThesaurus(%q) did not panic", data)
+				}
+			}()
+			parseThesaurus(data)
+		}()
+	}
+}
+
+func TestSynonymsOf(t *testing.T) {
+	tests := []struct {
+		word     string
+		contains string
+	}{
+		{"quiet", "silent"},
+		{"rivers", "streams"},
+		{"woods", "forest"},
+		{"pebbles", "stones"},
+		{"pools", "ponds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			syns := synonymsOf(tt.word)
+			found := false
+			for _, s := range syns {
+				found = found || s == tt.contains
+			}
+			if !found {
+				t.Errorf("synonymsOf(%q) = %v, want it to contain %q", tt.word, syns, tt.contains)
+			}
+		})
+	}
+
+	if syns := synonymsOf("frog"); syns != nil {
+		t.Errorf("synonymsOf(%q) = %v, want nil", "frog", syns)
+	}
+}
+
+func TestLineEdits(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected []string // kind: from -> to
+	}{
+		{"the pond", []string{"drop-article: the pond -> pond", "synonym: pond -> pool", "synonym: pond -> mere"}},
+		{"The pond", []string{"drop-article: The pond -> Pond", "synonym: pond -> pool", "synonym: pond -> mere"}},
+		{"old pond", []string{"synonym: old -> ancient", "synonym: old -> aged", "add-article: old pond -> the old pond", "synonym: pond -> pool", "synonym: pond -> mere"}},
+		{"I do not", []string{"contract: do not -> don't"}},
+		{"it's late", []string{"expand: it's -> it is"}},
+		{"Over the hill", []string{
+			"contract: Over -> O'er", "synonym: Over -> Above",
+			"drop-article: the hill -> hill", "synonym: hill -> mountain", "synonym: hill -> peak",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			var result []string
+			for _, c := range lineEdits(tt.line) {
+				result = append(result, c.edit.Kind+": "+c.edit.From+" -> "+c.edit.To)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("lineEdits(%q) = %q, want %q", tt.line, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFixLine(t *testing.T) {
+	tests := []struct {
+		line   string
+		target int
+		first  string
+		edits  int
+	}{
+		{"the little frogs leap over stones", 7, "little frogs leap over stones", 1},
+		{"I do not hear the quiet river", 7, "I don't hear quiet river", 2},
+		{"splash", 2, "the splash", 1},
+	}
+
+	a := New(0)
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			rewrites := a.FixLine(tt.line, tt.target).Rewrites
+			if len(rewrites) == 0 {
+				t.Fatalf("FixLine(%q, %d) = no rewrites", tt.line, tt.target)
+			}
+			r := rewrites[0]
+			if r.Text != tt.first || r.Syllables != tt.target || len(r.Edits) != tt.edits {
+				t.Errorf("FixLine(%q, %d)[0] = %+v, want %q with %d edits", tt.line, tt.target, r, tt.first, tt.edits)
+			}
+			for _, r := range rewrites {
+				if n := CountLineSyllables(r.Text); n != r.Syllables {
+					t.Errorf("rewrite %q reports %d syllables, counted %d", r.Text, r.Syllables, n)
+				}
+			}
+		})
+	}
+}
+
+func TestFixLine_AtTarget(t *testing.T) {
+	f := New(0).FixLine("an old silent pond", 5)
+	if f.Syllables != 5 || f.Rewrites != nil {
+		t.Errorf("FixLine() = %+v, want 5 syllables and no rewrites", f)
+	}
+}
+
+func TestFix(t *testing.T) {
+	h := haiku.FromSource("", []string{"an old silent pond", "a frog jumps into the cold pond", "the frog is still"})
+	a := New(0)
+	fixes := a.Fix(h)
+	var lines []string
+	for _, f := range fixes {
+		lines = append(lines, fmt.Sprintf("%s/%d/%d", f.Text, f.Syllables, f.Target))
+		if len(f.Rewrites) == 0 || f.Rewrites[0].Syllables != f.Target {
+			t.Errorf("line %d: rewrites %+v do not reach %d", f.Line, f.Rewrites, f.Target)
+		}
+	}
+	expected := []string{"a frog jumps into the cold pond/8/7", "the frog is still/4/5"}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Fix() lines = %q, want %q", lines, expected)
+	}
+
+	a.SetTolerance(1)
+	if fixes := a.Fix(h); fixes != nil {
+		t.Errorf("Fix() with tolerance 1 = %+v, want nil", fixes)
+	}
+}
@@ -0,0 +1,167 @@
+// Package analyzer provides concreteness and sense scoring of a haiku's imagery.
+package analyzer
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// Senses a word can engage, reported in WordToken.Senses and Metrics.Senses.
+const (
+	SenseSight = "sight"
+	SenseSound = "sound"
+	SenseTouch = "touch"
+	SenseSmell = "smell"
+	SenseTaste = "taste"
+)
+
+// senseOrder lists the senses in the order they are reported.
+var senseOrder = []string{SenseSight, SenseSound, SenseTouch, SenseSmell, SenseTaste}
+
+const (
+	// abstractThreshold is the concreteness below which a word is abstract.
+	abstractThreshold = 2.5
+	// abstractSuffixRating rates unlisted words with an abstract suffix.
+	abstractSuffixRating = 1.8
+)
+
+// abstractSuffixes form abstract nouns: "tenderness", "clarity", "kinship".
+var abstractSuffixes = []string{"ness", "ity", "ment", "tion", "sion", "ism", "ence", "ance", "hood", "ship", "dom"}
+
+//go:embed data/concreteness.txt
+var concretenessData string
+
+// imagery is a word's concreteness rating and the senses it engages.
+type imagery struct {
+	rating float64
+	senses []string
+}
+
+// concretenessLexicon maps words to their imagery.
+var concretenessLexicon = parseConcreteness(concretenessData)
+
+// parseConcreteness reads the embedded word<TAB>rating[<TAB>senses] list.
+func parseConcreteness(data string) map[string]imagery {
+	lexicon := make(map[string]imagery)
+	sc := bufio.NewScanner(strings.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 || len(fields) > 3 {
+			panic(fmt.Sprintf("concreteness data: invalid entry %q", line))
+		}
+		rating, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || rating < 1 || rating > 5 {
+			panic(fmt.Sprintf("concreteness data: invalid rating in %q", line))
+		}
+
+		im := imagery{rating: rating}
+		if len(fields) == 3 {
+			im.senses = strings.Split(fields[2], ",")
+			for _, s := range im.senses {
+				if !slices.Contains(senseOrder, s) {
+					panic(fmt.Sprintf("concreteness data: unknown sense %q in %q", s, line))
+				}
+			}
+		}
+		lexicon[fields[0]] = im
+	}
+	return lexicon
+}
+
+// wordImagery returns the imagery of a word: listed words and the bases of
+// their inflections from the lexicon, and unlisted words with an abstract
+// suffix as abstract. Other words are unrated.
+func wordImagery(word string) (imagery, bool) {
+	word = strings.ToLower(word)
+	if im, ok := concretenessLexicon[word]; ok {
+		return im, true
+	}
+	if hasAnySuffix(word, abstractSuffixes) && len(word) > 5 {
+		return imagery{rating: abstractSuffixRating}, true
+	}
+	if a, ok := splitSuffix(word); ok {
+		for _, base := range []string{a.base, a.stem} {
+			if im, ok := concretenessLexicon[base]; ok {
+				return im, true
+			}
+		}
+	}
+	return imagery{}, false
+}
+
+// tokenImagery rates a token by the mean of its rated spoken words and the
+// senses any of them engage.
+func tokenImagery(t Token) (imagery, bool) {
+	var im imagery
+	rated := 0
+	for _, part := range strings.FieldsFunc(t.Spoken, func(r rune) bool { return r == ' ' || r == '-' }) {
+		p, ok := wordImagery(part)
+		if !ok {
+			continue
+		}
+		rated++
+		im.rating += p.rating
+		for _, s := range p.senses {
+			if !slices.Contains(im.senses, s) {
+				im.senses = append(im.senses, s)
+			}
+		}
+	}
+	if rated == 0 {
+		return imagery{}, false
+	}
+	im.rating /= float64(rated)
+	sortSenses(im.senses)
+	return im, true
+}
+
+// scoreImagery sets the poem's mean concreteness, its abstract words and
+// the senses it engages from the rated tokens of each line.
+func scoreImagery(m *haiku.Metrics) {
+	var total float64
+	rated := 0
+	m.AbstractWords = []string{}
+	m.Senses = []string{}
+
+	for _, words := range m.Tokens {
+		for _, w := range words {
+			if w.Concreteness == 0 {
+				continue
+			}
+			rated++
+			total += w.Concreteness
+
+			if key := strings.ToLower(w.Normalized); w.Concreteness < abstractThreshold && !slices.Contains(m.AbstractWords, key) {
+				m.AbstractWords = append(m.AbstractWords, key)
+			}
+			for _, s := range w.Senses {
+				if !slices.Contains(m.Senses, s) {
+					m.Senses = append(m.Senses, s)
+				}
+			}
+		}
+	}
+
+	if rated > 0 {
+		m.Concreteness = total / float64(rated)
+	}
+	sortSenses(m.Senses)
+}
+
+// sortSenses orders senses as in senseOrder.
+func sortSenses(senses []string) {
+	slices.SortFunc(senses, func(a, b string) int {
+		return slices.Index(senseOrder, a) - slices.Index(senseOrder, b)
+	})
+}
@@ -0,0 +1,108 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+func TestWordImagery(t *testing.T) {
+	tests := []struct {
+		word   string
+		rating float64
+		senses []string
+		ok     bool
+	}{
+		// Lexicon
+		{"pond", 4.92, []string{SenseSight}, true},
+		{"Rain", 4.97, []string{SenseSight, SenseSound, SenseTouch}, true},
+		{"love", 2.07, nil, true},
+
+		// Inflections use their base
+		{"blossoms", 4.71, []string{SenseSight, SenseSmell}, true},
+		{"frogs", 5.00, []string{SenseSight, SenseSound}, true},
+		{"croaking", 3.80, []string{SenseSound}, true},
+
+		// Abstract suffixes
+		{"tenderness", abstractSuffixRating, nil, true},
+		{"clarity", abstractSuffixRating, nil, true},
+
+		// Unrated
+		{"the", 0, nil, false},
+		{"jumps", 0, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			im, ok := wordImagery(tt.word)
+			if ok != tt.ok || im.rating != tt.rating || !reflect.DeepEqual(im.senses, tt.senses) {
+				t.Errorf("wordImagery(%q) = %+v, %v, want {%v %v}, %v", tt.word, im, ok, tt.rating, tt.senses, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTokenImagery(t *testing.T) {
+	tokens := Tokenize("ice-cold")
+	im, ok := tokenImagery(tokens[0])
+	if !ok {
+		t.Fatal("tokenImagery(ice-cold) unrated")
+	}
+	if want := (4.93 + 3.89) / 2; im.rating != want {
+		t.Errorf("rating = %v, want %v", im.rating, want)
+	}
+	if want := []string{SenseSight, SenseTouch}; !reflect.DeepEqual(im.senses, want) {
+		t.Errorf("senses = %v, want %v", im.senses, want)
+	}
+}
+
+func TestAnalyze_Imagery(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		min, max float64
+		abstract []string
+		senses   []string
+	}{
+		{
+			name:     "concrete",
+			lines:    []string{"an old silent pond", "a frog jumps into the pond", "splash! silence again"},
+			min:      4,
+			max:      5,
+			abstract: []string{},
+			senses:   []string{SenseSight, SenseSound},
+		},
+		{
+			name:     "abstract",
+			lines:    []string{"love and sadness here", "the beauty of my lost hope", "eternal longing"},
+			min:      1,
+			max:      2,
+			abstract: []string{"love", "sadness", "beauty", "hope", "eternal", "longing"},
+			senses:   []string{},
+		},
+		{
+			name:     "senses",
+			lines:    []string{"cold rain on my hands", "the sweet smell of pine needles", "a temple bell rings"},
+			min:      3.5,
+			max:      5,
+			abstract: []string{},
+			senses:   []string{SenseSight, SenseSound, SenseTouch, SenseSmell, SenseTaste},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(0).Analyze(haiku.NewHaiku(tt.lines))
+			if m.Concreteness < tt.min || m.Concreteness > tt.max {
+				t.Errorf("Concreteness = %.2f, want %.1f-%.1f", m.Concreteness, tt.min, tt.max)
+			}
+			if !reflect.DeepEqual(m.AbstractWords, tt.abstract) {
+				t.Errorf("AbstractWords = %v, want %v", m.AbstractWords, tt.abstract)
+			}
+			if !reflect.DeepEqual(m.Senses, tt.senses) {
+				t.Errorf("Senses = %v, want %v", m.Senses, tt.senses)
+			}
+		})
+	}
+}
@@ -2,33 +2,86 @@
 package analyzer
 
 import (
+	"fmt"
+	"slices"
 	"sort"
 	"strings"
 )
 
-// kigo contains a minimal set of season words for haiku analysis.
+// Seasons of the built-in season words.
+const (
+	SeasonSpring = "spring"
+	SeasonSummer = "summer"
+	SeasonAutumn = "autumn"
+	SeasonWinter = "winter"
+	SeasonAll    = "all" // words used across seasons
+)
+
+// seasons lists the seasons in calendar order.
+var seasons = []string{SeasonSpring, SeasonSummer, SeasonAutumn, SeasonWinter, SeasonAll}
+
+// kigoBySeason contains a minimal set of season words for haiku analysis.
 // In traditional Japanese haiku, kigo are essential seasonal references.
 // This is a basic English approximation for educational purposes.
-var kigo = []string{
-	// Spring
-	"spring", "blossom", "cherry", "plum", "thaw", "sprout", "bloom", "nest",
-	"robin", "tulip", "daffodil", "crocus", "easter", "rain", "shower",
-
-	// Summer
-	"summer", "cicada", "firefly", "thunder", "lightning", "monsoon", "heat",
-	"sweat", "beach", "vacation", "pool", "sun", "sunlight", "bright",
-
-	// Autumn/Fall
-	"autumn", "fall", "harvest", "maple", "leaf", "leaves", "cricket", "apple",
-	"pumpkin", "orange", "golden", "acorn", "migration", "geese", "cool",
-
-	// Winter
-	"winter", "snow", "frost", "ice", "blizzard", "solstice", "cold", "freeze",
-	"icicle", "mittens", "scarf", "fireplace", "bare", "gray", "grey",
-
-	// Universal/Multiple seasons
-	"moon", "full moon", "new moon", "crescent", "stars", "dew", "mist", "fog",
-	"wind", "breeze", "cloud", "sky", "earth", "mountain", "river", "lake",
+var kigoBySeason = map[string][]string{
+	SeasonSpring: {
+		"spring", "blossom", "cherry", "plum", "thaw", "sprout", "bloom", "nest",
+		"robin", "tulip", "daffodil", "crocus", "easter", "rain", "shower",
+	},
+	SeasonSummer: {
+		"summer", "cicada", "firefly", "thunder", "lightning", "monsoon", "heat",
+		"sweat", "beach", "vacation", "pool", "sun", "sunlight", "bright",
+	},
+	SeasonAutumn: {
+		"autumn", "fall", "harvest", "maple", "leaf", "leaves", "cricket", "apple",
+		"pumpkin", "orange", "golden", "acorn", "migration", "geese", "cool",
+	},
+	SeasonWinter: {
+		"winter", "snow", "frost", "ice", "blizzard", "solstice", "cold", "freeze",
+		"icicle", "mittens", "scarf", "fireplace", "bare", "gray", "grey",
+	},
+	SeasonAll: {
+		"moon", "full moon", "new moon", "crescent", "stars", "dew", "mist", "fog",
+		"wind", "breeze", "cloud", "sky", "earth", "mountain", "river", "lake",
+	},
+}
+
+// kigo lists the season words in season order. AddSeasonWord extends it.
+var kigo = func() []string {
+	var words []string
+	for _, s := range seasons {
+		words = append(words, kigoBySeason[s]...)
+	}
+	return words
+}()
+
+// Seasons returns the seasons of the built-in season words.
+func Seasons() []string {
+	return slices.Clone(seasons)
+}
+
+// ParseSeason returns the season with the given name, accepting "fall" for autumn.
+func ParseSeason(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "fall" {
+		return SeasonAutumn, nil
+	}
+	if !slices.Contains(seasons, name) {
+		return "", fmt.Errorf("unknown season %q (want spring, summer, autumn, winter or all)", name)
+	}
+	return name, nil
+}
+
+// SeasonOf returns the season of a built-in season word, or "" for other
+// words, including those added with AddSeasonWord.
+func SeasonOf(word string) string {
+	word = strings.ToLower(word)
+	for _, s := range seasons {
+		if slices.Contains(kigoBySeason[s], word) {
+			return s
+		}
+	}
+	return ""
 }
 
 // DetectSeasonWords finds season-related words (kigo) in the given text.
@@ -113,3 +113,46 @@ func TestAddSeasonWord(t *testing.T) {
 		t.Error("Should have found the custom season word")
 	}
 }
+
+func TestParseSeason(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		wantErr  bool
+	}{
+		{"spring", SeasonSpring, false},
+		{" Winter ", SeasonWinter, false},
+		{"fall", SeasonAutumn, false},
+		{"all", SeasonAll, false},
+		{"monsoon", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseSeason(tt.name)
+			if (err != nil) != tt.wantErr || result != tt.expected {
+				t.Errorf("ParseSeason(%q) = %q, %v, want %q, error %v", tt.name, result, err, tt.expected, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSeasonOf(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected string
+	}{
+		{"cicada", SeasonSummer},
+		{"Maple", SeasonAutumn},
+		{"moon", SeasonAll},
+		{"frog", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if result := SeasonOf(tt.word); result != tt.expected {
+				t.Errorf("SeasonOf(%q) = %q, want %q", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Package analyzer provides stress pattern and meter analysis of lines.
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// Metrical feet reported in LineMeter.Foot.
+const (
+	FootIamb    = "iamb"
+	FootTrochee = "trochee"
+	FootAnapest = "anapest"
+	FootDactyl  = "dactyl"
+)
+
+// feet lists the feet dominantFoot tries, in order of preference on a tie.
+var feet = []struct {
+	name, marks string
+}{
+	{FootIamb, "x/"},
+	{FootTrochee, "/x"},
+	{FootAnapest, "xx/"},
+	{FootDactyl, "/xx"},
+}
+
+const (
+	// minFootSyllables is the shortest line with a dominant foot.
+	minFootSyllables = 4
+	// dominantFootShare is the share of syllables that must fit a foot.
+	dominantFootShare = 0.8
+	// singSongFeet is the length of an iambic run that reads as sing-song.
+	singSongFeet = 3
+)
+
+// ScanLine reports the stress pattern and meter of a single line.
+func ScanLine(line string) haiku.LineMeter {
+	m, _, _ := scanTokens(explainTokens(nil, 0, line, nil, DialectGeneralAmerican))
+	return m
+}
+
+// scanTokens scans the stress of explained tokens. It also returns the
+// indexes of the first and last token of the longest iambic run, or -1
+// when the line has none.
+func scanTokens(words []haiku.WordToken) (m haiku.LineMeter, first, last int) {
+	var marks strings.Builder
+	var owner []int // token index of each syllable
+	for j, w := range words {
+		marks.WriteString(w.Stress)
+		for range len(w.Stress) {
+			owner = append(owner, j)
+		}
+	}
+
+	s := marks.String()
+	m.Pattern = strings.Join(strings.Split(s, ""), " ")
+	m.Foot = dominantFoot(s)
+
+	run, start := iambicRun(s)
+	m.IambicRun = run
+	m.SingSong = run >= singSongFeet || (run >= 2 && m.Foot == FootIamb)
+	if run == 0 {
+		return m, -1, -1
+	}
+	return m, owner[start], owner[start+2*run-1]
+}
+
+// dominantFoot returns the foot that the marks fit best from the start of
+// the line, allowing a partial foot at the end, or "" when no foot fits
+// at least dominantFootShare of the syllables.
+func dominantFoot(marks string) string {
+	if len(marks) < minFootSyllables {
+		return ""
+	}
+
+	best, bestShare := "", 0.0
+	for _, f := range feet {
+		matches := 0
+		for i := range len(marks) {
+			if marks[i] == f.marks[i%len(f.marks)] {
+				matches++
+			}
+		}
+		share := float64(matches) / float64(len(marks))
+		if share >= dominantFootShare && share > bestShare {
+			best, bestShare = f.name, share
+		}
+	}
+	return best
+}
+
+// iambicRun returns the length in feet of the longest run of consecutive
+// iambs ("x/") in marks and the syllable index where it starts.
+func iambicRun(marks string) (run, start int) {
+	for i := range len(marks) {
+		n := 0
+		for j := i; j+1 < len(marks) && marks[j] == UnstressedMark && marks[j+1] == StressedMark; j += 2 {
+			n++
+		}
+		if n > run {
+			run, start = n, i
+		}
+	}
+	return run, start
+}
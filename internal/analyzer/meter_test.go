@@ -0,0 +1,110 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+func TestScanLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected haiku.LineMeter
+	}{
+		{"a frog jumps into the pond", haiku.LineMeter{Pattern: "x / / / x x /", IambicRun: 1}},
+		{"the light upon the hill", haiku.LineMeter{Pattern: "x / x / x /", Foot: FootIamb, IambicRun: 3, SingSong: true}},
+		{"the petals fall along the stream", haiku.LineMeter{Pattern: "x / x / x / x /", Foot: FootIamb, IambicRun: 4, SingSong: true}},
+		{"alone upon the shore", haiku.LineMeter{Pattern: "x / x / x /", Foot: FootIamb, IambicRun: 3, SingSong: true}},
+		{"whispering willows", haiku.LineMeter{Pattern: "/ x x / x", Foot: FootDactyl, IambicRun: 1}},
+		{"frogs jump, rain falls", haiku.LineMeter{Pattern: "/ / / /"}},
+		{"winter evening", haiku.LineMeter{Pattern: "/ x / x x", Foot: FootTrochee, IambicRun: 1}},
+		{"pond", haiku.LineMeter{Pattern: "/"}},
+		{"", haiku.LineMeter{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			if result := ScanLine(tt.line); result != tt.expected {
+				t.Errorf("ScanLine(%q) = %+v, want %+v", tt.line, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDominantFoot(t *testing.T) {
+	tests := []struct {
+		marks    string
+		expected string
+	}{
+		{"x/x/x", FootIamb},
+		{"/x/x/", FootTrochee},
+		{"xx/xx/x", FootAnapest},
+		{"/xx/xx/", FootDactyl},
+		{"x/x/x/xx", FootIamb},
+		{"////", ""},
+		{"x/x", ""},
+	}
+
+	for _, tt := range tests {
+		if result := dominantFoot(tt.marks); result != tt.expected {
+			t.Errorf("dominantFoot(%q) = %q, want %q", tt.marks, result, tt.expected)
+		}
+	}
+}
+
+func TestIambicRun(t *testing.T) {
+	tests := []struct {
+		marks string
+		run   int
+		start int
+	}{
+		{"x/x/x/", 3, 0},
+		{"//x/x/x", 2, 2},
+		{"/x//", 1, 1},
+		{"//", 0, 0},
+		{"", 0, 0},
+	}
+
+	for _, tt := range tests {
+		run, start := iambicRun(tt.marks)
+		if run != tt.run || start != tt.start {
+			t.Errorf("iambicRun(%q) = %d, %d, want %d, %d", tt.marks, run, start, tt.run, tt.start)
+		}
+	}
+}
+
+func TestAnalyze_Meter(t *testing.T) {
+	source := "old pond\n  the petals fall along the stream\nsplash"
+	h := haiku.FromSource(source, []string{"old pond", "the petals fall along the stream", "splash"})
+
+	m := New(0).Analyze(h)
+	if len(m.Meter) != 3 {
+		t.Fatalf("Meter has %d lines, want 3", len(m.Meter))
+	}
+	if m.Meter[0].SingSong || !m.Meter[1].SingSong || m.Meter[2].SingSong {
+		t.Errorf("SingSong = %v %v %v, want false true false", m.Meter[0].SingSong, m.Meter[1].SingSong, m.Meter[2].SingSong)
+	}
+
+	var found bool
+	for _, d := range m.Diagnostics {
+		if d.Kind != KindIambicRun {
+			continue
+		}
+		found = true
+		if d.Text != "the petals fall along the stream" {
+			t.Errorf("iambic-run diagnostic text = %q", d.Text)
+		}
+		if got := source[d.Span.Start:d.Span.End]; got != d.Text {
+			t.Errorf("iambic-run diagnostic maps to source text %q", got)
+		}
+	}
+	if !found {
+		t.Error("Expected an iambic-run diagnostic")
+	}
+
+	for _, w := range m.Tokens[1] {
+		if len(w.Stress) != w.Syllables {
+			t.Errorf("token %q: stress %q for %d syllables", w.Text, w.Stress, w.Syllables)
+		}
+	}
+}
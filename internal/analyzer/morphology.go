@@ -0,0 +1,216 @@
+// Package analyzer provides morphological rules for counting unknown words.
+package analyzer
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// syllabicSuffixes always add one syllable to the stem they follow.
+var syllabicSuffixes = []string{"ness", "ment", "less", "ful", "ing", "ly"}
+
+// vowelPrefixes are prefixes counted separately when the stem after them
+// begins with one of the given strings, which the vowel-group heuristic
+// would otherwise merge: "re-open", "pre-order", "de-ice", "co-exist",
+// "re-elect", "re-appear", "co-operate". Stems beginning with 'e' or 'a'
+// are spelled out further so that "reel", "ready" and "reason" are left
+// alone.
+var vowelPrefixes = []struct {
+	prefix string
+	starts []string
+}{
+	{"pre", reStarts},
+	{"re", reStarts},
+	{"de", []string{"o", "i", "u"}},
+	{"co", []string{"e", "i", "ord", "operat"}},
+}
+
+// reStarts are the stem beginnings after "re-" and "pre-".
+var reStarts = []string{
+	"o", "i", "u",
+	"el", "em", "en", "es", "eva", "ex",
+	"act", "adj", "aff", "app", "arr", "ass", "att", "awa",
+}
+
+// affix describes a word divided into a stem and a prefix or suffix. The
+// stem is counted as base, which restores letters dropped in spelling:
+// "stones" is counted from "stone" and "happiness" from "happy".
+type affix struct {
+	prefix, stem, suffix string
+	base                 string
+	syllabic             bool // the affix adds a syllable to the stem
+}
+
+// countMorphology counts a lowercase word by splitting off a known suffix
+// or prefix and counting the stem. It reports false when no rule applies.
+func countMorphology(word string) (int, bool) {
+	a, ok := splitAffix(word)
+	if !ok {
+		return 0, false
+	}
+	n := countStem(a.base)
+	if a.syllabic {
+		n++
+	}
+	return n, true
+}
+
+// splitAffix divides a word at a known suffix or, failing that, a prefix.
+func splitAffix(word string) (affix, bool) {
+	if a, ok := splitSuffix(word); ok {
+		return a, true
+	}
+	return splitPrefix(word)
+}
+
+// splitSuffix applies the inflectional and derivational suffix rules.
+func splitSuffix(word string) (affix, bool) {
+	switch {
+	case strings.HasSuffix(word, "ed"):
+		return splitPast(word[:len(word)-2])
+	case strings.HasSuffix(word, "es"):
+		return splitPluralES(word[:len(word)-2])
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") &&
+		!strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		stem := word[:len(word)-1]
+		if len(stem) < 3 || !hasVowel(stem) {
+			return affix{}, false
+		}
+		return affix{stem: stem, base: stem, suffix: "s"}, true
+	}
+
+	for _, suffix := range syllabicSuffixes {
+		if !strings.HasSuffix(word, suffix) {
+			continue
+		}
+		stem := word[:len(word)-len(suffix)]
+		if len(stem) < 2 || !hasVowel(stem) {
+			return affix{}, false
+		}
+		a := affix{stem: stem, base: stem, suffix: suffix, syllabic: true}
+		// "happiness" and "loneliness" are counted from "happy" and "lonely".
+		if suffix != "ing" && strings.HasSuffix(stem, "i") {
+			a.base = stem[:len(stem)-1] + "y"
+		}
+		if suffix == "ing" && isException(stem+"e") {
+			a.base = stem + "e"
+		}
+		return a, true
+	}
+
+	return affix{}, false
+}
+
+// splitPast divides a word ending in "-ed". The ending is a syllable only
+// after 't' or 'd' ("wanted", "faded"); otherwise it is silent ("jumped",
+// "walked"). Stems ending in a vowel, such as "freed" or "tried", or in a
+// vowel pair sounded as two syllables, such as "ruined", are left to the
+// vowel-group heuristic.
+func splitPast(stem string) (affix, bool) {
+	last, _ := utf8.DecodeLastRuneInString(stem)
+	if len(stem) < 2 || !hasVowel(stem) || isVowel(last) || endsInHiatus(stem) {
+		return affix{}, false
+	}
+
+	a := affix{stem: stem, base: stem, suffix: "ed"}
+	if isException(stem+"e") || prefixedWithE(stem) {
+		a.base = stem + "e"
+	}
+
+	switch {
+	case last == 't' || last == 'd':
+		a.syllabic = true
+	case clusterBefore(stem, 'l'):
+		// "tumbled" -> "tumble"
+		a.base = stem + "e"
+	case clusterBefore(stem, 'r'):
+		// "hundred", "sacred"
+		a.syllabic = true
+	}
+	return a, true
+}
+
+// splitPluralES divides a word ending in "-es". The ending is a syllable
+// after a sibilant ("boxes", "roses", "pages") and silent after a silent
+// 'e' ("stones") or a vowel ("heroes").
+func splitPluralES(stem string) (affix, bool) {
+	last, _ := utf8.DecodeLastRuneInString(stem)
+	if len(stem) < 2 || !hasVowel(stem) {
+		return affix{}, false
+	}
+
+	a := affix{stem: stem, base: stem, suffix: "es"}
+	switch {
+	case isVowel(last):
+	case endsSibilant(stem):
+		a.syllabic = true
+		if prefixedWithE(stem) {
+			a.base = stem + "e"
+		}
+	case last == 'c' || last == 'g':
+		// "faces" and "pages" have a silent 'e' and a soft consonant.
+		a.base, a.syllabic = stem+"e", true
+	default:
+		a.base = stem + "e"
+	}
+	return a, true
+}
+
+// splitPrefix separates a prefix from a following vowel when the remainder
+// is long enough to be a word of its own, as short as "use" in "reuse" and
+// "ice" in "deice". Words that only look prefixed, such as "reign" and
+// "deuce", are in the exception dictionary. A prefix is also separated from
+// a stem that is itself in the exception dictionary, as in "re-create".
+func splitPrefix(word string) (affix, bool) {
+	for _, p := range vowelPrefixes {
+		rest, ok := strings.CutPrefix(word, p.prefix)
+		if !ok || len(rest) < 3 || !hasAnyPrefix(rest, p.starts) && !isException(rest) {
+			continue
+		}
+		return affix{prefix: p.prefix, stem: rest, base: rest, syllabic: true}, true
+	}
+	return affix{}, false
+}
+
+// hasAnyPrefix reports whether s begins with one of prefixes.
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// endsInHiatus reports whether stem ends in a "ui" sounded as two
+// syllables before its final consonant, as in "ruin" and "fluid".
+func endsInHiatus(stem string) bool {
+	n := len(stem)
+	return n >= 3 && stem[n-3:n-1] == "ui" && strings.IndexByte("nd", stem[n-1]) >= 0
+}
+
+// prefixedWithE reports whether stem splits at a prefix only once its
+// dropped 'e' is restored: "reus" as "reuse", "deic" as "deice".
+func prefixedWithE(stem string) bool {
+	_, bare := splitPrefix(stem)
+	_, restored := splitPrefix(stem + "e")
+	return restored && !bare
+}
+
+// countStem counts a stem left after removing an affix.
+func countStem(stem string) int {
+	n, _ := countWord(stem)
+	return n
+}
+
+// clusterBefore reports whether stem ends in a consonant followed by c,
+// as in "tumbl" or "hundr", a cluster that cannot close a syllable.
+func clusterBefore(stem string, c byte) bool {
+	n := len(stem)
+	return n >= 3 && stem[n-1] == c && strings.IndexByte("bcdfgkpstz", stem[n-2]) >= 0
+}
+
+// hasVowel reports whether s contains a vowel.
+func hasVowel(s string) bool {
+	return strings.IndexFunc(s, isVowel) >= 0
+}
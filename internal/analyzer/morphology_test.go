@@ -0,0 +1,84 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func TestCountMorphology(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected int
+	}{
+		// -ed is a syllable only after t or d
+		{"jumped", 1},
+		{"walked", 1},
+		{"stopped", 1},
+		{"wanted", 2},
+		{"needed", 2},
+		{"created", 3}, // create + d
+		{"tumbled", 2}, // tumble + d
+		{"hundred", 2},
+		{"googled", 2}, // unknown stems are counted the same way
+
+		// -es after sibilants, -s after silent e
+		{"boxes", 2},
+		{"horses", 2},
+		{"pages", 2},
+		{"stones", 1},
+		{"tables", 2},
+		{"heroes", 2},
+		{"fires", 1}, // fire is an exception
+
+		// Derivational suffixes
+		{"making", 2},
+		{"creating", 3},
+		{"tumbling", 2},
+		{"lonely", 2},
+		{"happiness", 3},
+		{"loneliness", 3},
+		{"movement", 2},
+		{"hopeful", 2},
+		{"careless", 2},
+
+		// Prefixes before a vowel
+		{"reopen", 3},
+		{"reopened", 3},
+		{"preorder", 3},
+		{"coexist", 3},
+		{"reunion", 3},
+		{"reuse", 2},
+		{"deice", 2},
+		{"deiced", 2},
+		{"reused", 2},
+		{"reuses", 3},
+		{"reelect", 3},
+		{"reenter", 3},
+		{"preexist", 3},
+		{"reappear", 3},
+		{"reassure", 3},
+		{"recreate", 3}, // create is an exception
+		{"cooperate", 4},
+		{"coordinate", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			result, ok := countMorphology(tt.word)
+			if !ok {
+				t.Fatalf("countMorphology(%q) applied no rule", tt.word)
+			}
+			if result != tt.expected {
+				t.Errorf("countMorphology(%q) = %d, want %d", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCountMorphology_NoRule(t *testing.T) {
+	// Words whose endings only look like affixes are left to the heuristic.
+	for _, word := range []string{"bed", "shed", "freed", "tried", "played", "yes", "bus", "class", "string", "thing", "fly", "real", "pond", "coil", "rein", "reel", "ready", "reason", "reach", "cooler"} {
+		if n, ok := countMorphology(word); ok {
+			t.Errorf("countMorphology(%q) = %d, want no rule", word, n)
+		}
+	}
+}
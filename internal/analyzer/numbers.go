@@ -0,0 +1,124 @@
+// Package analyzer provides spelling out of numbers for syllable counting.
+package analyzer
+
+import (
+	"strings"
+)
+
+var smallNumbers = []string{
+	"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+	"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen",
+	"seventeen", "eighteen", "nineteen",
+}
+
+var tensNumbers = []string{
+	"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety",
+}
+
+var numberScales = []struct {
+	value int64
+	name  string
+}{
+	{1_000_000_000_000, "trillion"},
+	{1_000_000_000, "billion"},
+	{1_000_000, "million"},
+	{1_000, "thousand"},
+}
+
+// irregularOrdinals maps cardinal words whose ordinal is not formed by adding "th".
+var irregularOrdinals = map[string]string{
+	"one": "first", "two": "second", "three": "third", "five": "fifth",
+	"eight": "eighth", "nine": "ninth", "twelve": "twelfth",
+}
+
+// SpellNumber returns the English words for a non-negative integer,
+// e.g. 342 -> "three hundred forty-two".
+func SpellNumber(n int64) string {
+	if n < 0 {
+		return "minus " + SpellNumber(-n)
+	}
+	if n < 100 {
+		return spellUnder100(n)
+	}
+	if n < 1000 {
+		words := smallNumbers[n/100] + " hundred"
+		if r := n % 100; r > 0 {
+			words += " " + spellUnder100(r)
+		}
+		return words
+	}
+
+	var parts []string
+	for _, scale := range numberScales {
+		if n >= scale.value {
+			parts = append(parts, SpellNumber(n/scale.value)+" "+scale.name)
+			n %= scale.value
+		}
+	}
+	if n > 0 {
+		parts = append(parts, SpellNumber(n))
+	}
+	return strings.Join(parts, " ")
+}
+
+// SpellOrdinal returns the English ordinal words for n, e.g. 21 -> "twenty-first".
+func SpellOrdinal(n int64) string {
+	words := SpellNumber(n)
+	cut := strings.LastIndexAny(words, " -") + 1
+	last := words[cut:]
+
+	switch {
+	case irregularOrdinals[last] != "":
+		last = irregularOrdinals[last]
+	case strings.HasSuffix(last, "y"):
+		last = strings.TrimSuffix(last, "y") + "ieth"
+	default:
+		last += "th"
+	}
+	return words[:cut] + last
+}
+
+// SpellYear returns a four-digit year as it is usually read aloud,
+// e.g. 1999 -> "nineteen ninety-nine", 1905 -> "nineteen oh five",
+// 2008 -> "two thousand eight" and 2024 -> "twenty twenty-four".
+func SpellYear(n int64) string {
+	if n < 1000 || n > 9999 {
+		return SpellNumber(n)
+	}
+	if n >= 2000 && n < 2010 {
+		return SpellNumber(n)
+	}
+
+	century, rest := n/100, n%100
+	switch {
+	case rest == 0:
+		return SpellNumber(century) + " hundred"
+	case rest < 10:
+		return SpellNumber(century) + " oh " + smallNumbers[rest]
+	default:
+		return SpellNumber(century) + " " + spellUnder100(rest)
+	}
+}
+
+// spellUnder100 spells numbers from 0 to 99.
+func spellUnder100(n int64) string {
+	if n < 20 {
+		return smallNumbers[n]
+	}
+	words := tensNumbers[n/10]
+	if r := n % 10; r > 0 {
+		words += "-" + smallNumbers[r]
+	}
+	return words
+}
+
+// pluralizeSpoken forms the plural of the last spoken word, as in "1990s" -> "nineteen nineties".
+func pluralizeSpoken(words string) string {
+	if strings.HasSuffix(words, "y") {
+		return strings.TrimSuffix(words, "y") + "ies"
+	}
+	if strings.HasSuffix(words, "x") {
+		return words + "es"
+	}
+	return words + "s"
+}
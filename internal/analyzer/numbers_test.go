@@ -0,0 +1,71 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func TestSpellNumber(t *testing.T) {
+	tests := []struct {
+		n        int64
+		expected string
+	}{
+		{0, "zero"},
+		{3, "three"},
+		{15, "fifteen"},
+		{21, "twenty-one"},
+		{100, "one hundred"},
+		{342, "three hundred forty-two"},
+		{1000, "one thousand"},
+		{2005, "two thousand five"},
+		{1_000_001, "one million one"},
+		{-4, "minus four"},
+	}
+
+	for _, tt := range tests {
+		if result := SpellNumber(tt.n); result != tt.expected {
+			t.Errorf("SpellNumber(%d) = %q, want %q", tt.n, result, tt.expected)
+		}
+	}
+}
+
+func TestSpellOrdinal(t *testing.T) {
+	tests := []struct {
+		n        int64
+		expected string
+	}{
+		{1, "first"},
+		{2, "second"},
+		{3, "third"},
+		{4, "fourth"},
+		{12, "twelfth"},
+		{20, "twentieth"},
+		{21, "twenty-first"},
+		{100, "one hundredth"},
+	}
+
+	for _, tt := range tests {
+		if result := SpellOrdinal(tt.n); result != tt.expected {
+			t.Errorf("SpellOrdinal(%d) = %q, want %q", tt.n, result, tt.expected)
+		}
+	}
+}
+
+func TestSpellYear(t *testing.T) {
+	tests := []struct {
+		n        int64
+		expected string
+	}{
+		{1999, "nineteen ninety-nine"},
+		{1905, "nineteen oh five"},
+		{1900, "nineteen hundred"},
+		{2008, "two thousand eight"},
+		{2024, "twenty twenty-four"},
+		{1066, "ten sixty-six"},
+	}
+
+	for _, tt := range tests {
+		if result := SpellYear(tt.n); result != tt.expected {
+			t.Errorf("SpellYear(%d) = %q, want %q", tt.n, result, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+// Package analyzer provides phonetic approximations of words for sound analysis.
+package analyzer
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+//go:embed data/phones.txt
+var phonesData string
+
+// phonesLexicon maps words to ARPAbet phonemes with stress digits on vowels.
+var phonesLexicon = parsePhonesLexicon(phonesData)
+
+// consonantPhones spells single consonant letters as ARPAbet phonemes.
+var consonantPhones = map[rune]string{
+	'b': "B", 'c': "K", 'd': "D", 'f': "F", 'g': "G", 'h': "HH", 'j': "JH",
+	'k': "K", 'l': "L", 'm': "M", 'n': "N", 'p': "P", 'q': "K", 'r': "R",
+	's': "S", 't': "T", 'v': "V", 'w': "W", 'x': "K S", 'y': "Y", 'z': "Z",
+}
+
+// digraphPhones spells consonant digraphs.
+var digraphPhones = map[string]string{
+	"ch": "CH", "sh": "SH", "th": "TH", "ph": "F", "wh": "W", "ck": "K", "ng": "NG",
+}
+
+// silentOnsets are word-initial clusters whose first letter is silent.
+var silentOnsets = []string{"kn", "wr", "gn", "ps"}
+
+// vowelDigraphPhones spells two-letter vowel groups.
+var vowelDigraphPhones = map[string]string{
+	"ee": "IY", "ea": "IY", "ie": "IY", "ei": "EY", "ey": "EY", "ai": "EY", "ay": "EY",
+	"oa": "OW", "oe": "OW", "oo": "UW", "ou": "AW", "oi": "OY", "oy": "OY",
+	"au": "AO", "ue": "UW", "ui": "UW",
+}
+
+// rColoredVowels spell a single vowel before a final "re", as in "care",
+// "here", "fire", "shore" and "pure".
+var rColoredVowels = map[rune]string{'a': "EH", 'e': "IH", 'i': "AY", 'o': "AO", 'u': "UH"}
+
+// shortVowels and longVowels spell single vowel letters in closed and open
+// or silent-e syllables: "hat" and "hate", "kit" and "kite".
+var (
+	shortVowels = map[rune]string{'a': "AE", 'e': "EH", 'i': "IH", 'o': "AA", 'u': "AH", 'y': "IH"}
+	longVowels  = map[rune]string{'a': "EY", 'e': "IY", 'i': "AY", 'o': "OW", 'u': "UW", 'y': "AY"}
+)
+
+// parsePhonesLexicon reads the embedded word<TAB>phonemes list.
+func parsePhonesLexicon(data string) map[string][]string {
+	lexicon := make(map[string][]string)
+	sc := bufio.NewScanner(strings.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word, phones, ok := strings.Cut(line, "\t")
+		if !ok || phones == "" {
+			panic(fmt.Sprintf("phones data: invalid entry %q", line))
+		}
+		lexicon[word] = strings.Fields(phones)
+	}
+	return lexicon
+}
+
+// wordPhones returns the phonemes of a word, from the lexicon where listed
+// and otherwise approximated from its spelling.
+func wordPhones(word string) []string {
+	word = strings.ToLower(word)
+	if p, ok := phonesLexicon[word]; ok {
+		return p
+	}
+	return spellPhones(word)
+}
+
+// spellPhones approximates the phonemes of a lowercase word from its
+// spelling. Vowels follow the syllable nuclei used for counting, each
+// marked with the word's stress digit. Where the count is lower than the
+// spelled vowel groups, as in "stones" and "something", the extra 'e' is
+// silent, so the result has one vowel per counted syllable.
+func spellPhones(word string) []string {
+	r := []rune(strings.Map(func(ch rune) rune {
+		if f, ok := vowelFold[ch]; ok {
+			return f
+		}
+		if ch == '\'' || ch == '’' {
+			return -1
+		}
+		return ch
+	}, word))
+	if len(r) == 0 {
+		return nil
+	}
+
+	nuclei := vowelNuclei(r)
+	if n, _ := countWord(word); n > 0 {
+		for len(nuclei) > n {
+			k := len(nuclei) - 1
+			for k > 0 && string(r[nuclei[k][0]:nuclei[k][1]]) != "e" {
+				k--
+			}
+			if k == 0 {
+				break
+			}
+			nuclei = append(nuclei[:k], nuclei[k+1:]...)
+		}
+	}
+	stress := fitStress(wordStress(word), len(nuclei))
+	nucleusAt := make(map[int]int, len(nuclei))
+	for k, g := range nuclei {
+		nucleusAt[g[0]] = k
+	}
+
+	var phones []string
+	emit := func(p string) {
+		for _, f := range strings.Fields(p) {
+			if len(phones) > 0 && phones[len(phones)-1] == f && !isVowelPhone(f) {
+				continue
+			}
+			phones = append(phones, f)
+		}
+	}
+
+	for _, onset := range silentOnsets {
+		if strings.HasPrefix(string(r), onset) {
+			r = r[1:]
+			return append(phones, spellPhones(string(r))...)
+		}
+	}
+
+	for i := 0; i < len(r); {
+		if i == len(r)-2 && sonorantEnding(r) && len(nuclei) > 1 {
+			// The final syllable of "table" and "acre" is spoken with a schwa.
+			emit("AH" + string(stress[len(stress)-1]) + " " + consonantPhones[r[i]])
+			break
+		}
+		if k, ok := nucleusAt[i]; ok {
+			vowel, skip := vowelPhone(r, nuclei[k], len(nuclei))
+			emit(vowel + string(stress[k]))
+			i = nuclei[k][1] + skip
+			continue
+		}
+		if isVowel(r[i]) {
+			i++ // silent
+			continue
+		}
+
+		next := func(j int) rune {
+			if i+j < len(r) {
+				return r[i+j]
+			}
+			return 0
+		}
+		switch two := string(r[i:min(i+2, len(r))]); {
+		case two == "gh":
+			if i == 0 {
+				emit("G")
+			}
+			i += 2
+		case r[i] == 't' && next(1) == 'c' && next(2) == 'h':
+			i++
+		case r[i] == 'd' && next(1) == 'g' && next(2) == 'e':
+			emit("JH")
+			i += 2
+		case r[i] == 'm' && next(1) == 'b' && i+2 == len(r):
+			emit("M")
+			i += 2
+		case two == "qu":
+			emit("K W")
+			i++
+		case digraphPhones[two] != "":
+			emit(digraphPhones[two])
+			i += 2
+		case r[i] == 'c' && strings.ContainsRune("eiy", next(1)):
+			emit("S")
+			i++
+		case r[i] == 'g' && next(1) == 'e' && i+2 == len(r):
+			emit("JH")
+			i++
+		case r[i] == 'x' && i == 0:
+			emit("Z")
+			i++
+		case r[i] == 'y' && i > 0:
+			i++ // a vowel outside any nucleus
+		default:
+			emit(consonantPhones[r[i]])
+			i++
+		}
+	}
+	return phones
+}
+
+// vowelPhone spells the vowel group g of r as a phoneme without a stress
+// digit. It also returns how many letters after the group it consumed, as
+// when "igh" is read as one vowel.
+func vowelPhone(r []rune, g [2]int, syllables int) (string, int) {
+	spelled := string(r[g[0]:g[1]])
+	after := string(r[g[1]:])
+	if len(spelled) >= 2 && g[0] > 0 && r[g[0]-1] == 'q' && spelled[0] == 'u' {
+		spelled = spelled[1:] // the 'u' of "qu" was read as W
+	}
+
+	if len(spelled) >= 2 {
+		if p, ok := vowelDigraphPhones[spelled[:2]]; ok {
+			return p, 0
+		}
+	}
+
+	v := []rune(spelled)[0]
+	if len(spelled) == 1 && strings.HasPrefix(after, "w") {
+		rest := after[1:]
+		switch v {
+		case 'o':
+			if rest == "" || rest == "s" {
+				return "OW", 1
+			}
+			return "AW", 1
+		case 'a':
+			return "AO", 1
+		case 'e':
+			return "UW", 1
+		}
+	}
+
+	switch {
+	case rColoredVowels[v] != "" && strings.HasPrefix(after, "re") && isSilentETail(after[2:]):
+		return rColoredVowels[v], 0
+	case v == 'i' && strings.HasPrefix(after, "gh"):
+		return "AY", 2
+	case strings.HasPrefix(after, "r") && !strings.HasPrefix(after, "rr") && (len(after) == 1 || !isVowel([]rune(after)[1])):
+		switch v {
+		case 'a':
+			return "AA", 0
+		case 'o':
+			return "AO", 0
+		default:
+			return "ER", 1
+		}
+	case v == 'a' && (strings.HasPrefix(after, "ll") || strings.HasPrefix(after, "lk") || strings.HasPrefix(after, "lt")):
+		return "AO", 0
+	case v == 'o' && (strings.HasPrefix(after, "ld") || strings.HasPrefix(after, "lt") || strings.HasPrefix(after, "st")):
+		return "OW", 0
+	case v == 'i' && (strings.HasPrefix(after, "nd") || strings.HasPrefix(after, "ld")):
+		return "AY", 0
+	case v == 'y' && after == "" && syllables > 1:
+		return "IY", 0
+	case v == 'a' && after == "" && syllables > 1:
+		return "AH", 0
+	case after == "" || isOpenBeforeSilentE(after):
+		return longVowels[v], 0
+	}
+	return shortVowels[v], 0
+}
+
+// silentESuffixes are the endings that keep an 'e' before them silent, as
+// in "lonely" and "hopeful".
+var silentESuffixes = []string{"", "s", "d", "ly", "less", "ness", "ful", "ment"}
+
+// isOpenBeforeSilentE reports whether the letters after a single vowel are
+// one consonant and a silent 'e', final or before a suffix, as in "hate",
+// "stones" and "lonely".
+func isOpenBeforeSilentE(after string) bool {
+	a := []rune(after)
+	if len(a) < 2 || isVowel(a[0]) || a[1] != 'e' {
+		return false
+	}
+	return isSilentETail(string(a[2:]))
+}
+
+// isSilentETail reports whether rest, the letters after an 'e', leave it silent.
+func isSilentETail(rest string) bool {
+	return slices.Contains(silentESuffixes, rest)
+}
+
+// isVowelPhone reports whether an ARPAbet phoneme is a vowel, which
+// carries a stress digit.
+func isVowelPhone(p string) bool {
+	return p != "" && p[len(p)-1] >= '0' && p[len(p)-1] <= '2'
+}
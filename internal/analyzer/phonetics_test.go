@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWordPhones(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected string
+	}{
+		// Lexicon
+		{"one", "W AH1 N"},
+		{"Heart", "HH AA1 R T"},
+
+		// Spelling
+		{"cat", "K AE1 T"},
+		{"hate", "HH EY1 T"},
+		{"night", "N AY1 T"},
+		{"moon", "M UW1 N"},
+		{"snow", "S N OW1"},
+		{"crowd", "K R AW1 D"},
+		{"cold", "K OW1 L D"},
+		{"splash", "S P L AE1 SH"},
+		{"queen", "K W IY1 N"},
+		{"knight", "N AY1 T"},
+		{"judge", "JH AH1 JH"},
+		{"shore", "SH AO1 R"},
+		{"star", "S T AA1 R"},
+		{"bird", "B ER1 D"},
+		{"happy", "HH AE1 P IY0"},
+		{"candle", "K AE1 N D AH0 L"},
+
+		// Silent 'e' of uncounted endings
+		{"stones", "S T OW1 N S"},
+		{"jumped", "JH AH1 M P D"},
+		{"lonely", "L OW1 N L IY0"},
+		{"safely", "S EY1 F L IY0"},
+		{"careful", "K EH1 R F AH0 L"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if result := strings.Join(wordPhones(tt.word), " "); result != tt.expected {
+				t.Errorf("wordPhones(%q) = %q, want %q", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPhonesLexicon_MatchesCount(t *testing.T) {
+	for word, phones := range phonesLexicon {
+		vowels := 0
+		for _, p := range phones {
+			if isVowelPhone(p) {
+				vowels++
+			}
+		}
+		if n := CountSyllables(word); vowels != n {
+			t.Errorf("phones lexicon %q = %v, want %d vowels", word, phones, n)
+		}
+	}
+}
+
+func TestIsVowelPhone(t *testing.T) {
+	tests := []struct {
+		phone    string
+		expected bool
+	}{
+		{"AH0", true},
+		{"IY1", true},
+		{"AY2", true},
+		{"K", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if result := isVowelPhone(tt.phone); result != tt.expected {
+			t.Errorf("isVowelPhone(%q) = %v, want %v", tt.phone, result, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,394 @@
+// Package analyzer provides part-of-speech tagging and grammatical profiles of lines.
+package analyzer
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// Part-of-speech tags reported in WordToken.POS, from the Universal POS tag set.
+const (
+	TagNoun         = "NOUN"
+	TagVerb         = "VERB"
+	TagAuxiliary    = "AUX"
+	TagAdjective    = "ADJ"
+	TagAdverb       = "ADV"
+	TagDeterminer   = "DET"
+	TagAdposition   = "ADP"
+	TagPronoun      = "PRON"
+	TagConjunction  = "CONJ"
+	TagParticle     = "PART"
+	TagNumeral      = "NUM"
+	TagInterjection = "INTJ"
+)
+
+// Tenses reported in LineGrammar.Tense.
+const (
+	TensePresent = "present"
+	TensePast    = "past"
+	TenseMixed   = "mixed"
+)
+
+// articles are the determiners counted in LineGrammar.Articles.
+var articles = []string{"a", "an", "the"}
+
+//go:embed data/pos.txt
+var posData string
+
+// posEntry lists a word's possible tags, most likely first, and whether it
+// is a past-tense verb or plural noun form. A guessed
+// entry is the default for a word matching no lexicon entry or rule, and a
+// suffix entry was tagged by its ending alone.
+type posEntry struct {
+	tags   []string
+	past   bool
+	plural bool
+	guess  bool
+	suffix bool
+}
+
+// clauseBreaks are punctuation marks between tokens that end the context
+// the tagger reads from.
+const clauseBreaks = ".!?;:,—–()"
+
+// cliticAuxiliaries are the clitics that make a pronoun contraction carry
+// an auxiliary: "she's", "they're", "I'm", "we've", "you'll", "he'd".
+var cliticAuxiliaries = []string{"'s", "'re", "'m", "'ve", "'ll", "'d"}
+
+// posLexicon maps words to their possible tags.
+var posLexicon = parsePOSLexicon(posData)
+
+// posSuffixes tag unlisted words by their ending, in order.
+var posSuffixes = []struct {
+	suffix string
+	tags   []string
+}{
+	{"ly", []string{TagAdverb}},
+	{"ing", []string{TagVerb, TagAdjective, TagNoun}},
+	{"ed", []string{TagVerb, TagAdjective}},
+	{"ness", []string{TagNoun}},
+	{"ment", []string{TagNoun}},
+	{"tion", []string{TagNoun}},
+	{"sion", []string{TagNoun}},
+	{"ity", []string{TagNoun}},
+	{"ism", []string{TagNoun}},
+	{"ful", []string{TagAdjective}},
+	{"less", []string{TagAdjective}},
+	{"ous", []string{TagAdjective}},
+	{"ive", []string{TagAdjective}},
+	{"able", []string{TagAdjective}},
+	{"ible", []string{TagAdjective}},
+	{"ish", []string{TagAdjective}},
+}
+
+// parsePOSLexicon reads the embedded word<TAB>tags[<TAB>past] list.
+func parsePOSLexicon(data string) map[string]posEntry {
+	lexicon := make(map[string]posEntry)
+	sc := bufio.NewScanner(strings.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		form := ""
+		if len(fields) == 3 {
+			form = fields[2]
+		}
+		if len(fields) < 2 || len(fields) > 3 || (len(fields) == 3 && form != "past" && form != "plural") {
+			panic(fmt.Sprintf("pos data: invalid entry %q", line))
+		}
+		lexicon[fields[0]] = posEntry{tags: strings.Split(fields[1], ","), past: form == "past", plural: form == "plural"}
+	}
+	return lexicon
+}
+
+// candidateTags returns the possible tags of a token, most likely first,
+// and whether it is a past-tense or plural form.
+func candidateTags(t Token) posEntry {
+	switch t.Kind {
+	case TokenNumber, TokenYear:
+		return posEntry{tags: []string{TagNumeral}}
+	case TokenOrdinal:
+		return posEntry{tags: []string{TagAdjective}}
+	}
+
+	word := strings.ToLower(strings.ReplaceAll(t.Text, "’", "'"))
+	if e, ok := posLexicon[word]; ok {
+		return e
+	}
+	if t.Kind == TokenCompound {
+		// A compound is tagged by its last part: "ice-cold", "moon-viewing".
+		parts := strings.Split(word, "-")
+		word = parts[len(parts)-1]
+		if e, ok := posLexicon[word]; ok {
+			return e
+		}
+	}
+	if t.Kind == TokenContraction {
+		if head, _, ok := strings.Cut(word, "'"); ok && head != "" {
+			if e, ok := posLexicon[head]; ok {
+				return e
+			}
+		}
+	}
+	return wordTags(word)
+}
+
+// wordTags tags an unlisted word by its base form and then its suffix.
+// Words that match neither are nouns.
+func wordTags(word string) posEntry {
+	if a, ok := splitSuffix(word); ok {
+		for _, base := range []string{a.base, a.stem} {
+			e, ok := posLexicon[base]
+			if !ok {
+				continue
+			}
+			switch a.suffix {
+			case "s", "es":
+				// A verb reading first, so "the light falls" is not read as "light falls" the noun.
+				if slices.Contains(e.tags, TagVerb) {
+					return posEntry{tags: onlyTags(e.tags, TagVerb, TagNoun)}
+				}
+				return posEntry{tags: onlyTags(e.tags, TagNoun, TagVerb), plural: slices.Contains(e.tags, TagNoun)}
+			case "ed":
+				return posEntry{tags: []string{TagVerb, TagAdjective}, past: true}
+			case "ing":
+				return posEntry{tags: []string{TagVerb, TagAdjective, TagNoun}}
+			case "ly":
+				return posEntry{tags: []string{TagAdverb}}
+			}
+		}
+	}
+
+	for _, s := range posSuffixes {
+		if strings.HasSuffix(word, s.suffix) && len(word) > len(s.suffix)+2 {
+			return posEntry{tags: s.tags, past: s.suffix == "ed", suffix: true}
+		}
+	}
+	return posEntry{tags: []string{TagNoun, TagVerb}, guess: true}
+}
+
+// onlyTags keeps the tags in allowed, in the order of allowed, defaulting
+// to the first allowed tag.
+func onlyTags(tags []string, allowed ...string) []string {
+	var out []string
+	for _, t := range allowed {
+		if slices.Contains(tags, t) {
+			out = append(out, t)
+		}
+	}
+	if len(out) == 0 {
+		return allowed[:1]
+	}
+	return out
+}
+
+// tagTokens chooses a tag for each token of line from its candidates,
+// reading left to right: a verb after "to" or an auxiliary, a noun or an
+// adjective after a determiner, adjective or preposition, a verb after a
+// subject (unless a singular noun rules the verb out), and an adjective
+// before a noun. Punctuation such as "!" or a
+// dash between tokens starts a fresh context.
+func tagTokens(line string, tokens []Token) []string {
+	cands := make([]posEntry, len(tokens))
+	for i, t := range tokens {
+		cands[i] = candidateTags(t)
+	}
+
+	tags := make([]string, len(tokens))
+	for i := range tokens {
+		c := cands[i].tags
+		tags[i] = c[0]
+		if len(c) == 1 {
+			continue
+		}
+
+		prev, prevWord := "", ""
+		if i > 0 && !strings.ContainsAny(line[tokens[i-1].End:tokens[i].Start], clauseBreaks) {
+			prev, prevWord = tags[i-1], strings.ToLower(tokens[i-1].Text)
+			if hasCliticAuxiliary(tokens[i-1].Text, prev) {
+				prev = TagAuxiliary
+			}
+		}
+		nextNoun := false
+		if i+1 < len(tokens) {
+			next := cands[i+1].tags
+			nextNoun = next[0] == TagNoun && !cands[i+1].guess || next[0] == TagAdjective && !slices.Contains(next, TagVerb)
+		}
+
+		switch {
+		case (prevWord == "to" || prev == TagAuxiliary) && slices.Contains(c, TagVerb):
+			tags[i] = TagVerb
+		case cands[i].guess:
+			if prev == TagPronoun || prev == TagNoun && !bareAfterSingular(prevWord, strings.ToLower(tokens[i].Text), cands[i]) {
+				tags[i] = TagVerb
+			}
+		case slices.Contains([]string{TagDeterminer, TagAdjective, TagAdposition, TagNumeral}, prev):
+			if nextNoun && slices.Contains(c, TagAdjective) {
+				tags[i] = TagAdjective
+			} else if slices.Contains(c, TagNoun) {
+				tags[i] = TagNoun
+			} else if slices.Contains(c, TagAdjective) {
+				tags[i] = TagAdjective
+			}
+		case prev == TagNoun && bareAfterSingular(prevWord, strings.ToLower(tokens[i].Text), cands[i]):
+			tags[i] = c[slices.IndexFunc(c, func(tag string) bool { return tag != TagVerb })]
+		case (prev == TagNoun || prev == TagPronoun) && slices.Contains(c, TagVerb):
+			tags[i] = TagVerb
+		case nextNoun && slices.Contains(c, TagAdjective):
+			tags[i] = TagAdjective
+		}
+	}
+	return tags
+}
+
+// bareAfterSingular reports whether a bare word after the singular noun
+// prev is better read as something other than a verb, which would need
+// prev to be plural ("birds sing"): a compound ("spring breeze", "splash
+// silence") or a preposition ("the moon like a coin").
+func bareAfterSingular(prev, word string, e posEntry) bool {
+	return slices.ContainsFunc(e.tags, func(tag string) bool { return tag != TagVerb }) && !e.past &&
+		!strings.HasSuffix(prev, "s") && !strings.HasSuffix(word, "s")
+}
+
+// profileLine derives the grammatical profile of a tagged line. A line
+// with a finite verb is a clause.
+func profileLine(words []haiku.WordToken) haiku.LineGrammar {
+	var g haiku.LineGrammar
+	var tenses []string
+
+	for i, w := range words {
+		switch w.POS {
+		case TagNoun:
+			g.Nouns++
+		case TagAdjective:
+			g.Adjectives++
+		case TagVerb:
+			g.Verbs++
+		case TagAdverb:
+			g.Adverbs++
+		case TagDeterminer:
+			if slices.Contains(articles, strings.ToLower(w.Text)) {
+				g.Articles++
+			}
+		}
+		if tense := finiteTense(words, i); tense != "" && !slices.Contains(tenses, tense) {
+			tenses = append(tenses, tense)
+		}
+	}
+
+	g.Clause = len(tenses) > 0
+	switch len(tenses) {
+	case 1:
+		g.Tense = tenses[0]
+	case 2:
+		g.Tense = TenseMixed
+	}
+	return g
+}
+
+// finiteTense returns the tense of words[j] when it is a finite verb or
+// auxiliary, or "" otherwise. A verb after "to" or an auxiliary is not
+// finite, since the auxiliary carries the tense, and neither is an "-ing"
+// participle on its own. A pronoun with an auxiliary clitic is present.
+func finiteTense(words []haiku.WordToken, j int) string {
+	w := words[j]
+	if hasCliticAuxiliary(w.Text, w.POS) {
+		return TensePresent
+	}
+	if w.POS != TagVerb && w.POS != TagAuxiliary {
+		return ""
+	}
+	if j > 0 {
+		prev := words[j-1]
+		if prev.POS == TagAuxiliary || hasCliticAuxiliary(prev.Text, prev.POS) || strings.EqualFold(prev.Text, "to") {
+			return ""
+		}
+	}
+	word := strings.ToLower(strings.ReplaceAll(w.Text, "’", "'"))
+	if w.POS == TagVerb && strings.HasSuffix(word, "ing") {
+		return ""
+	}
+	if isPast(word, w.POS) {
+		return TensePast
+	}
+	return TensePresent
+}
+
+// hasCliticAuxiliary reports whether a pronoun contraction carries an
+// auxiliary, as in "she's" or "they're".
+func hasCliticAuxiliary(text, tag string) bool {
+	if tag != TagPronoun {
+		return false
+	}
+	text = strings.ToLower(strings.ReplaceAll(text, "’", "'"))
+	for _, c := range cliticAuxiliaries {
+		if strings.HasSuffix(text, c) && len(text) > len(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPast reports whether a verb or auxiliary is a past-tense form.
+func isPast(word, tag string) bool {
+	if e, ok := posLexicon[word]; ok {
+		return e.past && slices.Contains(e.tags, tag)
+	}
+	return wordTags(word).past
+}
+
+const (
+	// maxAdjectives is the most adjectives a line carries before it reads as overloaded.
+	maxAdjectives = 2
+	// maxArticles is the most articles a line carries before they read as padding.
+	maxArticles = 1
+)
+
+// grammarDiagnostics flags a line with more than maxAdjectives adjectives or
+// a stack of adjectives before a noun, more than maxArticles articles, and
+// each finite past-tense verb, since haiku favor the present.
+func grammarDiagnostics(h *haiku.Haiku, i int, line string, words []haiku.WordToken, g haiku.LineGrammar) []haiku.Diagnostic {
+	var diags []haiku.Diagnostic
+
+	adjectives := tagIndexes(words, func(w haiku.WordToken) bool { return w.POS == TagAdjective })
+	stacked := false
+	for k := 1; k < len(adjectives); k++ {
+		stacked = stacked || adjectives[k] == adjectives[k-1]+1
+	}
+	if g.Adjectives > maxAdjectives || stacked {
+		diags = append(diags, tokenDiagnostic(h, i, line, KindAdjectiveOverload, adjectives[0], adjectives[len(adjectives)-1]))
+	}
+
+	if g.Articles > maxArticles {
+		arts := tagIndexes(words, func(w haiku.WordToken) bool {
+			return w.POS == TagDeterminer && slices.Contains(articles, strings.ToLower(w.Text))
+		})
+		diags = append(diags, tokenDiagnostic(h, i, line, KindExcessArticles, arts[0], arts[len(arts)-1]))
+	}
+
+	for j := range words {
+		if finiteTense(words, j) == TensePast {
+			diags = append(diags, tokenDiagnostic(h, i, line, KindPastTense, j, j))
+		}
+	}
+	return diags
+}
+
+// tagIndexes returns the indexes of the words that match.
+func tagIndexes(words []haiku.WordToken, match func(haiku.WordToken) bool) []int {
+	var idx []int
+	for j, w := range words {
+		if match(w) {
+			idx = append(idx, j)
+		}
+	}
+	return idx
+}
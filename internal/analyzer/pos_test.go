@@ -0,0 +1,131 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+func TestTagTokens(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected string
+	}{
+		{"a frog jumps into the pond", "DET NOUN VERB ADP DET NOUN"},
+		{"the cold dark lonely night", "DET ADJ ADJ ADJ NOUN"},
+		{"light rain falls softly", "ADJ NOUN VERB ADV"},
+		{"the light falls", "DET NOUN VERB"},
+		{"the falling leaves drift", "DET ADJ NOUN VERB"},
+		{"I want to sing", "PRON VERB PART VERB"},
+		{"something stirs", "PRON VERB"},
+		{"splash! silence again", "NOUN NOUN ADV"},
+		{"splash silence again", "NOUN NOUN ADV"},
+		{"the wind blows softly", "DET NOUN VERB ADV"},
+		{"the frogs croak", "DET NOUN VERB"},
+		{"the moon like a coin", "DET NOUN ADP DET NOUN"},
+		{"leaves were falling", "NOUN AUX VERB"},
+		{"she's watching 3 crows", "PRON VERB NUM NOUN"},
+		{"mountain path", "NOUN NOUN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			result := strings.Join(tagTokens(tt.line, Tokenize(tt.line)), " ")
+			if result != tt.expected {
+				t.Errorf("tagTokens(%q) = %q, want %q", tt.line, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordTags(t *testing.T) {
+	tests := []struct {
+		word  string
+		tags  []string
+		past  bool
+		guess bool
+	}{
+		{"frogs", []string{TagNoun}, false, false},
+		{"jumps", []string{TagVerb, TagNoun}, false, false},
+		{"jumped", []string{TagVerb, TagAdjective}, true, false},
+		{"softly", []string{TagAdverb}, false, false},
+		{"stillness", []string{TagNoun}, false, false},
+		{"restless", []string{TagAdjective}, false, false},
+		{"pebble", []string{TagNoun, TagVerb}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			e := wordTags(tt.word)
+			if !reflect.DeepEqual(e.tags, tt.tags) || e.past != tt.past || e.guess != tt.guess {
+				t.Errorf("wordTags(%q) = %+v, want {%v %v %v}", tt.word, e, tt.tags, tt.past, tt.guess)
+			}
+		})
+	}
+}
+
+func TestWordTags_Suffix(t *testing.T) {
+	for word, want := range map[string]bool{"vanish": true, "softly": false, "frogs": false, "pebble": false} {
+		if got := wordTags(word).suffix; got != want {
+			t.Errorf("wordTags(%q).suffix = %t, want %t", word, got, want)
+		}
+	}
+}
+
+func TestCandidateTags_Plural(t *testing.T) {
+	for word, want := range map[string]bool{"frogs": true, "leaves": true, "frog": false, "jumps": false, "grass": false} {
+		if got := candidateTags(Tokenize(word)[0]).plural; got != want {
+			t.Errorf("candidateTags(%q).plural = %t, want %t", word, got, want)
+		}
+	}
+}
+
+func TestProfileLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected haiku.LineGrammar
+	}{
+		{"an old silent pond", haiku.LineGrammar{Nouns: 1, Adjectives: 2, Articles: 1}},
+		{"a frog jumps into the pond", haiku.LineGrammar{Nouns: 2, Verbs: 1, Articles: 2, Tense: TensePresent, Clause: true}},
+		{"a crow flew over the field", haiku.LineGrammar{Nouns: 2, Verbs: 1, Articles: 2, Tense: TensePast, Clause: true}},
+		{"leaves were falling", haiku.LineGrammar{Nouns: 1, Verbs: 1, Tense: TensePast, Clause: true}},
+		{"the wind blew and now it sings", haiku.LineGrammar{Nouns: 1, Verbs: 2, Adverbs: 1, Articles: 1, Tense: TenseMixed, Clause: true}},
+		{"falling slowly", haiku.LineGrammar{Verbs: 1, Adverbs: 1}},
+		{"she's watching the moon", haiku.LineGrammar{Nouns: 1, Verbs: 1, Articles: 1, Tense: TensePresent, Clause: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			if result := profileLine(ExplainLine(tt.line)); result != tt.expected {
+				t.Errorf("profileLine(%q) = %+v, want %+v", tt.line, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAnalyze_GrammarDiagnostics(t *testing.T) {
+	source := "the cold dark night\na crow flew over the field\nsnow"
+	h := haiku.FromSource(source, []string{"the cold dark night", "a crow flew over the field", "snow"})
+
+	m := New(0).Analyze(h)
+	if len(m.Grammar) != 3 || m.Grammar[1].Tense != TensePast || m.Grammar[2].Clause {
+		t.Errorf("Grammar = %+v", m.Grammar)
+	}
+
+	var got []string
+	for _, d := range m.Diagnostics {
+		switch d.Kind {
+		case KindAdjectiveOverload, KindExcessArticles, KindPastTense:
+			got = append(got, d.Kind+":"+d.Text)
+			if src := source[d.Span.Start:d.Span.End]; src != d.Text {
+				t.Errorf("%s diagnostic %q maps to source text %q", d.Kind, d.Text, src)
+			}
+		}
+	}
+	want := []string{"adjective-overload:cold dark", "excess-articles:a crow flew over the", "past-tense:flew"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diagnostics = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,189 @@
+// Package analyzer provides rubric scoring of a haiku's quality.
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+	"github.com/thornzero/haikugo/internal/score"
+)
+
+const (
+	// syllablePenalty is the structure score lost per syllable off 5-7-5.
+	syllablePenalty = 0.25
+	// excessWordPenalty is the economy score lost per article or adjective
+	// beyond the per-line limits.
+	excessWordPenalty = 0.2
+	// soundDeviceBonus is the sound score gained per device within a line,
+	// from a neutral 0.5.
+	soundDeviceBonus = 0.2
+	// soundFlawPenalty is the sound score lost per end rhyme or sing-song line.
+	soundFlawPenalty = 0.25
+)
+
+// clichePenalties is the originality score lost per cliché of each tier.
+var clichePenalties = map[string]float64{ClicheHigh: 0.5, ClicheMedium: 0.3, ClicheLow: 0.15}
+
+// scoreHaiku grades analyzed metrics against rubric r. Each criterion is
+// scored from 0 to 1 with a reason, and the overall score is their
+// weighted mean scaled to 100.
+func scoreHaiku(m *haiku.Metrics, r *score.Rubric) haiku.Score {
+	scorers := map[string]func(*haiku.Metrics) (float64, string){
+		score.CriterionStructure:   scoreStructure,
+		score.CriterionKigo:        scoreKigo,
+		score.CriterionCut:         scoreCut,
+		score.CriterionConcrete:    scoreConcreteness,
+		score.CriterionEconomy:     scoreEconomy,
+		score.CriterionSound:       scoreSound,
+		score.CriterionOriginality: scoreOriginality,
+	}
+
+	var s haiku.Score
+	var total, weights float64
+	for _, c := range score.Criteria() {
+		value, reason := scorers[c](m)
+		value = math.Round(math.Max(0, math.Min(1, value))*100) / 100
+		w := r.Weight(c)
+		s.Parts = append(s.Parts, haiku.SubScore{Criterion: c, Score: value, Weight: w, Reason: reason})
+		total += w * value
+		weights += w
+	}
+	if weights > 0 {
+		s.Overall = math.Round(total/weights*1000) / 10
+	}
+	return s
+}
+
+// scoreStructure loses syllablePenalty per syllable off 5-7-5 beyond the tolerance.
+func scoreStructure(m *haiku.Metrics) (float64, string) {
+	expected := []int{5, 7, 5}
+	off := 0
+	counts := make([]string, len(m.LineSyllables))
+	for i, n := range m.LineSyllables {
+		counts[i] = fmt.Sprint(n)
+		if d := n - expected[i]; d > m.Tolerance {
+			off += d - m.Tolerance
+		} else if -d > m.Tolerance {
+			off += -d - m.Tolerance
+		}
+	}
+
+	pattern := strings.Join(counts, "-")
+	if off == 0 {
+		if pattern != "5-7-5" {
+			return 1, fmt.Sprintf("%s, within ±%d of 5-7-5", pattern, m.Tolerance)
+		}
+		return 1, "5-7-5"
+	}
+	return 1 - syllablePenalty*float64(off), fmt.Sprintf("%s, %s off 5-7-5", pattern, countOf(off, "syllable"))
+}
+
+// scoreKigo rewards a season word.
+func scoreKigo(m *haiku.Metrics) (float64, string) {
+	if len(m.SeasonWords) == 0 {
+		return 0, "no season word"
+	}
+	return 1, "season words: " + strings.Join(m.SeasonWords, ", ")
+}
+
+// scoreCut rewards a cutting word or pause between images.
+func scoreCut(m *haiku.Metrics) (float64, string) {
+	if !m.HasKireji {
+		return 0, "no cut between images"
+	}
+	return 1, "cut: " + strings.Join(m.KirejiHits, " ")
+}
+
+// scoreConcreteness maps mean concreteness from 1-5 to 0-1. Poems without
+// rated words are neutral.
+func scoreConcreteness(m *haiku.Metrics) (float64, string) {
+	if m.Concreteness == 0 {
+		return 0.5, "no rated words"
+	}
+	reason := fmt.Sprintf("mean concreteness %.2f of 5", m.Concreteness)
+	if len(m.AbstractWords) > 0 {
+		reason += "; abstract: " + strings.Join(m.AbstractWords, ", ")
+	}
+	return (m.Concreteness - 1) / 4, reason
+}
+
+// scoreEconomy loses excessWordPenalty per article or adjective beyond the
+// limits of each line.
+func scoreEconomy(m *haiku.Metrics) (float64, string) {
+	excess := 0
+	var notes []string
+	for i, g := range m.Grammar {
+		if g.Articles > maxArticles {
+			excess += g.Articles - maxArticles
+			notes = append(notes, fmt.Sprintf("%s in line %d", countOf(g.Articles, "article"), i+1))
+		}
+		if g.Adjectives > maxAdjectives {
+			excess += g.Adjectives - maxAdjectives
+			notes = append(notes, fmt.Sprintf("%s in line %d", countOf(g.Adjectives, "adjective"), i+1))
+		}
+	}
+	if excess == 0 {
+		return 1, "no excess articles or adjectives"
+	}
+	return 1 - excessWordPenalty*float64(excess), strings.Join(notes, "; ")
+}
+
+// scoreSound starts from a neutral 0.5, gains soundDeviceBonus per sound
+// device within a line and loses soundFlawPenalty per end rhyme or
+// sing-song line.
+func scoreSound(m *haiku.Metrics) (float64, string) {
+	devices, rhymes, singSong := 0, 0, 0
+	for _, d := range m.Sounds {
+		switch d.Kind {
+		case SoundRhyme:
+			rhymes++
+		case SoundAlliteration, SoundAssonance, SoundConsonance, SoundInternalRhyme:
+			devices++
+		}
+	}
+	for _, lm := range m.Meter {
+		if lm.SingSong {
+			singSong++
+		}
+	}
+
+	var notes []string
+	if devices > 0 {
+		notes = append(notes, countOf(devices, "sound device"))
+	}
+	if rhymes > 0 {
+		notes = append(notes, countOf(rhymes, "end rhyme"))
+	}
+	if singSong > 0 {
+		notes = append(notes, countOf(singSong, "sing-song line"))
+	}
+	if len(notes) == 0 {
+		return 0.5, "no notable sound patterns"
+	}
+	value := 0.5 + soundDeviceBonus*float64(devices) - soundFlawPenalty*float64(rhymes+singSong)
+	return value, strings.Join(notes, "; ")
+}
+
+// scoreOriginality loses a tier-dependent penalty per cliché.
+func scoreOriginality(m *haiku.Metrics) (float64, string) {
+	if len(m.Cliches) == 0 {
+		return 1, "no clichés"
+	}
+	value := 1.0
+	phrases := make([]string, len(m.Cliches))
+	for i, c := range m.Cliches {
+		value -= clichePenalties[c.Tier]
+		phrases[i] = fmt.Sprintf("%q (%s)", c.Text, c.Tier)
+	}
+	return value, "clichés: " + strings.Join(phrases, ", ")
+}
+
+// countOf formats n and a noun, adding "s" unless n is one.
+func countOf(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,74 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+	"github.com/thornzero/haikugo/internal/score"
+)
+
+func TestScorers(t *testing.T) {
+	tests := []struct {
+		name   string
+		scorer func(*haiku.Metrics) (float64, string)
+		m      haiku.Metrics
+		score  float64
+		reason string
+	}{
+		{"structure exact", scoreStructure, haiku.Metrics{LineSyllables: []int{5, 7, 5}}, 1, "5-7-5"},
+		{"structure tolerated", scoreStructure, haiku.Metrics{LineSyllables: []int{6, 7, 5}, Tolerance: 1}, 1, "6-7-5, within ±1 of 5-7-5"},
+		{"structure off", scoreStructure, haiku.Metrics{LineSyllables: []int{6, 8, 5}}, 0.5, "6-8-5, 2 syllables off 5-7-5"},
+		{"kigo", scoreKigo, haiku.Metrics{SeasonWords: []string{"frost", "moon"}}, 1, "season words: frost, moon"},
+		{"no kigo", scoreKigo, haiku.Metrics{}, 0, "no season word"},
+		{"cut", scoreCut, haiku.Metrics{HasKireji: true, KirejiHits: []string{"—"}}, 1, "cut: —"},
+		{"no cut", scoreCut, haiku.Metrics{}, 0, "no cut between images"},
+		{"concrete", scoreConcreteness, haiku.Metrics{Concreteness: 4, AbstractWords: []string{"love"}}, 0.75, "mean concreteness 4.00 of 5; abstract: love"},
+		{"unrated", scoreConcreteness, haiku.Metrics{}, 0.5, "no rated words"},
+		{"economy", scoreEconomy, haiku.Metrics{Grammar: []haiku.LineGrammar{{Articles: 1}, {Articles: 3}, {Adjectives: 3}}}, 0.4, "3 articles in line 2; 3 adjectives in line 3"},
+		{"sound devices", scoreSound, haiku.Metrics{Sounds: []haiku.SoundDevice{{Kind: SoundAlliteration}, {Kind: SoundAssonance}}}, 0.9, "2 sound devices"},
+		{"sound flaws", scoreSound, haiku.Metrics{Sounds: []haiku.SoundDevice{{Kind: SoundRhyme}}, Meter: []haiku.LineMeter{{SingSong: true}}}, 0, "1 end rhyme; 1 sing-song line"},
+		{"no sound", scoreSound, haiku.Metrics{}, 0.5, "no notable sound patterns"},
+		{"originality", scoreOriginality, haiku.Metrics{Cliches: []haiku.Cliche{{Text: "old pond", Tier: ClicheHigh}, {Text: "still pond", Tier: ClicheMedium}}}, 0.2, `clichés: "old pond" (high), "still pond" (medium)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, reason := tt.scorer(&tt.m)
+			if diff := value - tt.score; diff > 1e-9 || diff < -1e-9 || reason != tt.reason {
+				t.Errorf("score = %g, %q, want %g, %q", value, reason, tt.score, tt.reason)
+			}
+		})
+	}
+}
+
+func TestAnalyze_Score(t *testing.T) {
+	h := haiku.NewHaiku([]string{"an old silent pond", "a frog jumps into the pond", "splash! silence again"})
+
+	a := New(0)
+	m := a.Analyze(h)
+	if len(m.Score.Parts) != len(score.Criteria()) {
+		t.Fatalf("len(Score.Parts) = %d, want %d", len(m.Score.Parts), len(score.Criteria()))
+	}
+	if m.Score.Overall <= 0 || m.Score.Overall > 100 {
+		t.Errorf("Score.Overall = %g, want between 0 and 100", m.Score.Overall)
+	}
+	for _, p := range m.Score.Parts {
+		if p.Reason == "" || p.Score < 0 || p.Score > 1 {
+			t.Errorf("sub-score %+v lacks a reason or is out of range", p)
+		}
+	}
+
+	// Weighting only structure makes the overall score the structure score.
+	r, err := score.Read(strings.NewReader("structure\t1\nkigo\t0\ncut\t0\nconcreteness\t0\neconomy\t0\nsound\t0\noriginality\t0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.SetRubric(r)
+	if a.GetRubric() != r {
+		t.Error("GetRubric() did not return the rubric set")
+	}
+	if got := a.Analyze(h).Score.Overall; got != 100 {
+		t.Errorf("Score.Overall with structure only = %g, want 100", got)
+	}
+}
@@ -0,0 +1,194 @@
+// Package analyzer provides detection of sound devices such as alliteration and rhyme.
+package analyzer
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// Sound device kinds reported in SoundDevice.Kind.
+const (
+	SoundAlliteration  = "alliteration"
+	SoundAssonance     = "assonance"
+	SoundConsonance    = "consonance"
+	SoundInternalRhyme = "internal-rhyme"
+	SoundRhyme         = "rhyme"
+	SoundNearRhyme     = "near-rhyme"
+)
+
+// soundWord holds the sound features of a stressed word.
+type soundWord struct {
+	token haiku.WordToken
+	key   string // lowercase spoken form, so repeats of a word are not matched
+	onset string // initial consonant when the first syllable is stressed
+	vowel string // stressed vowel
+	coda  string // final consonant
+	tail  string // consonants after the stressed vowel
+	rhyme string // phonemes from the stressed vowel to the end
+}
+
+// newSoundWord describes the sound of a token, or reports false for an
+// unstressed token such as a function word.
+func newSoundWord(t haiku.WordToken) (soundWord, bool) {
+	if !strings.ContainsRune(t.Stress, StressedMark) {
+		return soundWord{}, false
+	}
+
+	var phones []string
+	for _, part := range strings.FieldsFunc(t.Normalized, func(r rune) bool { return r == ' ' || r == '-' }) {
+		phones = append(phones, wordPhones(part)...)
+	}
+
+	stressed := stressedVowel(phones)
+	if stressed < 0 {
+		return soundWord{}, false
+	}
+	first := slices.IndexFunc(phones, isVowelPhone)
+
+	w := soundWord{
+		token: t,
+		key:   strings.ToLower(t.Normalized),
+		vowel: phoneme(phones[stressed]),
+		rhyme: phonemes(phones[stressed:]),
+	}
+	if first > 0 && phones[first][len(phones[first])-1] != '0' {
+		w.onset = phoneme(phones[0])
+	}
+	if last := phones[len(phones)-1]; !isVowelPhone(last) {
+		w.coda = phoneme(last)
+	}
+	w.tail = phonemes(phones[stressed+1:])
+	return w, true
+}
+
+// stressedVowel returns the index of the last primary-stressed vowel among
+// phones, or of the first secondary-stressed one, or -1 if none is stressed.
+func stressedVowel(phones []string) int {
+	stressed := -1
+	for i, p := range phones {
+		if !isVowelPhone(p) {
+			continue
+		}
+		if p[len(p)-1] == '1' || (p[len(p)-1] == '2' && stressed < 0) {
+			stressed = i
+		}
+	}
+	return stressed
+}
+
+// findSounds detects sound devices in the explained tokens of each line:
+// alliteration, assonance, consonance and internal rhyme within a line,
+// and rhyme and near-rhyme between the last stressed words of lines.
+func findSounds(lines [][]haiku.WordToken) []haiku.SoundDevice {
+	var devices []haiku.SoundDevice
+	var ends []soundWord
+
+	for _, tokens := range lines {
+		var words []soundWord
+		for _, t := range tokens {
+			if w, ok := newSoundWord(t); ok {
+				words = append(words, w)
+			}
+		}
+		if len(words) > 0 {
+			ends = append(ends, words[len(words)-1])
+		}
+
+		devices = append(devices, groupSounds(SoundAlliteration, words, func(w soundWord) string { return w.onset }, nil)...)
+		devices = append(devices, groupSounds(SoundAssonance, words, func(w soundWord) string { return w.vowel }, func(g []soundWord) bool {
+			return distinct(g, func(w soundWord) string { return w.rhyme }) > 1
+		})...)
+		devices = append(devices, groupSounds(SoundConsonance, words, func(w soundWord) string { return w.coda }, func(g []soundWord) bool {
+			return distinct(g, func(w soundWord) string { return w.vowel }) > 1
+		})...)
+		devices = append(devices, groupSounds(SoundInternalRhyme, words, func(w soundWord) string { return w.rhyme }, nil)...)
+	}
+
+	rhymes := groupSounds(SoundRhyme, ends, func(w soundWord) string { return w.rhyme }, nil)
+	devices = append(devices, rhymes...)
+
+	for i := range ends {
+		for j := i + 1; j < len(ends); j++ {
+			a, b := ends[i], ends[j]
+			if a.key == b.key || a.rhyme == b.rhyme {
+				continue
+			}
+			sound := ""
+			switch {
+			case a.vowel == b.vowel:
+				sound = a.vowel
+			case a.tail != "" && a.tail == b.tail:
+				sound = a.tail
+			default:
+				continue
+			}
+			devices = append(devices, haiku.SoundDevice{
+				Kind:  SoundNearRhyme,
+				Sound: sound,
+				Words: []haiku.SoundWord{a.word(), b.word()},
+			})
+		}
+	}
+	return devices
+}
+
+// groupSounds groups words by a sound feature and returns a device for each
+// group of at least two different words that keep accepts.
+func groupSounds(kind string, words []soundWord, feature func(soundWord) string, keep func([]soundWord) bool) []haiku.SoundDevice {
+	var order []string
+	groups := make(map[string][]soundWord)
+	for _, w := range words {
+		f := feature(w)
+		if f == "" {
+			continue
+		}
+		if _, ok := groups[f]; !ok {
+			order = append(order, f)
+		}
+		groups[f] = append(groups[f], w)
+	}
+
+	var devices []haiku.SoundDevice
+	for _, f := range order {
+		g := groups[f]
+		if distinct(g, func(w soundWord) string { return w.key }) < 2 || (keep != nil && !keep(g)) {
+			continue
+		}
+		d := haiku.SoundDevice{Kind: kind, Sound: f}
+		for _, w := range g {
+			d.Words = append(d.Words, w.word())
+		}
+		devices = append(devices, d)
+	}
+	return devices
+}
+
+// distinct counts the different values of feature among words.
+func distinct(words []soundWord, feature func(soundWord) string) int {
+	seen := make(map[string]bool)
+	for _, w := range words {
+		seen[feature(w)] = true
+	}
+	return len(seen)
+}
+
+// word returns the word's text and position for a sound device.
+func (w soundWord) word() haiku.SoundWord {
+	return haiku.SoundWord{Text: w.token.Text, Span: w.token.Span}
+}
+
+// phoneme returns an ARPAbet phoneme in lowercase without its stress digit.
+func phoneme(p string) string {
+	return strings.ToLower(strings.TrimRight(p, "012"))
+}
+
+// phonemes joins phonemes in lowercase without stress digits.
+func phonemes(ps []string) string {
+	out := make([]string, len(ps))
+	for i, p := range ps {
+		out[i] = phoneme(p)
+	}
+	return strings.Join(out, " ")
+}
@@ -0,0 +1,104 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+func TestFindSounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		expected []string
+	}{
+		{
+			name:  "alliteration and assonance",
+			lines: []string{"an old silent pond", "a frog jumps into the pond", "splash! silence again"},
+			expected: []string{
+				"consonance /d/ old pond",
+				"assonance /aa/ frog pond",
+				"alliteration /s/ splash silence",
+			},
+		},
+		{
+			name:  "internal and end rhyme",
+			lines: []string{"silver streams in spring", "the moon at noon is bright", "a light on the night"},
+			expected: []string{
+				"alliteration /s/ silver streams spring",
+				"assonance /ih/ silver spring",
+				"internal-rhyme /uw n/ moon noon",
+				"internal-rhyme /ay t/ light night",
+				"rhyme /ay t/ bright night",
+			},
+		},
+		{
+			name:  "near rhymes",
+			lines: []string{"under the cold moon", "a heron stands on one leg", "by the mossy stone"},
+			expected: []string{
+				"assonance /eh/ heron leg",
+				"consonance /n/ heron one",
+				"near-rhyme /n/ moon stone",
+			},
+		},
+		{
+			name:     "repeated words",
+			lines:    []string{"rain", "rain", "rain"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(0).Analyze(haiku.NewHaiku(tt.lines))
+			var result []string
+			for _, d := range m.Sounds {
+				var words []string
+				for _, w := range d.Words {
+					words = append(words, w.Text)
+				}
+				result = append(result, d.Kind+" /"+d.Sound+"/ "+strings.Join(words, " "))
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Sounds = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindSounds_Spans(t *testing.T) {
+	source := "  moon over the dune\nwind\nsnow"
+	h := haiku.FromSource(source, []string{"moon over the dune", "wind", "snow"})
+
+	m := New(0).Analyze(h)
+	if len(m.Sounds) == 0 {
+		t.Fatal("Expected sound devices")
+	}
+	for _, d := range m.Sounds {
+		for _, w := range d.Words {
+			if got := source[w.Span.Start:w.Span.End]; got != w.Text {
+				t.Errorf("%s word %q maps to source text %q", d.Kind, w.Text, got)
+			}
+		}
+	}
+}
+
+func TestNewSoundWord(t *testing.T) {
+	words := ExplainLine("the splashing moonlight")
+
+	if _, ok := newSoundWord(words[0]); ok {
+		t.Error("newSoundWord(the) = ok, want unstressed")
+	}
+
+	w, ok := newSoundWord(words[1])
+	if !ok || w.onset != "s" || w.vowel != "ae" || w.rhyme != "ae sh ih ng" {
+		t.Errorf("newSoundWord(splashing) = %+v", w)
+	}
+
+	w, ok = newSoundWord(words[2])
+	if !ok || w.onset != "m" || w.coda != "t" {
+		t.Errorf("newSoundWord(moonlight) = %+v", w)
+	}
+}
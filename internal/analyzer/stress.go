@@ -0,0 +1,169 @@
+// Package analyzer provides lexical stress patterns for meter analysis.
+package analyzer
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"strings"
+)
+
+// Scansion marks for stressed and unstressed syllables.
+const (
+	StressedMark   = '/'
+	UnstressedMark = 'x'
+)
+
+//go:embed data/stress.txt
+var stressData string
+
+// stressLexicon maps words to one stress digit per syllable: '1' primary,
+// '2' secondary and '0' none.
+var stressLexicon = parseStressLexicon(stressData)
+
+// finalStressSuffixes take the primary stress on their own syllable:
+// "degree", "balloon", "seventeen", "unique".
+var finalStressSuffixes = []string{"ee", "eer", "oon", "teen", "ique", "esque", "ette"}
+
+// penultStressSuffixes put the primary stress on the syllable before them:
+// "creation", "poetic", "musician".
+var penultStressSuffixes = []string{"tion", "sion", "cian", "cial", "tial", "cious", "tious", "ic", "ics"}
+
+// antepenultStressSuffixes put the primary stress on the third syllable
+// from the end: "clarity", "curious", "geology".
+var antepenultStressSuffixes = []string{"ity", "ety", "ify", "graphy", "logy", "ous"}
+
+// parseStressLexicon reads the embedded word<TAB>digits list.
+func parseStressLexicon(data string) map[string]string {
+	lexicon := make(map[string]string)
+	sc := bufio.NewScanner(strings.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word, digits, ok := strings.Cut(line, "\t")
+		if !ok || strings.Trim(digits, "012") != "" {
+			panic(fmt.Sprintf("stress data: invalid entry %q", line))
+		}
+		lexicon[word] = digits
+	}
+	return lexicon
+}
+
+// wordStress returns the stress digits of a single word, one per syllable
+// as counted by countWord. Listed words come from the lexicon; others
+// follow their affixes and then suffix rules, defaulting to stress on the
+// first syllable of short words and the third from last of long ones.
+func wordStress(word string) string {
+	word = strings.ToLower(strings.Trim(word, ".,;:!?\"“”‘’()[]"))
+	if s, ok := stressLexicon[word]; ok {
+		return s
+	}
+
+	n, _ := countWord(word)
+	if n == 0 {
+		return ""
+	}
+	if n == 1 {
+		return "1"
+	}
+
+	if _, ok := exceptionSyllables[word]; !ok {
+		if a, ok := splitAffix(word); ok && !(a.suffix == "ed" && a.syllabic && clusterBefore(a.stem, 'r')) {
+			var s string
+			switch {
+			case a.prefix != "":
+				s = "0" + wordStress(a.stem)
+			case a.syllabic:
+				s = wordStress(a.base) + "0"
+			default:
+				s = wordStress(a.base)
+			}
+			return fitStress(s, n)
+		}
+	}
+
+	return ruleStress(word, n)
+}
+
+// ruleStress places the primary stress of an unlisted word of n syllables
+// by its ending, with secondary stress two syllables before it.
+func ruleStress(word string, n int) string {
+	primary := 0
+	if n > 3 {
+		primary = n - 3
+	}
+	switch {
+	case hasAnySuffix(word, finalStressSuffixes):
+		primary = n - 1
+	case hasAnySuffix(word, penultStressSuffixes):
+		primary = n - 2
+	case hasAnySuffix(word, antepenultStressSuffixes):
+		primary = max(n-3, 0)
+	}
+
+	s := []byte(strings.Repeat("0", n))
+	s[primary] = '1'
+	for i := primary - 2; i >= 0; i -= 2 {
+		s[i] = '2'
+	}
+	return string(s)
+}
+
+// tokenStress returns the stress digits of a token, fitted to n syllables
+// so that overrides and dialect counts keep one digit per syllable.
+// Function words keep no stress; spelled-out numbers and compounds are
+// stressed word by word.
+func tokenStress(t Token, n int) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(t.Spoken, func(r rune) bool { return r == ' ' || r == '-' }) {
+		if s, ok := stressLexicon[strings.ToLower(part)]; ok {
+			b.WriteString(s)
+			continue
+		}
+		if !strings.ContainsAny(part, "'’") {
+			b.WriteString(wordStress(part))
+			continue
+		}
+
+		c := splitContraction(strings.ToLower(part))
+		b.WriteString(wordStress(c.head))
+		if c.syllabic {
+			b.WriteByte('0')
+		}
+	}
+	return fitStress(b.String(), n)
+}
+
+// fitStress pads s with unstressed syllables or trims it to n digits.
+func fitStress(s string, n int) string {
+	if len(s) >= n {
+		return s[:n]
+	}
+	return s + strings.Repeat("0", n-len(s))
+}
+
+// stressMarks renders stress digits as scansion marks, treating secondary
+// stress as stressed: "102" -> "/x/".
+func stressMarks(digits string) string {
+	marks := []byte(digits)
+	for i, d := range marks {
+		if d == '0' {
+			marks[i] = UnstressedMark
+		} else {
+			marks[i] = StressedMark
+		}
+	}
+	return string(marks)
+}
+
+// hasAnySuffix reports whether word ends in one of suffixes.
+func hasAnySuffix(word string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(word, suffix) && len(word) > len(suffix) {
+			return true
+		}
+	}
+	return false
+}
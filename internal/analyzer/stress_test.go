@@ -0,0 +1,103 @@
+package analyzer
+
+import "testing"
+
+func TestWordStress(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected string
+	}{
+		// Lexicon
+		{"the", "0"},
+		{"alone", "01"},
+		{"butterfly", "102"},
+		{"Tomorrow", "010"},
+
+		// Monosyllables
+		{"pond", "1"},
+		{"frog", "1"},
+
+		// Affixes keep the stress of their base
+		{"blossoms", "10"},
+		{"remembered", "010"},
+		{"happiness", "100"},
+		{"reopen", "010"},
+
+		// Suffix rules
+		{"degree", "01"},
+		{"seventeen", "201"},
+		{"creation", "010"},
+		{"conversation", "2010"},
+		{"clarity", "100"},
+
+		// Default
+		{"river", "10"},
+		{"elephant", "100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if result := wordStress(tt.word); result != tt.expected {
+				t.Errorf("wordStress(%q) = %q, want %q", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStressLexicon_MatchesCount(t *testing.T) {
+	for word, digits := range stressLexicon {
+		if n := CountSyllables(word); len(digits) != n {
+			t.Errorf("stress lexicon %q = %q, want %d digits", word, digits, n)
+		}
+	}
+}
+
+func TestTokenStress(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected []string
+	}{
+		{"in 1999", []string{"0", "2110"}},
+		{"didn't she", []string{"10", "0"}},
+		{"ice-cold a.m.", []string{"11", "11"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			tokens := Tokenize(tt.line)
+			if len(tokens) != len(tt.expected) {
+				t.Fatalf("Tokenize(%q) returned %d tokens, want %d", tt.line, len(tokens), len(tt.expected))
+			}
+			for i, tok := range tokens {
+				if result := tokenStress(tok, len(tt.expected[i])); result != tt.expected[i] {
+					t.Errorf("tokenStress(%q) = %q, want %q", tok.Text, result, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFitStress(t *testing.T) {
+	tests := []struct {
+		s        string
+		n        int
+		expected string
+	}{
+		{"10", 2, "10"},
+		{"10", 3, "100"},
+		{"102", 2, "10"},
+		{"", 1, "0"},
+	}
+
+	for _, tt := range tests {
+		if result := fitStress(tt.s, tt.n); result != tt.expected {
+			t.Errorf("fitStress(%q, %d) = %q, want %q", tt.s, tt.n, result, tt.expected)
+		}
+	}
+}
+
+func TestStressMarks(t *testing.T) {
+	if result := stressMarks("1020"); result != "/x/x" {
+		t.Errorf("stressMarks(%q) = %q, want %q", "1020", result, "/x/x")
+	}
+}
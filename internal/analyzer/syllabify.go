@@ -0,0 +1,397 @@
+// Package analyzer provides syllabification of words into hyphenated parts.
+package analyzer
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// SyllableSeparator joins the syllables of a word for display.
+const SyllableSeparator = "·"
+
+//go:embed data/hyphenation.txt
+var hyphenationData string
+
+// hyphenation holds the parsed embedded pattern set and exceptions.
+var hyphenation = parseHyphenation(hyphenationData)
+
+// hyphenationSet is a Liang pattern set with whole-word exceptions.
+type hyphenationSet struct {
+	patterns   map[string][]int    // pattern letters -> inter-letter values
+	maxLen     int                 // longest pattern, in runes
+	exceptions map[string][]string // word -> syllables
+}
+
+// legalOnsets are consonant clusters that can begin an English syllable.
+var legalOnsets = map[string]bool{
+	"bl": true, "br": true, "ch": true, "cl": true, "cr": true, "dr": true, "dw": true,
+	"fl": true, "fr": true, "gl": true, "gr": true, "kl": true, "kn": true, "kr": true,
+	"ph": true, "pl": true, "pr": true, "qu": true, "sc": true, "sh": true, "sk": true,
+	"sl": true, "sm": true, "sn": true, "sp": true, "st": true, "sw": true, "th": true,
+	"tr": true, "tw": true, "wh": true, "wr": true, "phr": true, "sch": true, "scr": true,
+	"shr": true, "spl": true, "spr": true, "squ": true, "str": true, "thr": true,
+}
+
+// Syllabify divides a word into its syllables, e.g. "middle" -> ["mid", "dle"]
+// and "creation" -> ["cre", "a", "tion"]. It follows the same rules as
+// CountSyllables, so the number of parts matches the count for the word.
+// Leading and trailing punctuation stays attached to the first and last part.
+func Syllabify(word string) []string {
+	w := []rune(word)
+	start, end := 0, len(w)
+	for start < end && !isLetter(w[start]) {
+		start++
+	}
+	for end > start && !isLetter(w[end-1]) {
+		end--
+	}
+	if start >= end {
+		if word == "" {
+			return nil
+		}
+		return []string{word}
+	}
+
+	core := string(w[start:end])
+	parts := recase(syllabifyLower(strings.ToLower(core)), core)
+	parts[0] = string(w[:start]) + parts[0]
+	parts[len(parts)-1] += string(w[end:])
+	return parts
+}
+
+// syllabifyLower divides a lowercase word, mirroring countWord: listed
+// exceptions first, then affixes, then vowel nuclei.
+func syllabifyLower(word string) []string {
+	if parts, ok := hyphenation.exceptions[word]; ok {
+		return slices.Clone(parts)
+	}
+
+	if n, ok := exceptionSyllables[word]; ok {
+		return fitParts(splitNuclei(word), n)
+	}
+
+	if a, ok := splitAffix(word); ok {
+		if a.suffix == "ed" && a.syllabic && clusterBefore(a.stem, 'r') {
+			// Not a past tense: "hun-dred", "sa-cred".
+			return splitNuclei(word)
+		}
+		if a.prefix != "" {
+			return append([]string{a.prefix}, syllabifyLower(a.stem)...)
+		}
+		parts := respell(syllabifyLower(a.base), a.stem)
+		if a.syllabic {
+			return append(parts, a.suffix)
+		}
+		parts[len(parts)-1] += a.suffix
+		return parts
+	}
+
+	return splitNuclei(word)
+}
+
+// splitNuclei divides a word between its vowel nuclei, placing each break
+// within the consonants between two nuclei where the pattern set allows
+// one, or else before the longest legal onset.
+func splitNuclei(word string) []string {
+	r := []rune(word)
+	nuclei := vowelNuclei(r)
+	if len(nuclei) < 2 {
+		return []string{word}
+	}
+
+	values := hyphenation.values(r)
+	parts := make([]string, 0, len(nuclei))
+	prev := 0
+	for k := 0; k < len(nuclei)-1; k++ {
+		lo, hi := nuclei[k][1], nuclei[k+1][0]
+		cut := -1
+		for j := lo; j <= hi; j++ {
+			if values[j]%2 == 1 && (cut < 0 || values[j] > values[cut]) {
+				cut = j
+			}
+		}
+		if cut < 0 || (k == len(nuclei)-2 && sonorantEnding(r)) {
+			cut = onsetSplit(r, lo, hi, k == len(nuclei)-2)
+		}
+		parts = append(parts, string(r[prev:cut]))
+		prev = cut
+	}
+	return append(parts, string(r[prev:]))
+}
+
+// onsetSplit chooses a break in the consonants r[lo:hi] between two
+// nuclei, giving the next syllable the longest legal onset. A final
+// consonant + "le"/"re" syllable keeps both consonants ("ta-ble"), 'x'
+// closes the syllable before it, and "ck" and "gh" are never divided.
+func onsetSplit(r []rune, lo, hi int, last bool) int {
+	if hi-lo == 0 {
+		return lo
+	}
+	if last && sonorantEnding(r) && hi-2 >= lo {
+		return hi - 2
+	}
+
+	cut := hi - 1
+	for j := lo; j < hi-1; j++ {
+		if legalOnsets[string(r[j:hi])] {
+			cut = j
+			break
+		}
+	}
+	switch {
+	case r[cut] == 'x':
+		cut++
+	case cut > lo && (r[cut-1] == 'c' && r[cut] == 'k' || r[cut-1] == 'g' && r[cut] == 'h'):
+		cut++
+	}
+	return cut
+}
+
+// values returns the Liang value at each break position of r: values[i]
+// applies between r[i-1] and r[i].
+func (h hyphenationSet) values(r []rune) []int {
+	dotted := append(append([]rune{'.'}, r...), '.')
+	dv := make([]int, len(dotted)+1)
+
+	for i := range dotted {
+		for j := i + 1; j <= len(dotted) && j-i <= h.maxLen; j++ {
+			pv, ok := h.patterns[string(dotted[i:j])]
+			if !ok {
+				continue
+			}
+			for k, v := range pv {
+				dv[i+k] = max(dv[i+k], v)
+			}
+		}
+	}
+
+	// Position m in r is position m+1 in the dotted word.
+	values := make([]int, len(r)+1)
+	for m := 1; m < len(r); m++ {
+		values[m] = dv[m+1]
+	}
+	return values
+}
+
+// parseHyphenation reads the embedded pattern and exception lists.
+func parseHyphenation(data string) hyphenationSet {
+	h := hyphenationSet{patterns: make(map[string][]int), exceptions: make(map[string][]string)}
+	section := ""
+
+	sc := bufio.NewScanner(strings.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "["):
+			section = line
+			continue
+		}
+
+		switch section {
+		case "[patterns]":
+			letters, values := parsePattern(line)
+			h.patterns[letters] = values
+			h.maxLen = max(h.maxLen, len([]rune(letters)))
+		case "[exceptions]":
+			h.exceptions[strings.ReplaceAll(line, "-", "")] = strings.Split(line, "-")
+		default:
+			panic(fmt.Sprintf("hyphenation data: entry %q outside a section", line))
+		}
+	}
+	return h
+}
+
+// parsePattern splits a Liang pattern such as "as1t" into its letters
+// ("ast") and the value before each letter and after the last ([0 0 1 0]).
+func parsePattern(p string) (string, []int) {
+	var letters []rune
+	values := []int{0}
+	for _, ch := range p {
+		if unicode.IsDigit(ch) {
+			values[len(values)-1] = int(ch - '0')
+			continue
+		}
+		letters = append(letters, ch)
+		values = append(values, 0)
+	}
+	return string(letters), values
+}
+
+// fitParts merges trailing parts until there are at most n.
+func fitParts(parts []string, n int) []string {
+	for len(parts) > max(n, 1) {
+		last := len(parts) - 1
+		parts = append(parts[:last-1], parts[last-1]+parts[last])
+	}
+	return parts
+}
+
+// respell maps the syllables of a counted base onto its spelled stem, which
+// differs only at the end: ["stone"] -> ["ston"], ["hap", "py"] -> ["hap", "pi"].
+func respell(parts []string, stem string) []string {
+	out := make([]string, len(parts))
+	copy(out, parts)
+
+	prefix := len(strings.Join(parts[:len(parts)-1], ""))
+	if prefix >= len(stem) {
+		return fitParts([]string{stem}, 1)
+	}
+	out[len(out)-1] = stem[prefix:]
+	return out
+}
+
+// recase applies the letter case of word to parts of its lowercase form.
+func recase(parts []string, word string) []string {
+	w := []rune(word)
+	out := make([]string, len(parts))
+	i := 0
+	for k, p := range parts {
+		n := len([]rune(p))
+		if i+n > len(w) {
+			return parts
+		}
+		out[k] = string(w[i : i+n])
+		i += n
+	}
+	if i != len(w) {
+		return parts
+	}
+	return out
+}
+
+// syllabifyToken divides a token's spoken form for display, joining
+// syllables with SyllableSeparator and keeping spaces and hyphens between
+// spoken words: "1999" -> "nine·teen nine·ty-nine". When n is positive the
+// parts are merged or split to number n, so they agree with a count taken
+// from an override or dialect: "hour" counted as two -> "ho·ur".
+func syllabifyToken(t Token, n int) string {
+	var words [][]string
+	var seps []rune
+	if t.Kind == TokenContraction {
+		words = append(words, strings.Split(syllabifyContraction(t.Spoken), SyllableSeparator))
+	} else {
+		start := 0
+		for i, ch := range t.Spoken {
+			if ch == ' ' || ch == '-' {
+				words = append(words, syllabifySpoken(t.Spoken[start:i]))
+				seps = append(seps, ch)
+				start = i + 1
+			}
+		}
+		words = append(words, syllabifySpoken(t.Spoken[start:]))
+	}
+	if n > 0 {
+		fitCount(words, n)
+	}
+
+	var b strings.Builder
+	for i, parts := range words {
+		if i > 0 {
+			b.WriteRune(seps[i-1])
+		}
+		b.WriteString(strings.Join(parts, SyllableSeparator))
+	}
+	return b.String()
+}
+
+// syllabifySpoken divides one spoken word, which may be a contraction.
+func syllabifySpoken(word string) []string {
+	switch {
+	case word == "":
+		return nil
+	case strings.Contains(word, "'"):
+		return strings.Split(syllabifyContraction(word), SyllableSeparator)
+	}
+	return Syllabify(word)
+}
+
+// fitCount merges or splits the parts of words, last word first, until
+// they number n or no part can be split further.
+func fitCount(words [][]string, n int) {
+	total := 0
+	for _, parts := range words {
+		total += len(parts)
+	}
+	for k := len(words) - 1; k >= 0 && total > n; k-- {
+		keep := max(len(words[k])-(total-n), 1)
+		total -= len(words[k]) - keep
+		words[k] = fitParts(words[k], keep)
+	}
+	for k := len(words) - 1; k >= 0 && total < n; k-- {
+		for total < n {
+			parts, ok := splitSyllable(words[k])
+			if !ok {
+				break
+			}
+			words[k] = parts
+			total++
+		}
+	}
+}
+
+// splitSyllable divides the last part holding two vowels before its last
+// vowel, taking along a consonant ahead of it: "hour" -> "ho·ur" and
+// "fire" -> "fi·re". It reports false when no part holds two vowels.
+func splitSyllable(parts []string) ([]string, bool) {
+	vowel := func(r rune) bool { return isVowel(unicode.ToLower(r)) }
+	for k := len(parts) - 1; k >= 0; k-- {
+		r := []rune(parts[k])
+		first := slices.IndexFunc(r, vowel)
+		last := len(r) - 1
+		for last > first && !vowel(r[last]) {
+			last--
+		}
+		if first < 0 || last == first {
+			continue
+		}
+		cut := last
+		if !vowel(r[cut-1]) && cut-1 > first {
+			cut--
+		}
+		out := slices.Concat(parts[:k], []string{string(r[:cut]), string(r[cut:])}, parts[k+1:])
+		return out, true
+	}
+	return parts, false
+}
+
+// syllabifyContraction divides a word with an apostrophe, giving a syllabic
+// clitic its own part: "didn't" -> "did·n't", "don't" -> "don't".
+func syllabifyContraction(word string) string {
+	c := splitContraction(word)
+	if c.joined {
+		// Restore the apostrophe removed from head.
+		joined := strings.Join(Syllabify(c.head), SyllableSeparator)
+		at := seekLetters(joined, strings.LastIndex(word, "'"))
+		return joined[:at] + "'" + joined[at:]
+	}
+
+	parts := Syllabify(c.head)
+	parts[0] = c.lead + parts[0]
+	if c.syllabic {
+		parts = append(parts, c.tail)
+	} else {
+		parts[len(parts)-1] += c.tail
+	}
+	return strings.Join(parts, SyllableSeparator)
+}
+
+// seekLetters returns the byte offset in joined after n bytes of letters,
+// skipping separators.
+func seekLetters(joined string, n int) int {
+	i := 0
+	for n > 0 && i < len(joined) {
+		if strings.HasPrefix(joined[i:], SyllableSeparator) {
+			i += len(SyllableSeparator)
+			continue
+		}
+		i++
+		n--
+	}
+	return i
+}
@@ -0,0 +1,145 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSyllabify(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected string
+	}{
+		// Listed exceptions
+		{"middle", "mid·dle"},
+		{"creation", "cre·a·tion"},
+		{"every", "ev·ery"},
+
+		// Patterns
+		{"question", "ques·tion"},
+		{"picture", "pic·ture"},
+		{"whisper", "whis·per"},
+		{"chicken", "chick·en"},
+		{"river", "riv·er"},
+
+		// Onset rules
+		{"silent", "si·lent"},
+		{"monster", "mon·ster"},
+		{"kitchen", "kit·chen"},
+		{"candle", "can·dle"},
+		{"acre", "a·cre"},
+		{"taxi", "tax·i"},
+		{"radio", "ra·di·o"},
+		{"hundred", "hun·dred"},
+
+		// Affixes
+		{"wanted", "want·ed"},
+		{"jumped", "jumped"},
+		{"created", "cre·at·ed"},
+		{"tumbled", "tum·bled"},
+		{"boxes", "box·es"},
+		{"happiness", "hap·pi·ness"},
+		{"reopen", "re·o·pen"},
+		{"reuse", "re·use"},
+		{"deice", "de·ice"},
+
+		// Case and punctuation are kept
+		{"Ouachita", "Oua·chi·ta"},
+		{"Hello,", "Hel·lo,"},
+		{"pond", "pond"},
+		{"—", "—"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			result := strings.Join(Syllabify(tt.word), SyllableSeparator)
+			if result != tt.expected {
+				t.Errorf("Syllabify(%q) = %q, want %q", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSyllabify_MatchesCount(t *testing.T) {
+	words := []string{
+		"beautiful", "elephant", "morning", "sunshine", "blossoms", "dancing", "petals",
+		"seventeen", "eleven", "curious", "special", "unique", "vague", "agree", "movie",
+		"value", "loneliness", "coexist", "googled", "naïve", "coöperate", "café",
+		"reelect", "preexist", "reappear", "recreate", "cooperate", "ruined",
+	}
+	for word := range exceptionSyllables {
+		words = append(words, word)
+	}
+	for word := range hyphenation.exceptions {
+		words = append(words, word)
+	}
+
+	for _, word := range words {
+		if parts := Syllabify(word); len(parts) != CountSyllables(word) {
+			t.Errorf("Syllabify(%q) = %v, want %d parts", word, parts, CountSyllables(word))
+		}
+	}
+}
+
+func TestSyllabifyToken(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected string
+	}{
+		{"in 1999", "in nine·teen nine·ty-nine"},
+		{"didn't don't it'll", "did·n't don't it·'ll"},
+		{"'tis o'clock", "'tis o'·clock"},
+		{"ice-cold Dr.", "ice-cold doc·tor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			var parts []string
+			for _, tok := range Tokenize(tt.line) {
+				parts = append(parts, syllabifyToken(tok, 0))
+			}
+			if result := strings.Join(parts, " "); result != tt.expected {
+				t.Errorf("syllabifyToken(%q) = %q, want %q", tt.line, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSyllabifyToken_Count(t *testing.T) {
+	tests := []struct {
+		word     string
+		n        int
+		expected string
+	}{
+		{"hour", 2, "ho·ur"},
+		{"fire", 2, "fi·re"},
+		{"fired", 2, "fi·red"},
+		{"every", 3, "ev·e·ry"},
+		{"poem", 1, "poem"},
+		{"1999", 4, "nine·teen ninety-nine"},
+		{"didn't", 1, "didn't"},
+		{"splash", 2, "splash"}, // nothing left to split
+		{"river", 0, "riv·er"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if result := syllabifyToken(Tokenize(tt.word)[0], tt.n); result != tt.expected {
+				t.Errorf("syllabifyToken(%q, %d) = %q, want %q", tt.word, tt.n, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	letters, values := parsePattern("as1t")
+	if letters != "ast" {
+		t.Errorf("letters = %q, want %q", letters, "ast")
+	}
+	want := []int{0, 0, 1, 0}
+	for i := range want {
+		if values[i] != want[i] {
+			t.Fatalf("values = %v, want %v", values, want)
+		}
+	}
+}
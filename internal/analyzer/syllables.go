@@ -16,27 +16,69 @@ var exceptionSyllables = map[string]int{
 	"gone": 1, "one": 1, "two": 1, "three": 1, "four": 1, "five": 1,
 	"eight": 1, "twelve": 1, "world": 1, "apple": 2, "chocolate": 3,
 	"table": 2, "simple": 2, "little": 2, "middle": 2, "pickle": 2,
-	"creation": 3, "reaction": 3,
+	"creation": 3, "reaction": 3, "create": 2, "react": 2,
+	"lion": 2, "dandelion": 4, "argue": 2, "segue": 2, "everything": 3, "everyone": 3, "element": 3,
+
+	// Adjectives in -ed whose ending is sounded
+	"naked": 2, "wicked": 2, "crooked": 2, "rugged": 2, "ragged": 2,
+	"jagged": 2, "wretched": 2, "beloved": 3,
+
+	// Compounds with a silent 'e' inside, and other vowel-group misses
+	"something": 2, "somewhere": 2, "somehow": 2, "sometimes": 2, "anyone": 3,
+	"everywhere": 3, "firefly": 2, "fireflies": 2, "beyond": 2, "silhouette": 3, "quiet": 2,
+	"fireplace": 2, "scarecrow": 2, "scarecrows": 2,
+
+	// Words that only look like a prefix before a vowel
+	"reign": 1, "deign": 1, "deuce": 1,
+
+	// Spelled-out numbers and abbreviations produced by the tokenizer
+	"nineteen": 2, "nineteenth": 2, "ninety": 2, "nineties": 2, "ninetieth": 3,
+	"avenue": 3, "january": 4, "february": 4, "approximately": 5,
 }
 
-var wordRe = regexp.MustCompile(`[A-Za-z']+`)
+// wordRe matches runs of letters, combining marks and apostrophes in any script.
+var wordRe = regexp.MustCompile(`[\p{L}\p{M}'’]+`)
+
+// vowelFold maps accented Latin vowels to their base vowel for counting.
+var vowelFold = map[rune]rune{
+	'à': 'a', 'á': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'å': 'a', 'ā': 'a', 'æ': 'a',
+	'è': 'e', 'é': 'e', 'ê': 'e', 'ë': 'e', 'ē': 'e',
+	'ì': 'i', 'í': 'i', 'î': 'i', 'ï': 'i', 'ī': 'i',
+	'ò': 'o', 'ó': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ø': 'o', 'ō': 'o', 'œ': 'o',
+	'ù': 'u', 'ú': 'u', 'û': 'u', 'ü': 'u', 'ū': 'u',
+	'ý': 'y', 'ÿ': 'y',
+}
+
+// diaeresis marks vowels whose diaeresis signals a separate syllable, as in "naïve".
+const diaeresis = "äëïöüÿ"
 
 // ExtractWords extracts all words from a string using regex.
+// Letters from any script are recognized, so "café" and "naïve" stay whole.
 func ExtractWords(s string) []string {
 	return wordRe.FindAllString(s, -1)
 }
 
 // CountSyllables estimates the number of syllables in a word using heuristic rules.
+// Words outside the exception dictionary are split into known prefixes and
+// suffixes before the remaining stem is counted by vowel groups.
 // Note: English syllable counting is inherently heuristic. This implementation
 // provides reasonable estimates but may not be 100% accurate for all words.
 func CountSyllables(word string) int {
+	n, _ := countWord(word)
+	return n
+}
+
+// countWord counts the syllables in a word and reports which method decided
+// the count: the exception dictionary, the morphological rules or the
+// vowel-group heuristic.
+func countWord(word string) (int, string) {
 	if word == "" {
-		return 0
+		return 0, MethodHeuristic
 	}
 
 	// Check exception dictionary first
 	if v, ok := exceptionSyllables[strings.ToLower(word)]; ok {
-		return v
+		return v, MethodException
 	}
 
 	w := []rune(word)
@@ -49,57 +91,124 @@ func CountSyllables(word string) int {
 		end--
 	}
 	if start >= end {
-		return 0
+		return 0, MethodHeuristic
 	}
 
-	r := w[start:end]
-	lower := strings.ToLower(string(r))
+	lower := strings.ToLower(string(w[start:end]))
+	if v, ok := exceptionSyllables[lower]; ok {
+		return v, MethodException
+	}
+	if v, ok := countMorphology(lower); ok {
+		return v, MethodMorphology
+	}
+	return countVowelGroups(lower), MethodHeuristic
+}
 
-	vowels := func(r rune) bool { return strings.ContainsRune("aeiouy", r) }
-	count := 0
-	prevV := false
+// countVowelGroups counts the vowel groups of a lowercase word, splitting
+// the hiatus in "ia" and "io" and discounting a silent final 'e'.
+func countVowelGroups(lower string) int {
+	// Ensure at least one syllable
+	return max(len(vowelNuclei([]rune(lower))), 1)
+}
 
-	// Count vowel groups
-	for _, ch := range lower {
-		isV := vowels(ch)
-		if isV && !prevV {
-			count++
+// vowelNuclei returns the start and end of each sounded vowel group in r.
+// A diaeresis or a hiatus starts a new group, and a silent final 'e' (or
+// "ue" after 'g' or 'q', as in "vague" and "unique") is not a nucleus.
+func vowelNuclei(r []rune) [][2]int {
+	var groups [][2]int
+	for i, ch := range r {
+		if !isVowel(ch) {
+			continue
+		}
+		if i > 0 && isVowel(r[i-1]) && !strings.ContainsRune(diaeresis, ch) && !isHiatus(r, i) {
+			groups[len(groups)-1][1] = i + 1
+			continue
 		}
-		prevV = isV
+		groups = append(groups, [2]int{i, i + 1})
 	}
 
-	// Handle terminal silent 'e'
-	if strings.HasSuffix(lower, "e") && !strings.HasSuffix(lower, "le") && count > 1 {
-		count--
+	if n := len(groups); n > 1 && isSilentEnding(r, groups[n-1]) {
+		groups = groups[:n-1]
 	}
+	return groups
+}
 
-	// Handle 'le' ending like "table" - often adds a syllable if preceded by consonant
-	if strings.HasSuffix(lower, "le") && len(lower) > 2 {
-		pre := rune(lower[len(lower)-3])
-		if !strings.ContainsRune("aeiouy", pre) {
-			count++
-		}
+// isSilentEnding reports whether the final vowel group g of r is silent.
+// A lone final 'e' is silent except in consonant + "le"/"re" endings such
+// as "table" and "acre".
+func isSilentEnding(r []rune, g [2]int) bool {
+	if g[1] != len(r) {
+		return false
 	}
+	switch string(r[g[0]:g[1]]) {
+	case "e":
+		return !sonorantEnding(r)
+	case "ue":
+		return g[0] > 0 && (r[g[0]-1] == 'g' || r[g[0]-1] == 'q')
+	}
+	return false
+}
 
-	// Ensure at least one syllable
-	if count < 1 {
-		count = 1
+// isHiatus reports whether the vowel at r[i] begins a new syllable after
+// an 'i', as in "radio", "piano" and "curious". The pair stays a single
+// syllable in "-tion", "-cial", "-gious", "-llia-" and similar endings
+// where the 'i' only softens the consonant before it.
+func isHiatus(r []rune, i int) bool {
+	if i < 1 || r[i-1] != 'i' || (r[i] != 'a' && r[i] != 'o') {
+		return false
 	}
 
-	return count
+	var pre rune
+	if i >= 2 {
+		pre = r[i-2]
+	}
+	next := string(r[i+1:])
+
+	switch {
+	case pre == 'l' && i >= 3 && r[i-3] == 'l':
+		return false
+	case r[i] == 'o' && strings.HasPrefix(next, "n"):
+		return !strings.ContainsRune("ctsxglnh", pre)
+	case r[i] == 'o' && strings.HasPrefix(next, "us"):
+		return !strings.ContainsRune("ctgxh", pre)
+	case r[i] == 'a' && strings.HasPrefix(next, "ge"):
+		return false
+	case r[i] == 'a':
+		return !strings.ContainsRune("ctsxgh", pre)
+	}
+	return true
+}
+
+// sonorantEnding reports whether a word ends in consonant + "le" or "re",
+// whose final 'e' is sounded as a syllable.
+func sonorantEnding(r []rune) bool {
+	n := len(r)
+	if n < 3 || r[n-1] != 'e' || (r[n-2] != 'l' && r[n-2] != 'r') {
+		return false
+	}
+	pre := r[n-3]
+	return !isVowel(pre) && pre != r[n-2] && pre != 'w'
 }
 
 // isLetter returns true if the rune is a letter or apostrophe.
 func isLetter(r rune) bool {
-	return unicode.IsLetter(r) || r == '\''
+	return unicode.IsLetter(r) || r == '\'' || r == '’'
+}
+
+// isVowel reports whether r is a vowel, including accented Latin vowels.
+func isVowel(r rune) bool {
+	if f, ok := vowelFold[r]; ok {
+		r = f
+	}
+	return strings.ContainsRune("aeiouy", r)
 }
 
 // CountLineSyllables counts total syllables in a line of text.
+// Numbers, abbreviations and contractions are counted by their spoken form.
 func CountLineSyllables(line string) int {
-	words := ExtractWords(line)
 	total := 0
-	for _, word := range words {
-		total += CountSyllables(strings.ToLower(word))
+	for _, tok := range Tokenize(line) {
+		total += tok.Syllables()
 	}
 	return total
 }
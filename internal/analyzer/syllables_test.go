@@ -25,12 +25,20 @@ func TestCountSyllables(t *testing.T) {
 		{"every", 2},
 		{"family", 2},
 		{"again", 2},
+		{"scarecrow", 2},
+		{"fireplace", 2},
 
 		// Silent 'e' cases
 		{"make", 1},
 		{"table", 2},  // 'le' ending
 		{"simple", 2}, // 'le' ending
 		{"apple", 2},  // exception dictionary
+		{"agree", 2},  // 'ee' is its own vowel group
+		{"movie", 2},
+		{"value", 2},
+		{"vague", 1},  // silent 'ue' after 'g'
+		{"unique", 2}, // silent 'ue' after 'q'
+		{"argue", 2},  // exception dictionary
 
 		// Edge cases
 		{"", 0},
@@ -43,11 +51,56 @@ func TestCountSyllables(t *testing.T) {
 		{"beautiful", 3}, // beau-ti-ful
 		{"creation", 3},  // cre-a-tion
 		{"reaction", 3},  // re-ac-tion
+		{"ruined", 2},    // ru-ined
+
+		// Compounds with an inner silent 'e'
+		{"something", 2},
+		{"sometimes", 2},
+		{"firefly", 2},
+		{"everywhere", 3},
+		{"anyone", 3},
+		{"beyond", 2},
+		{"silhouette", 3},
+		{"quiet", 2},
 
 		// Consonant 'le' endings
 		{"little", 2}, // lit-tle
 		{"middle", 2}, // mid-dle
 		{"pickle", 2}, // pick-le
+
+		// Consonant 'le' and 're' endings outside the dictionary
+		{"candle", 2},
+		{"whole", 1},
+		{"acre", 2},
+		{"gazelle", 2},
+
+		// Hiatus in "ia" and "io"
+		{"radio", 3},
+		{"piano", 3},
+		{"curious", 3},
+		{"nation", 2},
+		{"special", 2},
+		{"precious", 2},
+		{"million", 2},
+		{"marriage", 2},
+
+		// Inflected and derived words
+		{"jumped", 1},
+		{"wanted", 2},
+		{"boxes", 2},
+		{"stones", 1},
+		{"reopen", 3},
+		{"reuse", 2},
+		{"deice", 2},
+		{"reign", 1},
+		{"deuce", 1},
+
+		// Accented and typographic input
+		{"café", 2},      // ca-fé, final é is not silent
+		{"naïve", 2},     // na-ïve, diaeresis splits the vowels
+		{"résumé", 3},    // ré-su-mé
+		{"don’t", 1},     // curly apostrophe stays inside the word
+		{"coöperate", 4}, // co-op-er-ate
 	}
 
 	for _, tt := range tests {
@@ -71,6 +124,9 @@ func TestExtractWords(t *testing.T) {
 		{"", nil},
 		{"123 abc 456", []string{"abc"}},
 		{"don't worry, be happy!", []string{"don't", "worry", "be", "happy"}},
+		{"café naïve", []string{"café", "naïve"}},
+		{"don’t stop", []string{"don’t", "stop"}},
+		{"古池や", []string{"古池や"}},
 	}
 
 	for _, tt := range tests {
@@ -100,6 +156,9 @@ func TestCountLineSyllables(t *testing.T) {
 		{"", 0},
 		{"the quick brown fox", 4},
 		{"beautiful morning sunshine", 7},
+		{"3 crows on a wire", 5},
+		{"in 1999 we didn't sleep", 10},
+		{"Dr. Lee on Main St.", 6},
 	}
 
 	for _, tt := range tests {
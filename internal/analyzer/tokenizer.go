@@ -0,0 +1,302 @@
+// Package analyzer provides tokenization of haiku lines into spoken units.
+package analyzer
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenKind classifies a token produced by Tokenize.
+type TokenKind string
+
+// Token kinds recognized by Tokenize.
+const (
+	TokenWord         TokenKind = "word"
+	TokenNumber       TokenKind = "number"
+	TokenOrdinal      TokenKind = "ordinal"
+	TokenYear         TokenKind = "year"
+	TokenAbbreviation TokenKind = "abbreviation"
+	TokenCompound     TokenKind = "compound"
+	TokenContraction  TokenKind = "contraction"
+)
+
+// Token is a unit of a line together with the form in which it is spoken.
+// Start and End are byte offsets of Text within the tokenized line.
+type Token struct {
+	Text   string    `json:"text"`
+	Kind   TokenKind `json:"kind"`
+	Spoken string    `json:"spoken"`
+	Start  int       `json:"start"`
+	End    int       `json:"end"`
+}
+
+// tokenRe matches, in order of preference: dotted initialisms ("a.m."),
+// numbers with optional ordinal or plural suffix ("21st", "1990s", "3.5"),
+// and words with inner apostrophes or hyphens and an optional trailing period.
+var tokenRe = regexp.MustCompile(
+	`(?:\p{L}\.){2,}` +
+		`|\d+(?:[.,]\d+)*(?:st|nd|rd|th|'?s)?` +
+		`|['’]?[\p{L}\p{M}]+(?:['’-][\p{L}\p{M}]+)*\.?`)
+
+// abbreviations maps common abbreviations, including their period, to their spoken form.
+var abbreviations = map[string]string{
+	"dr.": "doctor", "mr.": "mister", "mrs.": "missus", "ms.": "miz",
+	"mt.": "mount", "ave.": "avenue", "rd.": "road", "blvd.": "boulevard",
+	"jr.": "junior", "sr.": "senior", "prof.": "professor", "capt.": "captain",
+	"gen.": "general", "gov.": "governor", "sgt.": "sergeant", "lt.": "lieutenant",
+	"vs.": "versus", "etc.": "et cetera", "approx.": "approximately",
+	"dept.": "department", "lb.": "pound", "lbs.": "pounds", "oz.": "ounces",
+	"jan.": "january", "feb.": "february", "aug.": "august", "sept.": "september",
+	"oct.": "october", "nov.": "november", "dec.": "december",
+}
+
+// letterNames gives the spoken name of each letter for reading initialisms.
+var letterNames = map[rune]string{
+	'a': "ay", 'b': "bee", 'c': "see", 'd': "dee", 'e': "ee", 'f': "ef", 'g': "gee",
+	'h': "aitch", 'i': "eye", 'j': "jay", 'k': "kay", 'l': "el", 'm': "em", 'n': "en",
+	'o': "oh", 'p': "pee", 'q': "cue", 'r': "ar", 's': "ess", 't': "tee", 'u': "you",
+	'v': "vee", 'w': "double-you", 'x': "ex", 'y': "why", 'z': "zee",
+}
+
+// elisions are words that legitimately begin with an apostrophe.
+var elisions = map[string]bool{
+	"'tis": true, "'twas": true, "'twere": true, "'til": true, "'em": true, "'neath": true, "'round": true,
+}
+
+// contractionStems are stems whose "n't" form adds no syllable: can't, don't, won't, ain't, shan't.
+var contractionStems = map[string]bool{
+	"ca": true, "do": true, "wo": true, "ai": true, "sha": true, "are": true, "were": true,
+}
+
+// Tokenize splits a line into typed tokens. Numbers, ordinals and years are
+// spelled out, known abbreviations and initialisms are expanded, and
+// hyphenated compounds and contractions are kept whole.
+func Tokenize(line string) []Token {
+	matches := tokenRe.FindAllStringIndex(line, -1)
+	tokens := make([]Token, 0, len(matches))
+
+	for i, loc := range matches {
+		start, end := loc[0], loc[1]
+		text := line[start:end]
+		lower := strings.ToLower(strings.ReplaceAll(text, "’", "'"))
+
+		tok := Token{Text: text, Kind: TokenWord, Start: start, End: end}
+
+		switch {
+		case unicode.IsDigit(rune(text[0])):
+			tok.Kind, tok.Spoken = spellNumeric(lower)
+
+		case strings.HasSuffix(lower, "."):
+			if lower == "st." {
+				// "St. Louis" is a saint, "Main St." a street.
+				tok.Kind, tok.Spoken = TokenAbbreviation, "street"
+				if i+1 < len(matches) && startsUpper(line[matches[i+1][0]:]) {
+					tok.Spoken = "saint"
+				}
+			} else if spoken, ok := abbreviations[lower]; ok {
+				tok.Kind, tok.Spoken = TokenAbbreviation, spoken
+			} else if strings.Count(lower, ".") >= 2 && !strings.ContainsAny(lower, "-'") {
+				tok.Kind, tok.Spoken = TokenAbbreviation, spellLetters(lower)
+			} else {
+				// A sentence-final period is not part of the word.
+				tok.End--
+				tok.Text = text[:len(text)-1]
+				lower = lower[:len(lower)-1]
+				tok.Kind, tok.Spoken = classifyWord(lower)
+			}
+
+		case strings.HasPrefix(lower, "'") && !elisions[lower]:
+			// An opening quote, not an elision.
+			_, size := utf8.DecodeRuneInString(text)
+			tok.Start += size
+			tok.Text = text[size:]
+			tok.Kind, tok.Spoken = classifyWord(lower[1:])
+
+		default:
+			tok.Kind, tok.Spoken = classifyWord(lower)
+		}
+
+		tokens = append(tokens, tok)
+	}
+
+	return tokens
+}
+
+// Syllables returns the number of syllables in the token's spoken form.
+func (t Token) Syllables() int {
+	return countToken(t, CountSyllables)
+}
+
+// countToken counts a token's syllables using count for individual words.
+// Multi-word spoken forms and compounds are summed part by part, and
+// contractions follow the rules in countContraction.
+func countToken(t Token, count func(string) int) int {
+	if t.Kind == TokenContraction {
+		return countContraction(t.Spoken, count)
+	}
+
+	total := 0
+	for _, part := range strings.FieldsFunc(t.Spoken, func(r rune) bool { return r == ' ' || r == '-' }) {
+		if strings.Contains(part, "'") {
+			total += countContraction(part, count)
+		} else {
+			total += count(part)
+		}
+	}
+	return total
+}
+
+// contraction describes how a word containing an apostrophe is spoken:
+// head is counted as a word, and lead and tail are the clitics spelled
+// before and after it. A syllabic tail adds a syllable. When joined is
+// set, head is the word with its apostrophe removed.
+type contraction struct {
+	lead, head, tail string
+	syllabic         bool
+	joined           bool
+}
+
+// countContraction counts a word containing an apostrophe. A clitic adds a
+// syllable only where it is pronounced as one: "didn't" and "it'll" gain a
+// syllable, "don't" and "you'll" do not, and "'s" does after a sibilant.
+func countContraction(word string, count func(string) int) int {
+	c := splitContraction(word)
+	n := count(c.head)
+	if c.syllabic {
+		n++
+	}
+	return n
+}
+
+// splitContraction separates a word at its apostrophe into the part
+// counted as a word and its clitics.
+func splitContraction(word string) contraction {
+	if isException(word) {
+		return contraction{head: word}
+	}
+
+	idx := strings.LastIndex(word, "'")
+	stem, clitic := word[:idx], word[idx+1:]
+	if stem == "" {
+		// Elisions such as "'tis" and "'twas".
+		return contraction{lead: "'", head: clitic}
+	}
+
+	switch {
+	case clitic == "t" && strings.HasSuffix(stem, "n"):
+		base := strings.TrimSuffix(stem, "n")
+		return contraction{head: base, tail: "n'" + clitic, syllabic: !contractionStems[base]}
+	case clitic == "s":
+		return contraction{head: stem, tail: "'s", syllabic: endsSibilant(stem)}
+	case clitic == "ll" || clitic == "ve" || clitic == "d":
+		last, _ := utf8.DecodeLastRuneInString(stem)
+		return contraction{head: stem, tail: "'" + clitic, syllabic: !isVowel(last) && last != 'w'}
+	case clitic == "re" || clitic == "m":
+		return contraction{head: stem, tail: "'" + clitic}
+	default:
+		// Apostrophes inside names and words such as "o'clock".
+		return contraction{head: stem + clitic, joined: true}
+	}
+}
+
+// classifyWord determines the kind of a lowercase word token.
+func classifyWord(lower string) (TokenKind, string) {
+	switch {
+	case strings.Contains(lower, "-"):
+		return TokenCompound, lower
+	case strings.Contains(lower, "'"):
+		return TokenContraction, lower
+	default:
+		return TokenWord, lower
+	}
+}
+
+// spellNumeric spells a numeric token as a cardinal, ordinal, year or decade.
+func spellNumeric(lower string) (TokenKind, string) {
+	digits := strings.ReplaceAll(lower, ",", "")
+
+	// Decimals are read digit by digit after the point: "3.14" -> "three point one four".
+	if whole, frac, ok := strings.Cut(digits, "."); ok {
+		n, _ := strconv.ParseInt(whole, 10, 64)
+		words := []string{SpellNumber(n), "point"}
+		for _, d := range frac {
+			if unicode.IsDigit(d) {
+				words = append(words, smallNumbers[d-'0'])
+			}
+		}
+		return TokenNumber, strings.Join(words, " ")
+	}
+
+	num := strings.TrimRightFunc(digits, func(r rune) bool { return !unicode.IsDigit(r) })
+	suffix := digits[len(num):]
+	n, err := strconv.ParseInt(num, 10, 64)
+	if err != nil {
+		return TokenNumber, spellDigits(num)
+	}
+
+	switch suffix {
+	case "st", "nd", "rd", "th":
+		return TokenOrdinal, SpellOrdinal(n)
+	case "s", "'s":
+		if len(num) == 4 && isYear(n) {
+			return TokenYear, pluralizeSpoken(SpellYear(n))
+		}
+		return TokenNumber, pluralizeSpoken(SpellNumber(n))
+	}
+
+	if len(num) == 4 && !strings.Contains(lower, ",") && isYear(n) {
+		return TokenYear, SpellYear(n)
+	}
+	return TokenNumber, SpellNumber(n)
+}
+
+// isYear reports whether a four-digit number is read as a year.
+func isYear(n int64) bool {
+	return n >= 1100 && n <= 2099
+}
+
+// spellDigits reads a digit string one digit at a time.
+func spellDigits(s string) string {
+	words := make([]string, 0, len(s))
+	for _, d := range s {
+		words = append(words, smallNumbers[d-'0'])
+	}
+	return strings.Join(words, " ")
+}
+
+// spellLetters reads a dotted initialism letter by letter: "a.m." -> "ay em".
+func spellLetters(lower string) string {
+	var words []string
+	for _, r := range lower {
+		if name, ok := letterNames[r]; ok {
+			words = append(words, name)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// endsSibilant reports whether a word ends in a sibilant sound, after which
+// a possessive or plural "s" forms its own syllable.
+func endsSibilant(word string) bool {
+	for _, suffix := range []string{"s", "x", "z", "ch", "sh", "ce", "se", "ze", "ge"} {
+		if strings.HasSuffix(word, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// startsUpper reports whether s begins with an uppercase letter.
+func startsUpper(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsUpper(r)
+}
+
+// isException reports whether word has an explicit entry in the exception dictionary.
+func isException(word string) bool {
+	_, ok := exceptionSyllables[word]
+	return ok
+}
@@ -0,0 +1,120 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		line   string
+		kinds  []TokenKind
+		spoken []string
+	}{
+		{
+			line:   "3 crows",
+			kinds:  []TokenKind{TokenNumber, TokenWord},
+			spoken: []string{"three", "crows"},
+		},
+		{
+			line:   "in 1999, the 21st spring",
+			kinds:  []TokenKind{TokenWord, TokenYear, TokenWord, TokenOrdinal, TokenWord},
+			spoken: []string{"in", "nineteen ninety-nine", "the", "twenty-first", "spring"},
+		},
+		{
+			line:   "Dr. Lee on Main St.",
+			kinds:  []TokenKind{TokenAbbreviation, TokenWord, TokenWord, TokenWord, TokenAbbreviation},
+			spoken: []string{"doctor", "lee", "on", "main", "street"},
+		},
+		{
+			line:   "St. Francis at 6 a.m.",
+			kinds:  []TokenKind{TokenAbbreviation, TokenWord, TokenWord, TokenNumber, TokenAbbreviation},
+			spoken: []string{"saint", "francis", "at", "six", "ay em"},
+		},
+		{
+			line:   "ice-cold pond. don’t go",
+			kinds:  []TokenKind{TokenCompound, TokenWord, TokenContraction, TokenWord},
+			spoken: []string{"ice-cold", "pond", "don't", "go"},
+		},
+		{
+			line:   "'tis the 1990s",
+			kinds:  []TokenKind{TokenContraction, TokenWord, TokenYear},
+			spoken: []string{"'tis", "the", "nineteen nineties"},
+		},
+		{
+			line:   "'quiet' pond",
+			kinds:  []TokenKind{TokenWord, TokenWord},
+			spoken: []string{"quiet", "pond"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			tokens := Tokenize(tt.line)
+			if len(tokens) != len(tt.kinds) {
+				t.Fatalf("Tokenize(%q) returned %d tokens, want %d: %+v", tt.line, len(tokens), len(tt.kinds), tokens)
+			}
+			for i, tok := range tokens {
+				if tok.Kind != tt.kinds[i] {
+					t.Errorf("Token %d (%q) kind = %s, want %s", i, tok.Text, tok.Kind, tt.kinds[i])
+				}
+				if tok.Spoken != tt.spoken[i] {
+					t.Errorf("Token %d (%q) spoken = %q, want %q", i, tok.Text, tok.Spoken, tt.spoken[i])
+				}
+				if tt.line[tok.Start:tok.End] != tok.Text {
+					t.Errorf("Token %d offsets [%d:%d] cover %q, want %q", i, tok.Start, tok.End, tt.line[tok.Start:tok.End], tok.Text)
+				}
+			}
+		})
+	}
+}
+
+func TestToken_Syllables(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected int
+	}{
+		// Numbers, ordinals and years by their spoken form
+		{"3", 1},
+		{"7", 2},
+		{"21st", 3},
+		{"1999", 5},  // nine-teen nine-ty nine
+		{"1905", 4},  // nine-teen oh five
+		{"1990s", 4}, // nine-teen nine-ties
+		{"100", 3},
+
+		// Abbreviations and initialisms
+		{"Dr.", 2},
+		{"etc.", 4},
+		{"a.m.", 2},
+
+		// Compounds count their parts
+		{"ice-cold", 2},
+		{"mother-in-law", 4},
+
+		// Contractions
+		{"don't", 1},
+		{"can't", 1},
+		{"didn't", 2},
+		{"couldn't", 2},
+		{"it'll", 2},
+		{"you'll", 1},
+		{"we're", 1},
+		{"could've", 2},
+		{"horse's", 2},
+		{"cat's", 1},
+		{"'tis", 1},
+		{"o'clock", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			tokens := Tokenize(tt.text)
+			if len(tokens) != 1 {
+				t.Fatalf("Tokenize(%q) returned %d tokens, want 1", tt.text, len(tokens))
+			}
+			if result := tokens[0].Syllables(); result != tt.expected {
+				t.Errorf("Syllables(%q) = %d, want %d", tt.text, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Package analyzer provides a word finder over the embedded lexicons.
+package analyzer
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// WordQuery selects words from the lexicon. Zero fields match any word.
+type WordQuery struct {
+	Syllables  int    // syllable count
+	Stress     string // stress pattern in scansion marks, e.g. "/x"
+	RhymesWith string // a word whose rhyme, from its stressed vowel, must match
+	Season     string // kigo season, see Seasons
+	StartsWith string // first sound in lowercase ARPAbet, e.g. "s" or "sh"
+	POS        string // part-of-speech tag, e.g. "NOUN"
+	Sense      string // sense the word engages, e.g. "sound"
+	SynonymOf  string // a word from the thesaurus
+	Limit      int    // most words returned; 0 returns all
+}
+
+// WordEntry describes a word found in the lexicon.
+type WordEntry struct {
+	Word      string   `json:"word"`
+	Syllables int      `json:"syllables"`
+	Stress    string   `json:"stress"`
+	Rhyme     string   `json:"rhyme"`
+	Tags      []string `json:"tags,omitempty"`
+	Past      bool     `json:"past,omitempty"`   // a past-tense verb form
+	Plural    bool     `json:"plural,omitempty"` // a plural noun form
+	Senses    []string `json:"senses,omitempty"`
+	Season    string   `json:"season,omitempty"`
+}
+
+// posTags lists the tags accepted in WordQuery.POS.
+var posTags = []string{
+	TagNoun, TagVerb, TagAuxiliary, TagAdjective, TagAdverb, TagDeterminer,
+	TagAdposition, TagPronoun, TagConjunction, TagParticle, TagNumeral, TagInterjection,
+}
+
+// FindWords returns the lexicon words matching q in alphabetical order,
+// counted with the analyzer's dialect and global overrides. The lexicon is
+// every single word in the embedded word lists and season words.
+func (a *Analyzer) FindWords(q WordQuery) ([]WordEntry, error) {
+	if q.Season != "" {
+		season, err := ParseSeason(q.Season)
+		if err != nil {
+			return nil, err
+		}
+		q.Season = season
+	}
+	if q.POS != "" {
+		q.POS = strings.ToUpper(q.POS)
+		if !slices.Contains(posTags, q.POS) {
+			return nil, fmt.Errorf("unknown part of speech %q", q.POS)
+		}
+	}
+	if q.Sense != "" {
+		q.Sense = strings.ToLower(q.Sense)
+		if !slices.Contains(senseOrder, q.Sense) {
+			return nil, fmt.Errorf("unknown sense %q (want %s)", q.Sense, strings.Join(senseOrder, ", "))
+		}
+	}
+	if strings.Trim(q.Stress, string([]byte{StressedMark, UnstressedMark})) != "" {
+		return nil, fmt.Errorf("invalid stress pattern %q (use %c and %c)", q.Stress, StressedMark, UnstressedMark)
+	}
+	rhyme := ""
+	if q.RhymesWith != "" {
+		rhyme = wordRhyme(strings.ToLower(q.RhymesWith))
+	}
+
+	candidates := lexiconWords()
+	if q.SynonymOf != "" {
+		candidates = synonymsOf(strings.ToLower(q.SynonymOf))
+	}
+
+	lookup := a.lookup("")
+	var result []WordEntry
+	for _, word := range candidates {
+		e := a.describeWord(word, lookup)
+		switch {
+		case q.Syllables > 0 && e.Syllables != q.Syllables,
+			q.Stress != "" && e.Stress != q.Stress,
+			q.RhymesWith != "" && (e.Rhyme != rhyme || word == strings.ToLower(q.RhymesWith)),
+			q.Season != "" && e.Season != q.Season,
+			q.StartsWith != "" && !strings.HasPrefix(phonemes(wordPhones(word))+" ", strings.ToLower(q.StartsWith)+" "),
+			q.POS != "" && !slices.Contains(e.Tags, q.POS),
+			q.Sense != "" && !slices.Contains(e.Senses, q.Sense):
+			continue
+		}
+		result = append(result, e)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Word < result[j].Word })
+	if q.Limit > 0 && len(result) > q.Limit {
+		result = result[:q.Limit]
+	}
+	return result, nil
+}
+
+// describeWord counts, stresses, tags and rates a lexicon word. A word whose
+// part of speech would only be guessed, or read from its ending alone, has
+// no tags.
+func (a *Analyzer) describeWord(word string, lookup lookupFunc) WordEntry {
+	tok := Tokenize(word)[0]
+	n, _, _, _ := explainCount(tok, lookup, a.dialect)
+	e := WordEntry{
+		Word:      word,
+		Syllables: n,
+		Stress:    stressMarks(tokenStress(tok, n)),
+		Rhyme:     wordRhyme(word),
+		Season:    SeasonOf(word),
+	}
+	if pos := candidateTags(tok); !pos.guess && !pos.suffix {
+		e.Tags, e.Past, e.Plural = pos.tags, pos.past, pos.plural
+	}
+	if im, ok := wordImagery(word); ok {
+		e.Senses = im.senses
+	}
+	return e
+}
+
+// wordRhyme returns the phonemes of word from its stressed vowel to the end,
+// or from its first vowel when no vowel is stressed.
+func wordRhyme(word string) string {
+	phones := wordPhones(word)
+	i := stressedVowel(phones)
+	if i < 0 {
+		i = slices.IndexFunc(phones, isVowelPhone)
+	}
+	if i < 0 {
+		return ""
+	}
+	return phonemes(phones[i:])
+}
+
+// lexiconWords returns the distinct single words of the embedded
+// pronunciation, stress, part-of-speech, concreteness and synonym lists and
+// of the season words.
+func lexiconWords() []string {
+	seen := make(map[string]bool)
+	var words []string
+	add := func(w string) {
+		if !seen[w] && w != "" && !strings.ContainsFunc(w, func(r rune) bool { return !unicode.IsLetter(r) }) {
+			seen[w] = true
+			words = append(words, w)
+		}
+	}
+
+	for w := range phonesLexicon {
+		add(w)
+	}
+	for w := range stressLexicon {
+		add(w)
+	}
+	for w := range posLexicon {
+		add(w)
+	}
+	for w := range concretenessLexicon {
+		add(w)
+	}
+	for w := range thesaurus {
+		add(w)
+	}
+	for _, w := range kigo {
+		add(w)
+	}
+	return words
+}
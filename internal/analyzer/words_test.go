@@ -0,0 +1,91 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    WordQuery
+		expected []string
+	}{
+		{"rhyme, count and POS", WordQuery{RhymesWith: "moon", Syllables: 1, POS: "noun"}, []string{"dune", "loon", "noon", "spoon"}},
+		{"synonym", WordQuery{SynonymOf: "quiet", Syllables: 2}, []string{"silent"}},
+		{"season", WordQuery{Season: "winter", Syllables: 2, POS: TagNoun}, []string{"blizzard", "fireplace", "solstice", "winter"}},
+		{"fall is autumn", WordQuery{Season: "fall", Syllables: 3}, []string{"migration"}},
+		{"stress", WordQuery{RhymesWith: "moon", Stress: "x/"}, []string{"balloon", "cocoon", "lagoon", "monsoon", "typhoon"}},
+		{"starting sound", WordQuery{StartsWith: "sh", Syllables: 1, POS: TagNoun}, []string{"shine"}},
+		{"sense", WordQuery{Sense: "sound", Syllables: 3, POS: TagNoun}, []string{"cicada", "mosquito"}},
+		{"limit", WordQuery{RhymesWith: "night", Limit: 2}, []string{"bright", "delight"}},
+		{"no match", WordQuery{SynonymOf: "frog"}, nil},
+	}
+
+	a := New(0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := a.FindWords(tt.query)
+			if err != nil {
+				t.Fatalf("FindWords() error = %v", err)
+			}
+			var words []string
+			for _, e := range entries {
+				words = append(words, e.Word)
+			}
+			if !reflect.DeepEqual(words, tt.expected) {
+				t.Errorf("FindWords(%+v) = %q, want %q", tt.query, words, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindWords_Entry(t *testing.T) {
+	entries, err := New(0).FindWords(WordQuery{RhymesWith: "june", Season: SeasonAll, Syllables: 1})
+	if err != nil {
+		t.Fatalf("FindWords() error = %v", err)
+	}
+	expected := []WordEntry{{Word: "moon", Syllables: 1, Stress: "/", Rhyme: "uw n", Tags: []string{TagNoun}, Senses: []string{SenseSight}, Season: SeasonAll}}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("FindWords() = %+v, want %+v", entries, expected)
+	}
+
+	plurals := map[string]bool{"stars": true, "leaves": true, "star": false, "grass": false}
+	entries, err = New(0).FindWords(WordQuery{POS: TagNoun})
+	if err != nil {
+		t.Fatalf("FindWords() error = %v", err)
+	}
+	for _, e := range entries {
+		if plural, ok := plurals[e.Word]; ok && e.Plural != plural {
+			t.Errorf("FindWords() %q has Plural = %v, want %v", e.Word, e.Plural, plural)
+		}
+	}
+}
+
+func TestFindWords_Errors(t *testing.T) {
+	for _, q := range []WordQuery{{Season: "monsoon"}, {POS: "gerund"}, {Stress: "-/-"}, {Sense: "hearing"}} {
+		if _, err := New(0).FindWords(q); err == nil {
+			t.Errorf("FindWords(%+v) error = nil, want an error", q)
+		}
+	}
+}
+
+func TestWordRhyme(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected string
+	}{
+		{"moon", "uw n"},
+		{"balloon", "uw n"},
+		{"night", "ay t"},
+		{"the", "ah"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if result := wordRhyme(tt.word); result != tt.expected {
+				t.Errorf("wordRhyme(%q) = %q, want %q", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,342 @@
+// Package dedupe provides near-duplicate detection across a haiku corpus.
+package dedupe
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/thornzero/haikugo/internal/analyzer"
+)
+
+const (
+	// DefaultThreshold is the edit similarity at which two poems are
+	// near-duplicates.
+	DefaultThreshold = 0.8
+	// DefaultShingleSize is the number of words in a shingle.
+	DefaultShingleSize = 2
+	// MaxShingleSize is the largest shingle accepted.
+	MaxShingleSize = 5
+)
+
+// Locality-sensitive hashing parameters: a pair of poems becomes a
+// candidate when all rows of any one band of their MinHash signatures
+// agree, which happens with probability 0.99 at a shingle Jaccard
+// similarity of 0.3 and 0.45 at 0.1. Adding a letter to two words of a
+// ten-word poem leaves it 0.9 similar but can drop the Jaccard similarity
+// of its two-word shingles below 0.4, so bands are kept short.
+const (
+	numBands  = 60
+	bandRows  = 2
+	numHashes = numBands * bandRows
+)
+
+// hashSeeds are the seeds of the MinHash functions.
+var hashSeeds = func() []uint64 {
+	seeds := make([]uint64, numHashes)
+	x := uint64(0x5eed)
+	for i := range seeds {
+		x = mix(x)
+		seeds[i] = x
+	}
+	return seeds
+}()
+
+// Options configures an Index.
+type Options struct {
+	Threshold   float64 // edit similarity of near-duplicates; 0 means DefaultThreshold
+	ShingleSize int     // words per shingle; 0 means DefaultShingleSize
+}
+
+// Entry is a poem in the index.
+type Entry struct {
+	ID     string   `json:"id"`
+	Author string   `json:"author,omitempty"`
+	Lines  []string `json:"lines"`
+}
+
+// Pair is a confirmed pair of near-duplicates. Jaccard is the overlap of
+// their word shingles and Similarity one minus their edit distance relative
+// to the longer poem, both from 0 to 1.
+type Pair struct {
+	A          string  `json:"a"`
+	B          string  `json:"b"`
+	Jaccard    float64 `json:"jaccard"`
+	Similarity float64 `json:"similarity"`
+}
+
+// Cluster is a group of poems linked by near-duplicate pairs, in the order
+// they were added. Similarity is the lowest similarity among its pairs.
+type Cluster struct {
+	Entries    []Entry `json:"entries"`
+	Pairs      []Pair  `json:"pairs"`
+	Similarity float64 `json:"similarity"`
+}
+
+// Match is an indexed poem found near-duplicate to a query.
+type Match struct {
+	Entry
+	Jaccard    float64 `json:"jaccard"`
+	Similarity float64 `json:"similarity"`
+}
+
+// doc is an indexed poem with its normalized text, shingles and signature.
+type doc struct {
+	entry     Entry
+	text      []rune
+	shingles  map[string]struct{}
+	signature []uint64
+}
+
+// band identifies an LSH bucket.
+type band struct {
+	index int
+	hash  uint64
+}
+
+// Index finds near-duplicate poems. Candidates are found by MinHash and
+// locality-sensitive hashing over word shingles and confirmed by edit
+// distance, so that a large corpus is not compared pair by pair.
+type Index struct {
+	threshold   float64
+	shingleSize int
+	docs        []doc
+	buckets     map[band][]int
+}
+
+// New creates an empty Index.
+func New(opts Options) (*Index, error) {
+	ix := &Index{threshold: opts.Threshold, shingleSize: opts.ShingleSize, buckets: make(map[band][]int)}
+	if ix.threshold == 0 {
+		ix.threshold = DefaultThreshold
+	}
+	if ix.shingleSize == 0 {
+		ix.shingleSize = DefaultShingleSize
+	}
+	if math.IsNaN(ix.threshold) || ix.threshold < 0 || ix.threshold > 1 {
+		return nil, fmt.Errorf("similarity threshold must be between 0 and 1, got %g", opts.Threshold)
+	}
+	if ix.shingleSize < 1 || ix.shingleSize > MaxShingleSize {
+		return nil, fmt.Errorf("shingle size must be between 1 and %d, got %d", MaxShingleSize, opts.ShingleSize)
+	}
+	return ix, nil
+}
+
+// Add indexes a poem. Poems without words are never near-duplicates.
+func (ix *Index) Add(e Entry) {
+	d := ix.newDoc(e)
+	i := len(ix.docs)
+	ix.docs = append(ix.docs, d)
+	for _, b := range bands(d.signature) {
+		ix.buckets[b] = append(ix.buckets[b], i)
+	}
+}
+
+// Len returns the number of indexed poems.
+func (ix *Index) Len() int {
+	return len(ix.docs)
+}
+
+// Query returns the indexed poems that are near-duplicates of lines, most
+// similar first.
+func (ix *Index) Query(lines []string) []Match {
+	q := ix.newDoc(Entry{Lines: lines})
+	var result []Match
+	for _, i := range ix.candidates(q, -1) {
+		if jaccard, sim, ok := ix.confirm(q, ix.docs[i]); ok {
+			result = append(result, Match{Entry: ix.docs[i].entry, Jaccard: jaccard, Similarity: sim})
+		}
+	}
+	slices.SortStableFunc(result, func(a, b Match) int {
+		switch {
+		case a.Similarity > b.Similarity:
+			return -1
+		case a.Similarity < b.Similarity:
+			return 1
+		}
+		return 0
+	})
+	return result
+}
+
+// Clusters groups the indexed poems linked by near-duplicate pairs,
+// ordered by their first poem. Poems without a near-duplicate are left out.
+func (ix *Index) Clusters() []Cluster {
+	parent := make([]int, len(ix.docs))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	type link struct {
+		a, b int
+		pair Pair
+	}
+	var links []link
+	for i, d := range ix.docs {
+		for _, j := range ix.candidates(d, i) {
+			jaccard, sim, ok := ix.confirm(d, ix.docs[j])
+			if !ok {
+				continue
+			}
+			links = append(links, link{i, j, Pair{A: d.entry.ID, B: ix.docs[j].entry.ID, Jaccard: jaccard, Similarity: sim}})
+			if ri, rj := find(i), find(j); ri != rj {
+				parent[max(ri, rj)] = min(ri, rj)
+			}
+		}
+	}
+
+	byRoot := make(map[int]*Cluster)
+	var roots []int
+	for i, d := range ix.docs {
+		r := find(i)
+		if byRoot[r] == nil {
+			byRoot[r] = &Cluster{Similarity: 1}
+			roots = append(roots, r)
+		}
+		byRoot[r].Entries = append(byRoot[r].Entries, d.entry)
+	}
+	for _, l := range links {
+		c := byRoot[find(l.a)]
+		c.Pairs = append(c.Pairs, l.pair)
+		c.Similarity = min(c.Similarity, l.pair.Similarity)
+	}
+
+	var result []Cluster
+	for _, r := range roots {
+		if c := byRoot[r]; len(c.Entries) > 1 {
+			result = append(result, *c)
+		}
+	}
+	return result
+}
+
+// newDoc normalizes a poem and computes its shingles and signature.
+func (ix *Index) newDoc(e Entry) doc {
+	words := normalizeWords(e.Lines)
+	d := doc{entry: e, text: []rune(strings.Join(words, " ")), shingles: make(map[string]struct{})}
+	if len(words) == 0 {
+		return d
+	}
+	for k := 0; k+ix.shingleSize <= max(len(words), ix.shingleSize); k++ {
+		d.shingles[strings.Join(words[k:min(k+ix.shingleSize, len(words))], " ")] = struct{}{}
+	}
+
+	d.signature = make([]uint64, numHashes)
+	for i := range d.signature {
+		d.signature[i] = math.MaxUint64
+	}
+	for s := range d.shingles {
+		h := fnv.New64a()
+		h.Write([]byte(s))
+		x := h.Sum64()
+		for i, seed := range hashSeeds {
+			d.signature[i] = min(d.signature[i], mix(x^seed))
+		}
+	}
+	return d
+}
+
+// normalizeWords returns the lower-cased words of a poem, with curly
+// apostrophes made straight.
+func normalizeWords(lines []string) []string {
+	words := analyzer.ExtractWords(strings.Join(lines, " "))
+	for i, w := range words {
+		words[i] = strings.ReplaceAll(strings.ToLower(w), "’", "'")
+	}
+	return words
+}
+
+// bands splits a signature into LSH buckets.
+func bands(signature []uint64) []band {
+	if signature == nil {
+		return nil
+	}
+	result := make([]band, numBands)
+	for b := range result {
+		h := uint64(b)
+		for _, v := range signature[b*bandRows : (b+1)*bandRows] {
+			h = mix(h ^ v)
+		}
+		result[b] = band{index: b, hash: h}
+	}
+	return result
+}
+
+// candidates returns the indexed poems sharing a bucket with d, after the
+// poem at index after, in index order.
+func (ix *Index) candidates(d doc, after int) []int {
+	var result []int
+	for _, b := range bands(d.signature) {
+		for _, j := range ix.buckets[b] {
+			if j > after {
+				result = append(result, j)
+			}
+		}
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// confirm computes the shingle overlap and edit similarity of two poems and
+// reports whether they are near-duplicates.
+func (ix *Index) confirm(a, b doc) (jaccard, similarity float64, ok bool) {
+	shared := 0
+	for s := range a.shingles {
+		if _, found := b.shingles[s]; found {
+			shared++
+		}
+	}
+	if union := len(a.shingles) + len(b.shingles) - shared; union > 0 {
+		jaccard = float64(shared) / float64(union)
+	}
+	// The edit distance is at least the difference in length.
+	longest := max(len(a.text), len(b.text))
+	if longest == 0 || 1-float64(longest-min(len(a.text), len(b.text)))/float64(longest) < ix.threshold {
+		return round(jaccard), 0, false
+	}
+	similarity = 1 - float64(levenshtein(a.text, b.text))/float64(longest)
+	return round(jaccard), round(similarity), similarity >= ix.threshold
+}
+
+// levenshtein returns the edit distance between two rune slices.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// mix is the SplitMix64 finalizer, used to derive independent hashes.
+func mix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// round rounds a score to three decimal places.
+func round(x float64) float64 {
+	return math.Round(x*1000) / 1000
+}
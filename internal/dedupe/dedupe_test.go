@@ -0,0 +1,213 @@
+package dedupe
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// entries mixes two near-duplicate groups with unrelated poems.
+var entries = []Entry{
+	{ID: "1", Lines: []string{"an old silent pond", "a frog jumps into the pond", "splash silence again"}},
+	{ID: "2", Lines: []string{"the autumn moon shines", "over the pond a cold wind", "the frog sleeps alone"}},
+	{ID: "3", Lines: []string{"An old silent pond", "a frog leaps into the pond", "splash! silence again"}},
+	{ID: "4", Lines: []string{"a cold winter night", "the snow falls into the pond", "silence in the pines"}},
+	{ID: "5", Lines: []string{"an old silent pond—", "a frog jumps in the pond", "splash, silence again"}},
+	{ID: "6", Lines: []string{"the old temple bell", "rings over the quiet hills", "a crow in the rain"}},
+	{ID: "7", Lines: []string{"The old temple bell", "rings over the quiet hills—", "a crow in the rain"}},
+}
+
+// words is the vocabulary of randomly generated poems.
+var words = strings.Fields("pond frog moon crow bell snow rain wind pine hill river stone leaf mist dew " +
+	"cicada heron lantern temple willow petal ember dusk dawn frost thunder meadow harbor sparrow ash " +
+	"falls drifts sleeps shines rings calls melts hums waits sways fades glows rests turns wakes")
+
+func newIndex(t *testing.T, opts Options) *Index {
+	t.Helper()
+	ix, err := New(opts)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	for _, e := range entries {
+		ix.Add(e)
+	}
+	return ix
+}
+
+func TestIndex_Clusters(t *testing.T) {
+	ix := newIndex(t, Options{})
+	if ix.Len() != len(entries) {
+		t.Fatalf("Len() = %d, want %d", ix.Len(), len(entries))
+	}
+
+	clusters := ix.Clusters()
+	var got [][]string
+	for _, c := range clusters {
+		var ids []string
+		for _, e := range c.Entries {
+			ids = append(ids, e.ID)
+		}
+		got = append(got, ids)
+		if len(c.Pairs) < len(c.Entries)-1 {
+			t.Errorf("Cluster %v has %d pairs, want at least %d", ids, len(c.Pairs), len(c.Entries)-1)
+		}
+		for _, p := range c.Pairs {
+			if p.Similarity < DefaultThreshold || p.Similarity > 1 || p.Jaccard <= 0 || p.Jaccard > 1 {
+				t.Errorf("Pair %+v has scores out of range", p)
+			}
+			if p.Similarity < c.Similarity {
+				t.Errorf("Cluster similarity %g is above its pair %+v", c.Similarity, p)
+			}
+		}
+	}
+	want := [][]string{{"1", "3", "5"}, {"6", "7"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Clusters() = %v, want %v", got, want)
+	}
+	if clusters[1].Similarity != 1 || clusters[1].Pairs[0].Jaccard != 1 {
+		t.Errorf("Poems differing in case and punctuation scored %+v, want identical", clusters[1].Pairs[0])
+	}
+}
+
+func TestIndex_Clusters_Threshold(t *testing.T) {
+	tests := []struct {
+		threshold float64
+		want      int
+	}{
+		{0.99, 1}, // only the poems differing in case and punctuation
+		{0.8, 2},
+	}
+	for _, tt := range tests {
+		ix := newIndex(t, Options{Threshold: tt.threshold})
+		if got := len(ix.Clusters()); got != tt.want {
+			t.Errorf("Threshold %g gave %d clusters, want %d", tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestIndex_Query(t *testing.T) {
+	ix := newIndex(t, Options{})
+
+	matches := ix.Query([]string{"an old silent pond", "a frog jumps into the pond", "splash silence again"})
+	var ids []string
+	for _, m := range matches {
+		ids = append(ids, m.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"1", "5", "3"}) && !reflect.DeepEqual(ids, []string{"1", "3", "5"}) {
+		t.Errorf("Query() = %v, want 1 then 3 and 5", ids)
+	}
+	if len(matches) > 0 && matches[0].Similarity != 1 {
+		t.Errorf("Exact copy similarity = %g, want 1", matches[0].Similarity)
+	}
+	for i := 1; i < len(matches); i++ {
+		if matches[i].Similarity > matches[i-1].Similarity {
+			t.Errorf("Query() not sorted by similarity: %+v", matches)
+		}
+	}
+
+	if got := ix.Query([]string{"cicadas shrilling", "in the heat of the noon sun", "a dog asleep"}); len(got) != 0 {
+		t.Errorf("Query() of an unrelated poem = %+v, want none", got)
+	}
+	if got := ix.Query([]string{"", "—", "!"}); len(got) != 0 {
+		t.Errorf("Query() of a poem without words = %+v, want none", got)
+	}
+}
+
+func TestIndex_Scale(t *testing.T) {
+	ix, err := New(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := rand.New(rand.NewPCG(1, 2))
+	line := func(n int) string {
+		var line []string
+		for range n {
+			line = append(line, words[rng.IntN(len(words))])
+		}
+		return strings.Join(line, " ")
+	}
+	var original []string
+	for i := range 2000 {
+		lines := []string{line(3), line(4), line(3)}
+		if i == 15 {
+			original = lines
+		}
+		ix.Add(Entry{ID: fmt.Sprint(i), Lines: lines})
+	}
+	ix.Add(Entry{ID: "copy", Lines: []string{original[0], original[1] + " again", original[2]}})
+
+	clusters := ix.Clusters()
+	if len(clusters) != 1 || len(clusters[0].Entries) != 2 || clusters[0].Entries[0].ID != "15" || clusters[0].Entries[1].ID != "copy" {
+		t.Errorf("Clusters() = %+v, want poem 15 with its copy", clusters)
+	}
+}
+
+func TestIndex_Recall(t *testing.T) {
+	ix, err := New(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := rand.New(rand.NewPCG(3, 4))
+	const n = 2000
+	for i := range n {
+		poem := make([]string, 10)
+		for j := range poem {
+			poem[j] = words[rng.IntN(len(words))]
+		}
+		ix.Add(Entry{ID: fmt.Sprint(i), Lines: []string{strings.Join(poem[:3], " "), strings.Join(poem[3:7], " "), strings.Join(poem[7:], " ")}})
+
+		// A plural on two words keeps the poem about 0.9 similar.
+		j, k := rng.IntN(10), rng.IntN(9)
+		if k >= j {
+			k++
+		}
+		poem[j] += "s"
+		poem[k] += "s"
+		ix.Add(Entry{ID: fmt.Sprint(i, "b"), Lines: []string{strings.Join(poem[:3], " "), strings.Join(poem[3:7], " "), strings.Join(poem[7:], " ")}})
+	}
+
+	found := make(map[string]bool)
+	for _, c := range ix.Clusters() {
+		for _, p := range c.Pairs {
+			found[p.A+" "+p.B] = true
+		}
+	}
+	missed := 0
+	for i := range n {
+		if !found[fmt.Sprint(i, " ", i, "b")] {
+			missed++
+		}
+	}
+	if missed > 0 {
+		t.Errorf("Clusters() missed %d of %d poems with two pluralized words", missed, n)
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	for _, opts := range []Options{{Threshold: -0.1}, {Threshold: 1.5}, {Threshold: math.NaN()}, {ShingleSize: -1}, {ShingleSize: MaxShingleSize + 1}} {
+		if _, err := New(opts); err == nil {
+			t.Errorf("New(%+v) should fail", opts)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"pond", "", 4},
+		{"pond", "ponds", 1},
+		{"kitten", "sitting", 3},
+		{"café", "cafe", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
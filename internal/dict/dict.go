@@ -0,0 +1,211 @@
+// Package dict provides user syllable override dictionaries.
+package dict
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/thornzero/haikugo/internal/project"
+)
+
+// DefaultPath is the project-relative location of the override dictionary.
+const DefaultPath = project.Dir + "/syllables.tsv"
+
+// Entry is a single syllable override. An empty Author applies to everyone.
+type Entry struct {
+	Word      string `json:"word"`
+	Syllables int    `json:"syllables"`
+	Author    string `json:"author,omitempty"`
+}
+
+// Dictionary holds syllable overrides, optionally scoped per author.
+// Words and authors are matched case-insensitively; authors keep the
+// spelling they were first given.
+type Dictionary struct {
+	entries map[string]map[string]int // author -> word -> syllables
+	names   map[string]string         // author -> name as first given
+}
+
+// New creates an empty Dictionary.
+func New() *Dictionary {
+	return &Dictionary{entries: make(map[string]map[string]int), names: make(map[string]string)}
+}
+
+// Set records that word has n syllables for author, or for everyone when author is empty.
+func (d *Dictionary) Set(word string, n int, author string) error {
+	name := strings.TrimSpace(author)
+	word, author = normalizeKey(word), normalizeKey(author)
+	if word == "" {
+		return errors.New("empty word")
+	}
+	if strings.ContainsAny(word, " \t") {
+		return fmt.Errorf("word %q contains whitespace", word)
+	}
+	if n < 1 {
+		return fmt.Errorf("word %q: syllable count must be positive, got %d", word, n)
+	}
+
+	if d.entries[author] == nil {
+		d.entries[author] = make(map[string]int)
+		d.names[author] = name
+	}
+	d.entries[author][word] = n
+	return nil
+}
+
+// Remove deletes the override of word for author and reports whether it existed.
+func (d *Dictionary) Remove(word, author string) bool {
+	word, author = normalizeKey(word), normalizeKey(author)
+	if _, ok := d.entries[author][word]; !ok {
+		return false
+	}
+	delete(d.entries[author], word)
+	if len(d.entries[author]) == 0 {
+		delete(d.entries, author)
+		delete(d.names, author)
+	}
+	return true
+}
+
+// Lookup returns the override of word for author. An author's own entry
+// takes precedence over one that applies to everyone.
+func (d *Dictionary) Lookup(word, author string) (int, bool) {
+	if d == nil {
+		return 0, false
+	}
+	word, author = normalizeKey(word), normalizeKey(author)
+	if author != "" {
+		if n, ok := d.entries[author][word]; ok {
+			return n, true
+		}
+	}
+	n, ok := d.entries[""][word]
+	return n, ok
+}
+
+// Entries returns all overrides sorted by author and word, with authors
+// spelled as first given.
+func (d *Dictionary) Entries() []Entry {
+	var entries []Entry
+	for author, words := range d.entries {
+		for word, n := range words {
+			entries = append(entries, Entry{Word: word, Syllables: n, Author: d.names[author]})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if a, b := normalizeKey(entries[i].Author), normalizeKey(entries[j].Author); a != b {
+			return a < b
+		}
+		return entries[i].Word < entries[j].Word
+	})
+	return entries
+}
+
+// Len returns the number of overrides.
+func (d *Dictionary) Len() int {
+	n := 0
+	for _, words := range d.entries {
+		n += len(words)
+	}
+	return n
+}
+
+// Read parses overrides in TSV form, one "word<TAB>count[<TAB>author]" per line.
+// Blank lines and lines starting with '#' are ignored.
+func Read(r io.Reader) (*Dictionary, error) {
+	d := New()
+	sc := bufio.NewScanner(r)
+	lineNo := 0
+
+	for sc.Scan() {
+		lineNo++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("line %d: want word<TAB>count[<TAB>author], got %q", lineNo, line)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(fields[1]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid syllable count %q", lineNo, fields[1])
+		}
+		author := ""
+		if len(fields) == 3 {
+			author = fields[2]
+		}
+		if err := d.Set(fields[0], n, author); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Write writes the overrides in the TSV form accepted by Read.
+func (d *Dictionary) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "# word\tsyllables\tauthor")
+	for _, e := range d.Entries() {
+		if e.Author != "" {
+			fmt.Fprintf(bw, "%s\t%d\t%s\n", e.Word, e.Syllables, e.Author)
+		} else {
+			fmt.Fprintf(bw, "%s\t%d\n", e.Word, e.Syllables)
+		}
+	}
+	return bw.Flush()
+}
+
+// Load reads a dictionary from the TSV file at path.
+func Load(path string) (*Dictionary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	d, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return d, nil
+}
+
+// Save writes the dictionary to path, creating its directory if needed.
+func (d *Dictionary) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := d.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Find looks for DefaultPath in dir and each of its parents and returns
+// the first match.
+func Find(dir string) (string, bool) {
+	return project.Find(dir, DefaultPath)
+}
+
+// normalizeKey folds a word or author name for matching.
+func normalizeKey(s string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), "’", "'"))
+}
@@ -0,0 +1,155 @@
+package dict
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDictionary_Lookup(t *testing.T) {
+	d := New()
+	d.Set("Ouachita", 3, "")
+	d.Set("hour", 2, "Basho")
+	d.Set("fire", 2, "")
+	d.Set("fire", 1, "basho")
+
+	tests := []struct {
+		word, author string
+		want         int
+		ok           bool
+	}{
+		{"ouachita", "", 3, true},
+		{"OUACHITA", "Issa", 3, true},
+		{"hour", "", 0, false},
+		{"hour", "basho", 2, true},
+		{"fire", "", 2, true},
+		{"fire", "Basho", 1, true},
+		{"fire", "Issa", 2, true},
+		{"pond", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		n, ok := d.Lookup(tt.word, tt.author)
+		if n != tt.want || ok != tt.ok {
+			t.Errorf("Lookup(%q, %q) = %d, %v, want %d, %v", tt.word, tt.author, n, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDictionary_SetErrors(t *testing.T) {
+	d := New()
+	for _, tt := range []struct {
+		word string
+		n    int
+	}{
+		{"", 1},
+		{"two words", 2},
+		{"pond", 0},
+	} {
+		if err := d.Set(tt.word, tt.n, ""); err == nil {
+			t.Errorf("Set(%q, %d) succeeded, want error", tt.word, tt.n)
+		}
+	}
+}
+
+func TestDictionary_Remove(t *testing.T) {
+	d := New()
+	d.Set("hour", 2, "basho")
+
+	if d.Remove("hour", "") {
+		t.Error("Remove without author removed an author-scoped entry")
+	}
+	if !d.Remove("Hour", "Basho") {
+		t.Error("Remove(Hour, Basho) = false, want true")
+	}
+	if d.Len() != 0 {
+		t.Errorf("Len() = %d after remove, want 0", d.Len())
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		entries int
+		wantErr bool
+	}{
+		{"entries", "# comment\nouachita\t3\n\nhour\t2\tbasho\n", 2, false},
+		{"missing count", "ouachita\n", 0, true},
+		{"bad count", "ouachita\tthree\n", 0, true},
+		{"too many fields", "a\t1\tb\tc\n", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := Read(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && d.Len() != tt.entries {
+				t.Errorf("Len() = %d, want %d", d.Len(), tt.entries)
+			}
+		})
+	}
+}
+
+func TestDictionary_WriteRead(t *testing.T) {
+	d := New()
+	d.Set("ouachita", 3, "")
+	d.Set("hour", 2, "Basho")
+	d.Set("fire", 2, "basho")
+
+	var buf bytes.Buffer
+	if err := d.Write(&buf); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := []Entry{{Word: "ouachita", Syllables: 3}, {Word: "fire", Syllables: 2, Author: "Basho"}, {Word: "hour", Syllables: 2, Author: "Basho"}}
+	entries := got.Entries()
+	if len(entries) != len(want) {
+		t.Fatalf("Entries() = %v, want %v", entries, want)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("Entries()[%d] = %v, want %v", i, entries[i], want[i])
+		}
+	}
+}
+
+func TestSaveLoadFind(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, DefaultPath)
+	nested := filepath.Join(root, "poems", "spring")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := Find(nested); ok {
+		t.Fatal("Find() found a dictionary before one was saved")
+	}
+
+	d := New()
+	d.Set("ouachita", 3, "")
+	if err := d.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	found, ok := Find(nested)
+	if !ok || found != path {
+		t.Fatalf("Find() = %q, %v, want %q, true", found, ok, path)
+	}
+
+	loaded, err := Load(found)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if n, ok := loaded.Lookup("ouachita", ""); !ok || n != 3 {
+		t.Errorf("Lookup(ouachita) = %d, %v, want 3, true", n, ok)
+	}
+}
@@ -0,0 +1,380 @@
+// Package generate provides a template-based haiku generator constrained to 5-7-5.
+package generate
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/thornzero/haikugo/internal/analyzer"
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// Template kinds.
+const (
+	KindFragment = "fragment"
+	KindPhrase   = "phrase"
+)
+
+// Slots filled from the lexicon.
+const (
+	slotKigo = "kigo"
+	slotNoun = "noun"
+	slotAdj  = "adj"
+	slotVerb = "verb"
+	slotPrep = "prep"
+)
+
+// maxAttempts is the most candidates tried for each poem.
+const maxAttempts = 500
+
+// prepositions fill {prep} slots.
+var prepositions = []string{
+	"in", "on", "over", "under", "beneath", "across", "along", "through",
+	"beyond", "among", "into", "near", "behind", "above", "below",
+}
+
+// intransitive lists the lexicon verbs that read naturally without an
+// object; only they fill {verb} slots.
+var intransitive = []string{
+	"fly", "bloom", "drift", "float", "flow", "sway", "fade", "glow", "shine",
+	"sleep", "wake", "walk", "run", "sit", "stand", "wait", "sing", "jump",
+	"rise", "cry", "call", "dance", "melt", "hang", "grow", "turn", "wander",
+	"whisper", "tremble", "scatter", "breathe", "listen",
+}
+
+// tellingAdjectives name a judgment rather than an image and never fill
+// {adj} slots.
+var tellingAdjectives = []string{"sad", "beautiful", "eternal", "infinite", "sublime"}
+
+// expected is the syllable count of each line.
+var expected = []int{5, 7, 5}
+
+// Template is a grammar template. A fragment fills one line and holds the
+// season word; a phrase fills two lines, separated by " / ".
+type Template struct {
+	Kind string `json:"kind"`
+	Text string `json:"text"`
+}
+
+//go:embed data/templates.txt
+var templatesData string
+
+// templates are the embedded grammar templates.
+var templates = parseTemplates(templatesData)
+
+// slotRe matches a slot such as "{noun}".
+var slotRe = regexp.MustCompile(`^\{([a-z]+)\}$`)
+
+// parseTemplates reads the embedded kind<TAB>text list.
+func parseTemplates(data string) []Template {
+	var result []Template
+	sc := bufio.NewScanner(strings.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		kind, text, ok := strings.Cut(line, "\t")
+		parts := strings.Split(text, " / ")
+		kigo := strings.Count(text, "{"+slotKigo+"}")
+		switch {
+		case !ok:
+			panic(fmt.Sprintf("templates data: invalid entry %q", line))
+		case kind == KindFragment && (len(parts) != 1 || kigo != 1),
+			kind == KindPhrase && (len(parts) != 2 || kigo != 0):
+			panic(fmt.Sprintf("templates data: malformed %s %q", kind, text))
+		case kind != KindFragment && kind != KindPhrase:
+			panic(fmt.Sprintf("templates data: unknown kind %q", kind))
+		}
+		for _, w := range strings.Fields(text) {
+			if m := slotRe.FindStringSubmatch(w); m != nil && !slices.Contains([]string{slotKigo, slotNoun, slotAdj, slotVerb, slotPrep}, m[1]) {
+				panic(fmt.Sprintf("templates data: unknown slot %q in %q", w, text))
+			}
+		}
+		result = append(result, Template{Kind: kind, Text: text})
+	}
+	return result
+}
+
+// Templates returns the embedded grammar templates.
+func Templates() []Template {
+	return slices.Clone(templates)
+}
+
+// Options configures Generate.
+type Options struct {
+	Season string // season of the season word; empty picks one per poem
+	Sense  string // sense the image nouns engage; empty allows any
+	Seed   uint64 // the same seed and options give the same poems
+	Count  int    // poems generated; 0 generates one
+}
+
+// Poem is a generated haiku with its season, the templates it was built
+// from, fragment or phrase first, and its analysis. Poems sampled from a
+// Model have no templates, and no season when their season words are
+// missing or disagree.
+type Poem struct {
+	Lines     []string       `json:"lines"`
+	Season    string         `json:"season,omitempty"`
+	Templates []Template     `json:"templates,omitempty"`
+	Metrics   *haiku.Metrics `json:"metrics"`
+}
+
+// Generate fills grammar templates with season words and lexicon words
+// counted by a, returning Count distinct poems. Every poem is analyzed with
+// a and returned only when its lines count exactly 5-7-5 and its season
+// words are all of its season or of every season.
+func Generate(a *analyzer.Analyzer, opts Options) ([]Poem, error) {
+	if opts.Season != "" {
+		season, err := analyzer.ParseSeason(opts.Season)
+		if err != nil {
+			return nil, err
+		}
+		opts.Season = season
+	}
+	lexicon, err := a.FindWords(analyzer.WordQuery{})
+	if err != nil {
+		return nil, err
+	}
+	if opts.Sense != "" {
+		if _, err := a.FindWords(analyzer.WordQuery{Sense: opts.Sense, Limit: 1}); err != nil {
+			return nil, err
+		}
+		opts.Sense = strings.ToLower(opts.Sense)
+	}
+	count := max(opts.Count, 1)
+
+	g := newGenerator(lexicon, opts.Sense)
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed))
+	seen := make(map[string]bool)
+	var poems []Poem
+	for len(poems) < count {
+		season := opts.Season
+		if season == "" {
+			season = analyzer.Seasons()[rng.IntN(4)]
+		}
+
+		found := false
+		for range maxAttempts {
+			p, ok := g.poem(rng, season)
+			key := strings.Join(p.Lines, "\n")
+			if !ok || seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			m := a.Analyze(haiku.NewHaiku(p.Lines))
+			if !slices.Equal(m.LineSyllables, expected) || !inSeason(m.SeasonWords, season) {
+				continue
+			}
+			p.Metrics = m
+			poems = append(poems, p)
+			found = true
+			break
+		}
+		if !found {
+			return poems, fmt.Errorf("could not generate a 5-7-5 %s haiku in %d attempts", season, maxAttempts)
+		}
+	}
+	return poems, nil
+}
+
+// inSeason reports whether words include a word of season and no word of
+// another season, counting words of every season as in season.
+func inSeason(words []string, season string) bool {
+	found := false
+	for _, w := range words {
+		switch analyzer.SeasonOf(w) {
+		case season:
+			found = true
+		case analyzer.SeasonAll, "":
+		default:
+			return false
+		}
+	}
+	return found
+}
+
+// generator holds the lexicon words that fill each slot, by syllable count.
+type generator struct {
+	syllables map[string]int
+	slots     map[string]map[int][]string
+	kigo      map[string]map[int][]string // season -> syllables -> words
+}
+
+// newGenerator sorts the lexicon into slots. Season words fill only {kigo}
+// slots, except words of every season, which may also be images. Plural
+// nouns never fill {noun} slots, whose articles and verbs are singular.
+func newGenerator(lexicon []analyzer.WordEntry, sense string) *generator {
+	g := &generator{
+		syllables: make(map[string]int),
+		slots:     make(map[string]map[int][]string),
+		kigo:      make(map[string]map[int][]string),
+	}
+	add := func(pool map[string]map[int][]string, key string, e analyzer.WordEntry) {
+		if pool[key] == nil {
+			pool[key] = make(map[int][]string)
+		}
+		pool[key][e.Syllables] = append(pool[key][e.Syllables], e.Word)
+	}
+
+	for _, e := range lexicon {
+		g.syllables[e.Word] = e.Syllables
+		if len(e.Tags) == 0 {
+			continue
+		}
+		if e.Season != "" && e.Season != analyzer.SeasonAll {
+			if e.Tags[0] == analyzer.TagNoun {
+				add(g.kigo, e.Season, e)
+			}
+			continue
+		}
+
+		switch {
+		case e.Season == analyzer.SeasonAll && e.Tags[0] == analyzer.TagNoun:
+			add(g.kigo, analyzer.SeasonAll, e)
+			if !e.Plural && (sense == "" || slices.Contains(e.Senses, sense)) {
+				add(g.slots, slotNoun, e)
+			}
+		case e.Tags[0] == analyzer.TagNoun && !e.Plural && len(e.Senses) > 0 && (sense == "" || slices.Contains(e.Senses, sense)):
+			add(g.slots, slotNoun, e)
+		case e.Tags[0] == analyzer.TagAdjective && !slices.Contains(tellingAdjectives, e.Word):
+			add(g.slots, slotAdj, e)
+		case e.Tags[0] == analyzer.TagVerb && !e.Past && slices.Contains(intransitive, e.Word):
+			verb := e
+			verb.Word, verb.Syllables = thirdPerson(e.Word, e.Syllables)
+			add(g.slots, slotVerb, verb)
+		}
+	}
+	for _, p := range prepositions {
+		add(g.slots, slotPrep, analyzer.WordEntry{Word: p, Syllables: g.syllables[p]})
+	}
+	return g
+}
+
+// thirdPerson inflects a verb to agree with a singular subject, returning
+// the inflected form and its syllable count.
+func thirdPerson(verb string, syllables int) (string, int) {
+	switch {
+	case strings.HasSuffix(verb, "y") && !strings.ContainsAny(verb[len(verb)-2:len(verb)-1], "aeiou"):
+		return verb[:len(verb)-1] + "ies", syllables
+	case hasAnySuffix(verb, "s", "x", "z", "ch", "sh"):
+		return verb + "es", syllables + 1
+	case hasAnySuffix(verb, "ce", "ge", "se", "ze"):
+		return verb + "s", syllables + 1
+	}
+	return verb + "s", syllables
+}
+
+// hasAnySuffix reports whether s ends with any of the suffixes.
+func hasAnySuffix(s string, suffixes ...string) bool {
+	return slices.ContainsFunc(suffixes, func(suffix string) bool { return strings.HasSuffix(s, suffix) })
+}
+
+// poem builds a candidate poem, a fragment followed by a phrase or a phrase
+// followed by a fragment, with a season word of season.
+func (g *generator) poem(rng *rand.Rand, season string) (Poem, bool) {
+	var fragments, phrases []Template
+	for _, t := range templates {
+		if t.Kind == KindFragment {
+			fragments = append(fragments, t)
+		} else {
+			phrases = append(phrases, t)
+		}
+	}
+	fragment := fragments[rng.IntN(len(fragments))]
+	phrase := phrases[rng.IntN(len(phrases))]
+
+	p := Poem{Season: season, Templates: []Template{fragment, phrase}}
+	parts := append([]string{fragment.Text}, strings.Split(phrase.Text, " / ")...)
+	if rng.IntN(2) == 1 {
+		p.Templates = []Template{phrase, fragment}
+		parts = append(strings.Split(phrase.Text, " / "), fragment.Text)
+	}
+
+	used := make(map[string]bool)
+	for i, part := range parts {
+		line, ok := g.fill(rng, part, expected[i], season, used)
+		if !ok {
+			return p, false
+		}
+		p.Lines = append(p.Lines, line)
+	}
+	return p, true
+}
+
+// fill fills the slots of a one-line template with words whose syllables,
+// with those of the fixed words, add up to target. No word is used twice
+// in a poem.
+func (g *generator) fill(rng *rand.Rand, text string, target int, season string, used map[string]bool) (string, bool) {
+	words := strings.Fields(text)
+	var slots []int
+	var pools []map[int][]string
+	remaining := target
+	for i, w := range words {
+		m := slotRe.FindStringSubmatch(w)
+		if m == nil {
+			n, ok := g.syllables[w]
+			if !ok {
+				return "", false
+			}
+			remaining -= n
+			continue
+		}
+		pool := g.slots[m[1]]
+		if m[1] == slotKigo {
+			pool = g.kigo[season]
+		}
+		slots = append(slots, i)
+		pools = append(pools, pool)
+	}
+
+	counts := make([]int, len(slots))
+	if !assign(rng, pools, counts, 0, remaining) {
+		return "", false
+	}
+	for k, i := range slots {
+		candidates := slices.DeleteFunc(slices.Clone(pools[k][counts[k]]), func(w string) bool { return used[w] })
+		if len(candidates) == 0 {
+			return "", false
+		}
+		words[i] = candidates[rng.IntN(len(candidates))]
+		used[words[i]] = true
+	}
+
+	for i := range len(words) - 1 {
+		if words[i] == "a" && strings.ContainsRune("aeiou", rune(words[i+1][0])) {
+			words[i] = "an"
+		}
+	}
+	return strings.Join(words, " "), true
+}
+
+// assign picks a syllable count for each slot from k on, trying counts in
+// random order, so that they add up to remaining.
+func assign(rng *rand.Rand, pools []map[int][]string, counts []int, k, remaining int) bool {
+	if k == len(pools) {
+		return remaining == 0
+	}
+	var options []int
+	for n := range pools[k] {
+		if n > 0 && n <= remaining {
+			options = append(options, n)
+		}
+	}
+	slices.Sort(options)
+	rng.Shuffle(len(options), func(i, j int) { options[i], options[j] = options[j], options[i] })
+	for _, n := range options {
+		counts[k] = n
+		if assign(rng, pools, counts, k+1, remaining-n) {
+			return true
+		}
+	}
+	return false
+}
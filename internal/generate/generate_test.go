@@ -0,0 +1,211 @@
+package generate
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/thornzero/haikugo/internal/analyzer"
+)
+
+func TestParseTemplates(t *testing.T) {
+	got := parseTemplates("# comment\nfragment\t{adj} {kigo}\n\nphrase\tthe {noun} {verb} / {prep} the {noun}\n")
+	expected := []Template{
+		{Kind: KindFragment, Text: "{adj} {kigo}"},
+		{Kind: KindPhrase, Text: "the {noun} {verb} / {prep} the {noun}"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("parseTemplates() = %+v, want %+v", got, expected)
+	}
+
+	for _, data := range []string{
+		"fragment {kigo}",                     // no tab
+		"fragment\t{adj} {noun}",              // no season word
+		"phrase\t{kigo} {verb} / {noun}",      // season word in a phrase
+		"phrase\tthe {noun} {verb}",           // one line
+		"fragment\t{kigo} {adverb}",           // unknown slot
+		"couplet\t{kigo} / {noun} / {noun}",   // unknown kind
+		"fragment\t{kigo} / the {adj} {noun}", // two lines
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("parseTemplates(%q) did not panic", data)
+				}
+			}()
+			parseTemplates(data)
+		}()
+	}
+}
+
+func TestTemplates_Lexicon(t *testing.T) {
+	lexicon, err := analyzer.New(0).FindWords(analyzer.WordQuery{})
+	if err != nil {
+		t.Fatalf("FindWords() error = %v", err)
+	}
+	entries := make(map[string]analyzer.WordEntry)
+	for _, e := range lexicon {
+		entries[e.Word] = e
+	}
+
+	for _, tmpl := range Templates() {
+		for _, w := range strings.Fields(strings.ReplaceAll(tmpl.Text, " / ", " ")) {
+			if _, ok := entries[w]; !ok && slotRe.FindStringSubmatch(w) == nil {
+				t.Errorf("template %q: %q is not in the lexicon", tmpl.Text, w)
+			}
+		}
+	}
+	for _, w := range intransitive {
+		if e := entries[w]; len(e.Tags) == 0 || e.Tags[0] != analyzer.TagVerb || e.Past {
+			t.Errorf("intransitive verb %q is tagged %v in the lexicon", w, e.Tags)
+		}
+	}
+	for _, w := range prepositions {
+		if e := entries[w]; !slices.Contains(e.Tags, analyzer.TagAdposition) {
+			t.Errorf("preposition %q is tagged %v in the lexicon", w, e.Tags)
+		}
+	}
+}
+
+func TestThirdPerson(t *testing.T) {
+	tests := []struct {
+		verb      string
+		syllables int
+		word      string
+		count     int
+	}{
+		{"sing", 1, "sings", 1},
+		{"fly", 1, "flies", 1},
+		{"sway", 1, "sways", 1},
+		{"wash", 1, "washes", 2},
+		{"dance", 1, "dances", 2},
+		{"wander", 2, "wanders", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.verb, func(t *testing.T) {
+			if word, count := thirdPerson(tt.verb, tt.syllables); word != tt.word || count != tt.count {
+				t.Errorf("thirdPerson(%q) = %q, %d, want %q, %d", tt.verb, word, count, tt.word, tt.count)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	a := analyzer.New(0)
+	for _, season := range []string{analyzer.SeasonSpring, analyzer.SeasonSummer, analyzer.SeasonAutumn, analyzer.SeasonWinter, analyzer.SeasonAll, ""} {
+		t.Run(season, func(t *testing.T) {
+			poems, err := Generate(a, Options{Season: season, Seed: 42, Count: 10})
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if len(poems) != 10 {
+				t.Fatalf("Generate() returned %d poems, want 10", len(poems))
+			}
+			seen := make(map[string]bool)
+			for _, p := range poems {
+				text := strings.Join(p.Lines, " / ")
+				if seen[text] {
+					t.Errorf("duplicate poem %q", text)
+				}
+				seen[text] = true
+				if !reflect.DeepEqual(p.Metrics.LineSyllables, []int{5, 7, 5}) {
+					t.Errorf("%q counts %v", text, p.Metrics.LineSyllables)
+				}
+				if season != "" && p.Season != season {
+					t.Errorf("%q has season %q, want %q", text, p.Season, season)
+				}
+				if !inSeason(p.Metrics.SeasonWords, p.Season) {
+					t.Errorf("%q has season words %v, want %s", text, p.Metrics.SeasonWords, p.Season)
+				}
+				if len(p.Templates) != 2 {
+					t.Errorf("%q built from %d templates, want 2", text, len(p.Templates))
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_Seed(t *testing.T) {
+	a := analyzer.New(0)
+	lines := func(seed uint64) [][]string {
+		poems, err := Generate(a, Options{Season: "autumn", Seed: seed, Count: 3})
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		var result [][]string
+		for _, p := range poems {
+			result = append(result, p.Lines)
+		}
+		return result
+	}
+
+	if first, again := lines(1), lines(1); !reflect.DeepEqual(first, again) {
+		t.Errorf("seed 1 gave %q, then %q", first, again)
+	}
+	if first, other := lines(1), lines(2); reflect.DeepEqual(first, other) {
+		t.Errorf("seeds 1 and 2 both gave %q", first)
+	}
+}
+
+func TestGenerate_Sense(t *testing.T) {
+	a := analyzer.New(0)
+	lexicon, err := a.FindWords(analyzer.WordQuery{})
+	if err != nil {
+		t.Fatalf("FindWords() error = %v", err)
+	}
+	senses := make(map[string][]string)
+	for _, e := range lexicon {
+		senses[e.Word] = e.Senses
+	}
+
+	g := newGenerator(lexicon, analyzer.SenseSound)
+	for _, words := range g.slots[slotNoun] {
+		for _, w := range words {
+			if !slices.Contains(senses[w], analyzer.SenseSound) {
+				t.Errorf("noun %q engages %v, want sound", w, senses[w])
+			}
+		}
+	}
+
+	if _, err := Generate(a, Options{Season: "winter", Sense: "Sound", Seed: 3, Count: 5}); err != nil {
+		t.Errorf("Generate() with a sense error = %v", err)
+	}
+
+	// Every sense offered can fill the templates in every season.
+	for _, sense := range []string{analyzer.SenseSight, analyzer.SenseSound, analyzer.SenseTouch, analyzer.SenseSmell, analyzer.SenseTaste} {
+		for _, season := range append(analyzer.Seasons(), analyzer.SeasonAll) {
+			for seed := range uint64(3) {
+				if _, err := Generate(a, Options{Season: season, Sense: sense, Seed: seed, Count: 3}); err != nil {
+					t.Errorf("Generate(%s, %s, seed %d) error = %v", sense, season, seed, err)
+				}
+			}
+		}
+	}
+}
+
+func TestGenerate_SingularNouns(t *testing.T) {
+	a := analyzer.New(0)
+	lexicon, err := a.FindWords(analyzer.WordQuery{})
+	if err != nil {
+		t.Fatalf("FindWords() error = %v", err)
+	}
+	g := newGenerator(lexicon, "")
+	for _, words := range g.slots[slotNoun] {
+		for _, w := range []string{"stars", "petals", "hands", "blossoms", "leaves"} {
+			if slices.Contains(words, w) {
+				t.Errorf("plural %q fills {noun} slots", w)
+			}
+		}
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	a := analyzer.New(0)
+	for _, opts := range []Options{{Season: "monsoon"}, {Sense: "hearing"}} {
+		if _, err := Generate(a, opts); err == nil {
+			t.Errorf("Generate(%+v) error = nil, want an error", opts)
+		}
+	}
+}
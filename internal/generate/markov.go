@@ -0,0 +1,381 @@
+// Package generate provides a Markov-chain haiku generator trained on a corpus.
+package generate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/thornzero/haikugo/internal/analyzer"
+	"github.com/thornzero/haikugo/internal/haiku"
+	"github.com/thornzero/haikugo/internal/input"
+)
+
+// Model orders.
+const (
+	MinOrder     = 1
+	MaxOrder     = 4
+	DefaultOrder = 2
+)
+
+// DefaultMaxOverlap is the share of a poem's n-grams found in a single
+// training poem at which the poem is rejected as a near-copy.
+const DefaultMaxOverlap = 0.5
+
+// modelVersion is the version of the model file format.
+const modelVersion = 1
+
+const (
+	// lineEnd is the token that ends a line.
+	lineEnd = "</>"
+	// maxLineWords is the most words sampled for a line.
+	maxLineWords = 12
+	// maxLineSteps bounds the backtracking search for a single line.
+	maxLineSteps = 5000
+)
+
+// Model is an n-gram model of the lines of a haiku corpus. Each line is
+// modeled separately, so that first, middle and last lines keep their own
+// openings. The training poems are kept to reject near-copies.
+type Model struct {
+	Version     int                       `json:"version"`
+	Order       int                       `json:"order"`
+	Transitions map[string]map[string]int `json:"transitions"` // context -> next token -> count
+	Poems       [][]string                `json:"poems"`
+}
+
+// Train builds a model of the given order from poems, each a slice of
+// lines. Only three-line poems are used; words are lower-cased and keep
+// their punctuation.
+func Train(poems [][]string, order int) (*Model, error) {
+	if order < MinOrder || order > MaxOrder {
+		return nil, fmt.Errorf("model order must be between %d and %d, got %d", MinOrder, MaxOrder, order)
+	}
+
+	m := &Model{Version: modelVersion, Order: order, Transitions: make(map[string]map[string]int)}
+	for _, poem := range poems {
+		if len(poem) != len(expected) {
+			continue
+		}
+		lines := make([]string, len(poem))
+		tokens := make([][]string, len(poem))
+		for i, line := range poem {
+			tokens[i] = strings.Fields(strings.ToLower(line))
+			lines[i] = strings.Join(tokens[i], " ")
+		}
+		if slices.ContainsFunc(tokens, func(t []string) bool { return len(t) == 0 }) {
+			continue
+		}
+
+		for i, words := range tokens {
+			ctx := m.start(i)
+			for _, w := range append(words, lineEnd) {
+				key := contextKey(ctx)
+				if m.Transitions[key] == nil {
+					m.Transitions[key] = make(map[string]int)
+				}
+				m.Transitions[key][w]++
+				ctx = append(ctx[1:], w)
+			}
+		}
+		m.Poems = append(m.Poems, lines)
+	}
+
+	if len(m.Poems) == 0 {
+		return nil, errors.New("no three-line poems to train on")
+	}
+	return m, nil
+}
+
+// TrainCorpus trains a model on the poems in the file or directory at path,
+// read as by input.Parser.ReadCorpus. Poems that fail to parse are skipped.
+func TrainCorpus(path string, order int) (*Model, error) {
+	records, err := input.New(false).ReadCorpus(path, input.FieldMapping{})
+	if err != nil {
+		return nil, err
+	}
+	var poems [][]string
+	for _, rec := range records {
+		if rec.Err == nil {
+			poems = append(poems, rec.Haiku.Lines)
+		}
+	}
+	return Train(poems, order)
+}
+
+// start returns the context that opens line i.
+func (m *Model) start(i int) []string {
+	ctx := make([]string, m.Order)
+	for k := range ctx {
+		ctx[k] = "<" + strconv.Itoa(i+1) + ">"
+	}
+	return ctx
+}
+
+// contextKey joins the words of a context.
+func contextKey(ctx []string) string {
+	return strings.Join(ctx, " ")
+}
+
+// ReadModel reads a model in the JSON form written by Write.
+func ReadModel(r io.Reader) (*Model, error) {
+	var m Model
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, err
+	}
+	switch {
+	case m.Version != modelVersion:
+		return nil, fmt.Errorf("unsupported model version %d (want %d)", m.Version, modelVersion)
+	case m.Order < MinOrder || m.Order > MaxOrder:
+		return nil, fmt.Errorf("invalid model order %d", m.Order)
+	case len(m.Transitions) == 0 || len(m.Poems) == 0:
+		return nil, errors.New("model has no training data")
+	}
+	return &m, nil
+}
+
+// Write writes the model as JSON.
+func (m *Model) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(m)
+}
+
+// LoadModel reads a model from the file at path.
+func LoadModel(path string) (*Model, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	m, err := ReadModel(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Save writes the model to path, creating its directory if needed.
+func (m *Model) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := m.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// MarkovOptions configures Model.Generate.
+type MarkovOptions struct {
+	Seed       uint64   // the same seed and model give the same poems
+	Count      int      // poems generated; 0 generates one
+	MaxOverlap *float64 // near-copy threshold, above 0 and at most 1; nil means DefaultMaxOverlap
+}
+
+// Generate samples Count distinct poems from the model. Each line is
+// sampled word by word, backtracking until its syllables, counted by a,
+// reach exactly 5, 7 or 5. A poem is rejected when it shares MaxOverlap or
+// more of its n-grams of Order+1 words with any one training poem, and is
+// returned only when a analyzes it as exactly 5-7-5.
+func (m *Model) Generate(a *analyzer.Analyzer, opts MarkovOptions) ([]Poem, error) {
+	maxOverlap := DefaultMaxOverlap
+	if opts.MaxOverlap != nil {
+		maxOverlap = *opts.MaxOverlap
+	}
+	if maxOverlap <= 0 || maxOverlap > 1 {
+		return nil, fmt.Errorf("maximum overlap must be above 0 and at most 1, got %g", maxOverlap)
+	}
+	count := max(opts.Count, 1)
+
+	s := &sampler{
+		model:     m,
+		analyzer:  a,
+		rng:       rand.New(rand.NewPCG(opts.Seed, opts.Seed)),
+		syllables: make(map[string]int),
+	}
+	training := make(map[string][]int)
+	for i, poem := range m.Poems {
+		for _, g := range m.grams(poem) {
+			if ids := training[g]; len(ids) == 0 || ids[len(ids)-1] != i {
+				training[g] = append(ids, i)
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	var poems []Poem
+	for len(poems) < count {
+		found := false
+		for range maxAttempts {
+			lines, ok := s.poem()
+			key := strings.Join(lines, "\n")
+			if !ok || seen[key] {
+				continue
+			}
+			seen[key] = true
+			if m.overlap(lines, training) >= maxOverlap {
+				continue
+			}
+
+			metrics := a.Analyze(haiku.NewHaiku(lines))
+			if !slices.Equal(metrics.LineSyllables, expected) {
+				continue
+			}
+			poems = append(poems, Poem{Lines: lines, Season: seasonOf(metrics.SeasonWords), Metrics: metrics})
+			found = true
+			break
+		}
+		if !found {
+			return poems, fmt.Errorf("could not generate a 5-7-5 haiku unlike the training poems in %d attempts", maxAttempts)
+		}
+	}
+	return poems, nil
+}
+
+// grams returns the distinct n-grams of Order+1 tokens in a poem's lines,
+// including the line openings and ends.
+func (m *Model) grams(lines []string) []string {
+	var result []string
+	for i, line := range lines {
+		tokens := append(m.start(i), strings.Fields(line)...)
+		tokens = append(tokens, lineEnd)
+		for k := 0; k+m.Order < len(tokens); k++ {
+			result = append(result, contextKey(tokens[k:k+m.Order+1]))
+		}
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// overlap returns the largest share of the poem's n-grams found in a single
+// training poem.
+func (m *Model) overlap(lines []string, training map[string][]int) float64 {
+	grams := m.grams(lines)
+	shared := make(map[int]int)
+	best := 0
+	for _, g := range grams {
+		for _, id := range training[g] {
+			shared[id]++
+			best = max(best, shared[id])
+		}
+	}
+	return float64(best) / float64(len(grams))
+}
+
+// seasonOf returns the season of a poem's season words, counting words of
+// every season as in season, or "" when they disagree or there are none.
+func seasonOf(words []string) string {
+	season := ""
+	for _, w := range words {
+		switch s := analyzer.SeasonOf(w); {
+		case s == analyzer.SeasonAll || s == "":
+		case season == "":
+			season = s
+		case s != season:
+			return ""
+		}
+	}
+	if season == "" && len(words) > 0 {
+		return analyzer.SeasonAll
+	}
+	return season
+}
+
+// sampler samples lines from a model, caching the syllables of each word.
+type sampler struct {
+	model     *Model
+	analyzer  *analyzer.Analyzer
+	rng       *rand.Rand
+	syllables map[string]int
+	steps     int
+}
+
+// count returns the syllables of a word.
+func (s *sampler) count(word string) int {
+	n, ok := s.syllables[word]
+	if !ok {
+		n = s.analyzer.CountLine(word)
+		s.syllables[word] = n
+	}
+	return n
+}
+
+// poem samples the three lines of a candidate poem.
+func (s *sampler) poem() ([]string, bool) {
+	lines := make([]string, len(expected))
+	for i, target := range expected {
+		s.steps = 0
+		words, ok := s.line(s.model.start(i), target, nil)
+		if !ok {
+			return nil, false
+		}
+		lines[i] = strings.Join(words, " ")
+	}
+	return lines, true
+}
+
+// line extends words from the context ctx, trying next words in weighted
+// random order and backtracking, until a line end is reached with exactly
+// remaining syllables used.
+func (s *sampler) line(ctx []string, remaining int, words []string) ([]string, bool) {
+	if s.steps >= maxLineSteps {
+		return nil, false
+	}
+	s.steps++
+
+	for _, w := range s.order(s.model.Transitions[contextKey(ctx)]) {
+		if w == lineEnd {
+			if remaining == 0 && len(words) > 0 {
+				return words, true
+			}
+			continue
+		}
+		n := s.count(w)
+		if n > remaining || len(words) == maxLineWords {
+			continue
+		}
+		next := append(slices.Clone(ctx[1:]), w)
+		if result, ok := s.line(next, remaining-n, append(words, w)); ok {
+			return result, true
+		}
+	}
+	return nil, false
+}
+
+// order returns the possible next words in random order, each drawn ahead
+// of the rest in proportion to its count.
+func (s *sampler) order(next map[string]int) []string {
+	words := make([]string, 0, len(next))
+	for w := range next {
+		words = append(words, w)
+	}
+	slices.Sort(words)
+
+	keys := make(map[string]float64, len(words))
+	for _, w := range words {
+		keys[w] = math.Pow(s.rng.Float64(), 1/float64(next[w]))
+	}
+	slices.SortStableFunc(words, func(x, y string) int {
+		switch {
+		case keys[x] > keys[y]:
+			return -1
+		case keys[x] < keys[y]:
+			return 1
+		}
+		return 0
+	})
+	return words
+}
@@ -0,0 +1,234 @@
+package generate
+
+import (
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/thornzero/haikugo/internal/analyzer"
+)
+
+// corpus is a small training set whose lines share enough words to
+// recombine.
+var corpus = [][]string{
+	{"An old silent pond", "a frog jumps into the pond", "splash silence again"},
+	{"the autumn moon shines", "over the pond a cold wind", "the frog sleeps alone"},
+	{"a cold winter night", "the snow falls into the pond", "silence in the pines"},
+	{"the old temple bell", "rings over the quiet hills", "a crow in the rain"},
+	{"spring rain on the roof", "a crow sleeps in the old pine", "the bell rings again"},
+	{"the summer wind blows", "a frog sings in the still grass", "the moon in the pond"},
+}
+
+func TestTrain(t *testing.T) {
+	m, err := Train(append(slices.Clone(corpus), []string{"only two", "lines"}, []string{"", "empty", "line"}), 1)
+	if err != nil {
+		t.Fatalf("Train() error: %v", err)
+	}
+	if len(m.Poems) != len(corpus) {
+		t.Errorf("Train() kept %d poems, want %d", len(m.Poems), len(corpus))
+	}
+	if got := m.Poems[0][0]; got != "an old silent pond" {
+		t.Errorf("First training line = %q, want it lower-cased", got)
+	}
+
+	tests := []struct {
+		context string
+		next    string
+		want    int
+	}{
+		{"<1>", "the", 3},
+		{"<2>", "a", 3},
+		{"<3>", "splash", 1},
+		{"pond", lineEnd, 4},
+		{"frog", "jumps", 1},
+	}
+	for _, tt := range tests {
+		if got := m.Transitions[tt.context][tt.next]; got != tt.want {
+			t.Errorf("Transitions[%q][%q] = %d, want %d", tt.context, tt.next, got, tt.want)
+		}
+	}
+
+	m, err = Train(corpus, 2)
+	if err != nil {
+		t.Fatalf("Train() error: %v", err)
+	}
+	if got := m.Transitions["<1> <1>"]["the"]; got != 3 {
+		t.Errorf("Order 2 opening count = %d, want 3", got)
+	}
+	if got := m.Transitions["old silent"]["pond"]; got != 1 {
+		t.Errorf("Order 2 Transitions[\"old silent\"][\"pond\"] = %d, want 1", got)
+	}
+
+	for _, order := range []int{0, 5} {
+		if _, err := Train(corpus, order); err == nil {
+			t.Errorf("Train(order %d) should fail", order)
+		}
+	}
+	if _, err := Train([][]string{{"one", "two"}}, 1); err == nil {
+		t.Error("Train() without three-line poems should fail")
+	}
+}
+
+func TestTrainCorpus(t *testing.T) {
+	dir := t.TempDir()
+	var stanzas []string
+	for _, poem := range corpus {
+		stanzas = append(stanzas, strings.Join(poem, "\n"))
+	}
+	if err := os.WriteFile(filepath.Join(dir, "poems.txt"), []byte(strings.Join(stanzas, "\n\n")+"\n\nnot a haiku\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := TrainCorpus(dir, DefaultOrder)
+	if err != nil {
+		t.Fatalf("TrainCorpus() error: %v", err)
+	}
+	if len(m.Poems) != len(corpus) || m.Order != DefaultOrder {
+		t.Errorf("TrainCorpus() = %d poems of order %d, want %d of order %d", len(m.Poems), m.Order, len(corpus), DefaultOrder)
+	}
+
+	if _, err := TrainCorpus(filepath.Join(dir, "missing"), DefaultOrder); err == nil {
+		t.Error("TrainCorpus() of a missing path should fail")
+	}
+}
+
+func TestModel_SaveLoad(t *testing.T) {
+	m, err := Train(corpus, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "models", "corpus.json")
+	if err := m.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	loaded, err := LoadModel(path)
+	if err != nil {
+		t.Fatalf("LoadModel() error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, m) {
+		t.Error("LoadModel() did not return the saved model")
+	}
+
+	for _, data := range []string{
+		"not json",
+		`{"version":2,"order":2,"transitions":{"<1> <1>":{"a":1}},"poems":[["a","b","c"]]}`,
+		`{"version":1,"order":9,"transitions":{"<1> <1>":{"a":1}},"poems":[["a","b","c"]]}`,
+		`{"version":1,"order":2}`,
+	} {
+		if _, err := ReadModel(strings.NewReader(data)); err == nil {
+			t.Errorf("ReadModel(%q) should fail", data)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadModel(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("LoadModel() error = %v, want it to name the file", err)
+	}
+}
+
+func TestModel_Generate(t *testing.T) {
+	m, err := Train(corpus, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := analyzer.New(0)
+	poems, err := m.Generate(a, MarkovOptions{Seed: 7, Count: 5})
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+	if len(poems) != 5 {
+		t.Fatalf("Generate() returned %d poems, want 5", len(poems))
+	}
+
+	training := make(map[string][]int)
+	for i, poem := range m.Poems {
+		for _, g := range m.grams(poem) {
+			training[g] = append(training[g], i)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, p := range poems {
+		key := strings.Join(p.Lines, "\n")
+		if seen[key] {
+			t.Errorf("Generate() repeated %q", p.Lines)
+		}
+		seen[key] = true
+		if !slices.Equal(p.Metrics.LineSyllables, expected) {
+			t.Errorf("Poem %q counts %v, want 5-7-5", p.Lines, p.Metrics.LineSyllables)
+		}
+		if overlap := m.overlap(p.Lines, training); overlap >= DefaultMaxOverlap {
+			t.Errorf("Poem %q overlaps a training poem by %.2f", p.Lines, overlap)
+		}
+		if p.Templates != nil {
+			t.Errorf("Poem %q has templates %v", p.Lines, p.Templates)
+		}
+	}
+
+	again, err := m.Generate(a, MarkovOptions{Seed: 7, Count: 5})
+	if err != nil || !reflect.DeepEqual(again, poems) {
+		t.Error("Generate() with the same seed gave different poems")
+	}
+}
+
+func TestModel_Generate_Errors(t *testing.T) {
+	m, err := Train(corpus, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, overlap := range []float64{-0.5, 0, 1.5} {
+		if _, err := m.Generate(analyzer.New(0), MarkovOptions{MaxOverlap: &overlap}); err == nil {
+			t.Errorf("Generate(MaxOverlap %g) should fail", overlap)
+		}
+	}
+
+	// A single poem can only be copied.
+	m, err = Train(corpus[:1], 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Generate(analyzer.New(0), MarkovOptions{}); err == nil {
+		t.Error("Generate() from one poem should fail rather than copy it")
+	}
+}
+
+func TestSeasonOf(t *testing.T) {
+	tests := []struct {
+		words []string
+		want  string
+	}{
+		{nil, ""},
+		{[]string{"cicada"}, "summer"},
+		{[]string{"moon", "cicada"}, "summer"},
+		{[]string{"moon"}, analyzer.SeasonAll},
+		{[]string{"cicada", "snow"}, ""},
+	}
+	for _, tt := range tests {
+		if got := seasonOf(tt.words); got != tt.want {
+			t.Errorf("seasonOf(%q) = %q, want %q", tt.words, got, tt.want)
+		}
+	}
+}
+
+func TestSampler_Order(t *testing.T) {
+	m, err := Train(corpus, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &sampler{model: m, analyzer: analyzer.New(0), syllables: make(map[string]int)}
+	common := 0
+	for seed := range uint64(200) {
+		s.rng = rand.New(rand.NewPCG(seed, seed))
+		if s.order(map[string]int{"common": 9, "rare": 1})[0] == "common" {
+			common++
+		}
+	}
+	if common < 150 {
+		t.Errorf("order() put the common word first %d times in 200, want most", common)
+	}
+}
@@ -0,0 +1,35 @@
+// Package haiku provides syllable patterns such as "5-7-5".
+package haiku
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Form returns the syllable pattern of a poem, such as "5-7-5".
+func Form(syllables []int) string {
+	parts := make([]string, len(syllables))
+	for i, n := range syllables {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, "-")
+}
+
+// ParseForm normalizes a syllable pattern written with dashes, spaces or
+// slashes, such as "5 7 5" or "3/5/3".
+func ParseForm(s string) (string, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '/' || r == ' ' || r == ',' })
+	counts := make([]int, len(fields))
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("invalid syllable pattern %q (want counts such as 5-7-5)", s)
+		}
+		counts[i] = n
+	}
+	if len(counts) == 0 {
+		return "", fmt.Errorf("invalid syllable pattern %q (want counts such as 5-7-5)", s)
+	}
+	return Form(counts), nil
+}
@@ -0,0 +1,32 @@
+package haiku
+
+import "testing"
+
+func TestForm(t *testing.T) {
+	if got := Form([]int{5, 7, 5}); got != "5-7-5" {
+		t.Errorf("Form() = %q, want %q", got, "5-7-5")
+	}
+	if got := Form(nil); got != "" {
+		t.Errorf("Form(nil) = %q, want empty", got)
+	}
+}
+
+func TestParseForm(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"5-7-5", "5-7-5", false},
+		{"5 7 5", "5-7-5", false},
+		{"3/5/3", "3-5-3", false},
+		{"", "", true},
+		{"5-x-5", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseForm(tt.in)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("ParseForm(%q) = %q, %v, want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -16,11 +16,163 @@ type Metrics struct {
 	SeasonWords    []string `json:"season_words"`
 	Valid575       bool     `json:"valid_575"`
 	Tolerance      int      `json:"tolerance"`
+	Dialect        string   `json:"dialect"`
+	Concreteness   float64  `json:"concreteness"`
+	AbstractWords  []string `json:"abstract_words"`
+	Senses         []string `json:"senses"`
+	Score          Score    `json:"score"`
+
+	LineSources []LineSource  `json:"line_sources,omitempty"`
+	Diagnostics []Diagnostic  `json:"diagnostics,omitempty"`
+	Tokens      [][]WordToken `json:"tokens,omitempty"`
+	Meter       []LineMeter   `json:"meter,omitempty"`
+	Sounds      []SoundDevice `json:"sounds,omitempty"`
+	Grammar     []LineGrammar `json:"grammar,omitempty"`
+	Cliches     []Cliche      `json:"cliches,omitempty"`
+}
+
+// LineGrammar is the grammatical profile of a line: counts of its nouns,
+// verbs, adjectives, adverbs and articles, the tense of its finite verbs
+// (present, past or mixed, empty when it has none) and whether it is a
+// clause or a fragment without a finite verb.
+type LineGrammar struct {
+	Nouns      int    `json:"nouns"`
+	Verbs      int    `json:"verbs"`
+	Adjectives int    `json:"adjectives"`
+	Adverbs    int    `json:"adverbs"`
+	Articles   int    `json:"articles"`
+	Tense      string `json:"tense,omitempty"`
+	Clause     bool   `json:"clause"`
+}
+
+// Score grades a haiku against a weighted rubric: an Overall score from 0
+// to 100 and the sub-score behind it for each criterion.
+type Score struct {
+	Overall float64    `json:"overall"`
+	Parts   []SubScore `json:"parts"`
+}
+
+// SubScore is one criterion's score from 0 to 1, its weight in the overall
+// score and a human-readable reason for it.
+type SubScore struct {
+	Criterion string  `json:"criterion"`
+	Score     float64 `json:"score"`
+	Weight    float64 `json:"weight"`
+	Reason    string  `json:"reason"`
+}
+
+// Cliche is an overused phrase from the corpus found in a line: the corpus
+// phrase, its frequency tier (high, medium or low) and the matching text
+// as written, which may inflect or reorder the phrase.
+type Cliche struct {
+	Phrase string `json:"phrase"`
+	Tier   string `json:"tier"`
+	Text   string `json:"text"`
+	Span   Span   `json:"span"`
+}
+
+// LineFix lists the rewrites that bring a line of a haiku to its target
+// syllable count. Line is 1-based.
+type LineFix struct {
+	Line      int       `json:"line"`
+	Text      string    `json:"text"`
+	Syllables int       `json:"syllables"`
+	Target    int       `json:"target"`
+	Rewrites  []Rewrite `json:"rewrites"`
+}
+
+// Rewrite is a suggested rewrite of a line, its syllable count and the
+// edits that produce it.
+type Rewrite struct {
+	Text      string `json:"text"`
+	Syllables int    `json:"syllables"`
+	Edits     []Edit `json:"edits"`
+}
+
+// Edit is one change made by a rewrite: the text From is replaced by To,
+// e.g. "the pond" by "pond" when dropping an article.
+type Edit struct {
+	Kind string `json:"kind"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Accidental is a run of consecutive words in prose that splits exactly
+// into 5-7-5 syllables on word boundaries. Sentence reports whether the run
+// starts and ends on sentence boundaries.
+type Accidental struct {
+	Lines    []string `json:"lines"`
+	Sentence bool     `json:"sentence"`
+	Span     Span     `json:"span"`
+}
+
+// SoundDevice is a pattern of repeated sound across words, such as
+// alliteration within a line or a rhyme between line ends. Sound is the
+// shared sound in lowercase ARPAbet, e.g. "s" or "iy m".
+type SoundDevice struct {
+	Kind  string      `json:"kind"`
+	Sound string      `json:"sound"`
+	Words []SoundWord `json:"words"`
+}
+
+// SoundWord is a word taking part in a sound device.
+type SoundWord struct {
+	Text string `json:"text"`
+	Span Span   `json:"span"`
+}
+
+// LineMeter describes the stress pattern of a line. Pattern marks each
+// syllable 'x' (unstressed) or '/' (stressed), as in "x / x / x". Foot is the
+// metrical foot most of the line fits, or empty when none dominates.
+// IambicRun is the longest run of consecutive iambs, and SingSong flags a
+// line that scans as an accidental iambic run.
+type LineMeter struct {
+	Pattern   string `json:"pattern"`
+	Foot      string `json:"foot,omitempty"`
+	IambicRun int    `json:"iambic_run"`
+	SingSong  bool   `json:"sing_song"`
+}
+
+// WordToken explains how a single token of a line was counted.
+// Syllabified shows the normalized form divided into syllables, as in
+// "mid·dle", and Stress marks each syllable 'x' or '/', as in "x/" for
+// "alone". MinSyllables and MaxSyllables differ from Syllables when a word
+// has more than one accepted pronunciation, such as "fire" or "every".
+// Concreteness rates the word from 1 (abstract) to 5 (concrete), or is zero
+// when the word is unrated, and Senses lists the senses it engages. POS is
+// the word's part-of-speech tag, such as "NOUN" or "VERB".
+type WordToken struct {
+	Text         string   `json:"text"`
+	Normalized   string   `json:"normalized"`
+	Syllabified  string   `json:"syllabified"`
+	Kind         string   `json:"kind"`
+	Syllables    int      `json:"syllables"`
+	MinSyllables int      `json:"min_syllables"`
+	MaxSyllables int      `json:"max_syllables"`
+	Method       string   `json:"method"`
+	Stress       string   `json:"stress"`
+	POS          string   `json:"pos"`
+	Concreteness float64  `json:"concreteness,omitempty"`
+	Senses       []string `json:"senses,omitempty"`
+	Span         Span     `json:"span"`
 }
 
 // Haiku represents a three-line haiku poem.
+// Lines hold the normalized text used for analysis. When the haiku was
+// parsed from input, Source keeps the original text and LineSources map
+// each line back to it. Author, when known, selects per-author syllable
+// overrides.
 type Haiku struct {
-	Lines []string
+	Lines       []string
+	Source      string
+	LineSources []LineSource
+	Author      string
+
+	// offsets maps normalized byte offsets to Source offsets and lineStarts
+	// holds each line's normalized offset; both are nil when Lines were
+	// taken from Source verbatim.
+	offsets    []int
+	lineStarts []int
 }
 
 // NewHaiku creates a new Haiku from the provided lines.
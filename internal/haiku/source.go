@@ -0,0 +1,161 @@
+// Package haiku provides source position tracking for parsed haiku.
+package haiku
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Span locates a range of text in the original source.
+// Offsets are in bytes; Line and Column are 1-based.
+type Span struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	Start  int `json:"start"`
+	End    int `json:"end"`
+}
+
+// LineSource records where a normalized haiku line came from in the source.
+type LineSource struct {
+	Line   int    `json:"line"`   // 1-based source line number
+	Column int    `json:"column"` // 1-based byte column where the line text starts
+	Offset int    `json:"offset"` // byte offset of the line text in the source
+	Indent string `json:"indent"` // whitespace preceding the line text on its source line
+	Raw    string `json:"raw"`    // the full, untrimmed source line
+}
+
+// Diagnostic is an analysis finding tied to a position in the source.
+type Diagnostic struct {
+	Kind string `json:"kind"`
+	Text string `json:"text"`
+	Span Span   `json:"span"`
+}
+
+// FromSource creates a Haiku whose lines were taken from source text.
+// Each line must occur in source in order; its position, indentation and
+// original line are recorded so that analysis results can be mapped back.
+// Lines that cannot be found are positioned as if the source were Text().
+func FromSource(source string, lines []string) *Haiku {
+	h := &Haiku{Lines: lines, Source: source}
+
+	sources := make([]LineSource, len(lines))
+	cursor := 0
+	for i, line := range lines {
+		idx := strings.Index(source[cursor:], line)
+		if idx < 0 {
+			return NewHaiku(lines)
+		}
+		sources[i] = lineSourceAt(source, cursor+idx)
+		cursor += idx + len(line)
+	}
+
+	h.LineSources = sources
+	return h
+}
+
+// FromNormalized is like FromSource for lines taken from a normalized copy
+// of source. offsets maps each byte of normalized, plus one past its end, to
+// the byte offset in source it was produced from, so that positions found in
+// the normalized lines still map back to the original text.
+func FromNormalized(source, normalized string, offsets []int, lines []string) *Haiku {
+	h := FromSource(normalized, lines)
+	if h.LineSources == nil || len(offsets) != len(normalized)+1 {
+		return h
+	}
+
+	h.Source = source
+	h.offsets = offsets
+	h.lineStarts = make([]int, len(lines))
+	for i, ls := range h.LineSources {
+		h.lineStarts[i] = ls.Offset
+		h.LineSources[i] = lineSourceAt(source, offsets[ls.Offset])
+	}
+	return h
+}
+
+// Locate maps a byte range within normalized line i to its source span.
+func (h *Haiku) Locate(line, start, end int) Span {
+	sources := h.LineSources
+	if len(sources) != len(h.Lines) {
+		sources = FromSource(h.Text(), h.Lines).LineSources
+	}
+	if line < 0 || line >= len(sources) {
+		return Span{}
+	}
+
+	ls := sources[line]
+	if h.offsets == nil {
+		return Span{
+			Line:   ls.Line,
+			Column: ls.Column + start,
+			Start:  ls.Offset + start,
+			End:    ls.Offset + end,
+		}
+	}
+
+	s := h.offsets[h.lineStarts[line]+start]
+	e := h.sourceEnd(h.lineStarts[line] + end)
+	return Span{
+		Line:   ls.Line,
+		Column: ls.Column + s - ls.Offset,
+		Start:  s,
+		End:    e,
+	}
+}
+
+// SpanAt locates the byte range [start, end) of text extracted from source.
+// offsets maps each byte of the extracted text, plus one past its end, to
+// the source byte it came from; nil offsets mean the text is the source.
+func SpanAt(source string, offsets []int, start, end int) Span {
+	if offsets != nil {
+		s, e := offsets[start], offsets[start]
+		if end > start {
+			e = offsets[end-1]
+			_, size := utf8.DecodeRuneInString(source[e:])
+			e += size
+		}
+		start, end = s, e
+	}
+	ls := lineSourceAt(source, start)
+	return Span{Line: ls.Line, Column: ls.Column, Start: start, End: end}
+}
+
+// sourceEnd maps an exclusive normalized end offset to the source, extending
+// it past any source character that expanded into several normalized bytes.
+func (h *Haiku) sourceEnd(end int) int {
+	if end <= 0 {
+		return h.offsets[0]
+	}
+	last := h.offsets[end-1]
+	for end < len(h.offsets)-1 && h.offsets[end] == last {
+		end++
+	}
+	return h.offsets[end]
+}
+
+// lineSourceAt describes the source line containing the byte at offset.
+func lineSourceAt(source string, offset int) LineSource {
+	lineStart := strings.LastIndexByte(source[:offset], '\n') + 1
+	lineEnd := strings.IndexByte(source[offset:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(source)
+	} else {
+		lineEnd += offset
+	}
+
+	// Only whitespace-only prefixes count as indentation; autosplit segments
+	// that share a source line with earlier text have none.
+	indent := source[lineStart:offset]
+	if strings.TrimLeftFunc(indent, unicode.IsSpace) != "" {
+		indent = ""
+	}
+
+	return LineSource{
+		Line:   strings.Count(source[:offset], "\n") + 1,
+		Column: offset - lineStart + 1,
+		Offset: offset,
+		Indent: indent,
+		Raw:    strings.TrimSuffix(source[lineStart:lineEnd], "\r"),
+	}
+}
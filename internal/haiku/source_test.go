@@ -0,0 +1,98 @@
+package haiku
+
+import (
+	"testing"
+)
+
+func TestFromSource(t *testing.T) {
+	source := "\n  old pond\n\n      frog jumps in\n\tsplash\n"
+	h := FromSource(source, []string{"old pond", "frog jumps in", "splash"})
+
+	if h.Source != source {
+		t.Errorf("Source = %q, want original text", h.Source)
+	}
+
+	want := []LineSource{
+		{Line: 2, Column: 3, Offset: 3, Indent: "  ", Raw: "  old pond"},
+		{Line: 4, Column: 7, Offset: 19, Indent: "      ", Raw: "      frog jumps in"},
+		{Line: 5, Column: 2, Offset: 34, Indent: "\t", Raw: "\tsplash"},
+	}
+
+	if len(h.LineSources) != len(want) {
+		t.Fatalf("Got %d line sources, want %d", len(h.LineSources), len(want))
+	}
+	for i, ls := range h.LineSources {
+		if ls != want[i] {
+			t.Errorf("LineSources[%d] = %+v, want %+v", i, ls, want[i])
+		}
+		if got := source[ls.Offset : ls.Offset+len(h.Lines[i])]; got != h.Lines[i] {
+			t.Errorf("Offset of line %d points at %q", i, got)
+		}
+	}
+}
+
+func TestFromSource_SharedLine(t *testing.T) {
+	source := "old pond / frog jumps in / splash"
+	h := FromSource(source, []string{"old pond", "frog jumps in", "splash"})
+
+	wantOffsets := []int{0, 11, 27}
+	for i, ls := range h.LineSources {
+		if ls.Offset != wantOffsets[i] {
+			t.Errorf("Line %d offset = %d, want %d", i, ls.Offset, wantOffsets[i])
+		}
+		if ls.Line != 1 {
+			t.Errorf("Line %d source line = %d, want 1", i, ls.Line)
+		}
+		if ls.Indent != "" {
+			t.Errorf("Line %d indent = %q, want none", i, ls.Indent)
+		}
+	}
+}
+
+func TestHaiku_Locate(t *testing.T) {
+	source := "a\n   old pond\n\nfrog jumps in\nsplash"
+	h := FromSource(source, []string{"old pond", "frog jumps in", "splash"})
+
+	span := h.Locate(1, 5, 10) // "jumps"
+	if got := source[span.Start:span.End]; got != "jumps" {
+		t.Errorf("Span %+v covers %q, want %q", span, got, "jumps")
+	}
+	if span.Line != 4 || span.Column != 6 {
+		t.Errorf("Span position = %d:%d, want 4:6", span.Line, span.Column)
+	}
+
+	// Haiku built without a source maps onto its joined text.
+	plain := NewHaiku([]string{"one", "two", "three"})
+	span = plain.Locate(2, 0, 5)
+	if got := plain.Text()[span.Start:span.End]; got != "three" {
+		t.Errorf("Plain span covers %q, want %q", got, "three")
+	}
+
+	if (h.Locate(5, 0, 1) != Span{}) {
+		t.Error("Out of range line should return a zero Span")
+	}
+}
+
+func TestSpanAt(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     string
+		offsets    []int
+		start, end int
+		expected   Span
+	}{
+		{"plain", "one\ntwo words", nil, 8, 13, Span{Line: 2, Column: 5, Start: 8, End: 13}},
+		// "<b>é</b> x" extracted as "é x".
+		{"extracted", "<b>é</b> x", []int{3, 4, 9, 10, 11}, 0, 2, Span{Line: 1, Column: 4, Start: 3, End: 5}},
+		// "a &amp; b" extracted as "a & b", ending on the reference.
+		{"reference", "a &amp; b", []int{0, 1, 6, 7, 8, 9}, 0, 3, Span{Line: 1, Column: 1, Start: 0, End: 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if span := SpanAt(tt.source, tt.offsets, tt.start, tt.end); span != tt.expected {
+				t.Errorf("SpanAt() = %+v, want %+v", span, tt.expected)
+			}
+		})
+	}
+}
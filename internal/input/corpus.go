@@ -0,0 +1,82 @@
+// Package input provides reading of poem collections from files and directories.
+package input
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FormatForPath guesses the batch format of a file from its extension:
+// JSONL for .jsonl and .ndjson, CSV for .csv and stanzas otherwise.
+func FormatForPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return FormatJSONL
+	case ".csv":
+		return FormatCSV
+	}
+	return FormatStanza
+}
+
+// ReadCorpus reads every poem in the file or directory at path. Each file's
+// format is guessed with FormatForPath. A directory is read recursively in
+// lexical order, skipping hidden files and directories, and the IDs of its
+// records are prefixed with the file's path relative to it, as in
+// "spring/ponds.txt:2". Records that fail to parse are returned with Err set.
+func (p *Parser) ReadCorpus(path string, m FieldMapping) ([]Record, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return p.readCorpusFile(path, "", m)
+	}
+
+	var records []Record
+	err = filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if file != path && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(path, file)
+		if err != nil {
+			return err
+		}
+		recs, err := p.readCorpusFile(file, filepath.ToSlash(rel)+":", m)
+		records = append(records, recs...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// readCorpusFile reads the poems in one file, prefixing their IDs.
+func (p *Parser) readCorpusFile(file, prefix string, m FieldMapping) ([]Record, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	records, err := collect(p.NewScanner(f, FormatForPath(file), m))
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		records[i].ID = prefix + records[i].ID
+	}
+	return records, nil
+}
@@ -0,0 +1,77 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFormatForPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want Format
+	}{
+		{"poems.jsonl", FormatJSONL},
+		{"poems.NDJSON", FormatJSONL},
+		{"contest/entries.csv", FormatCSV},
+		{"spring.txt", FormatStanza},
+		{"README", FormatStanza},
+	}
+	for _, tt := range tests {
+		if got := FormatForPath(tt.path); got != tt.want {
+			t.Errorf("FormatForPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestParser_ReadCorpus(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.txt":          "old pond\nfrog jumps in\nsplash\n\nonly two\nlines\n",
+		"spring/b.jsonl": `{"id":"x1","author":"Issa","poem":["one","two","three"]}` + "\n",
+		".git/config":    "not\na\npoem\n",
+		".hidden.txt":    "not\na\npoem\n",
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantIDs []string
+		wantErr []bool
+	}{
+		{"directory", dir, []string{"a.txt:1", "a.txt:2", "spring/b.jsonl:x1"}, []bool{false, true, false}},
+		{"single file", filepath.Join(dir, "spring", "b.jsonl"), []string{"x1"}, []bool{false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := New(false).ReadCorpus(tt.path, FieldMapping{})
+			if err != nil {
+				t.Fatalf("ReadCorpus() error: %v", err)
+			}
+			if len(records) != len(tt.wantIDs) {
+				t.Fatalf("Got %d records, want %d", len(records), len(tt.wantIDs))
+			}
+			for i, rec := range records {
+				if rec.ID != tt.wantIDs[i] {
+					t.Errorf("Record %d ID = %q, want %q", i, rec.ID, tt.wantIDs[i])
+				}
+				if (rec.Err != nil) != tt.wantErr[i] {
+					t.Errorf("Record %s error = %v, want error %v", rec.ID, rec.Err, tt.wantErr[i])
+				}
+			}
+		})
+	}
+
+	if _, err := New(false).ReadCorpus(filepath.Join(dir, "missing"), FieldMapping{}); err == nil {
+		t.Error("ReadCorpus() of a missing path should fail")
+	}
+}
@@ -0,0 +1,212 @@
+// Package input provides character encoding detection and transcoding.
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding identifies the character encoding detected in raw input.
+type Encoding string
+
+// Encodings recognized by DetectEncoding.
+const (
+	EncodingUTF8    Encoding = "utf-8"
+	EncodingUTF16LE Encoding = "utf-16le"
+	EncodingUTF16BE Encoding = "utf-16be"
+	EncodingLatin1  Encoding = "latin-1"
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// windows1252 maps the C1 range of Windows-1252, which word processors use
+// for smart quotes and dashes, to Unicode. Other Latin-1 bytes map directly.
+var windows1252 = [32]rune{
+	'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 'Ž', 0x8F,
+	0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9D, 'ž', 'Ÿ',
+}
+
+// DetectEncoding inspects raw bytes for a byte order mark, a UTF-16 byte
+// pattern, or invalid UTF-8, which is taken to be Latin-1.
+func DetectEncoding(data []byte) Encoding {
+	switch {
+	case bytes.HasPrefix(data, bomUTF8):
+		return EncodingUTF8
+	case bytes.HasPrefix(data, bomUTF16LE):
+		return EncodingUTF16LE
+	case bytes.HasPrefix(data, bomUTF16BE):
+		return EncodingUTF16BE
+	}
+
+	if len(data)%2 == 0 {
+		if enc := guessUTF16(data[:min(len(data), utf16Sample)]); enc != "" {
+			return enc
+		}
+	}
+
+	if utf8.Valid(data) {
+		return EncodingUTF8
+	}
+	return EncodingLatin1
+}
+
+// Decode converts raw input to UTF-8 text, stripping any byte order mark.
+// It returns the detected encoding alongside the text.
+func Decode(data []byte) (string, Encoding) {
+	enc := DetectEncoding(data)
+	switch enc {
+	case EncodingUTF16LE:
+		return decodeUTF16(bytes.TrimPrefix(data, bomUTF16LE), binary.LittleEndian), enc
+	case EncodingUTF16BE:
+		return decodeUTF16(bytes.TrimPrefix(data, bomUTF16BE), binary.BigEndian), enc
+	case EncodingLatin1:
+		return decodeLatin1(data), enc
+	default:
+		return string(bytes.TrimPrefix(data, bomUTF8)), enc
+	}
+}
+
+// NewDecodingReader wraps r so that UTF-16 input is transcoded to UTF-8 and
+// a UTF-8 byte order mark is dropped. UTF-16 without a byte order mark is
+// detected as by DetectEncoding, from the bytes of the first read rather
+// than the whole input. Invalid UTF-8 in the remaining stream is handled
+// per record by the parser.
+func NewDecodingReader(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(3)
+
+	switch {
+	case bytes.HasPrefix(head, bomUTF8):
+		_, _ = br.Discard(len(bomUTF8))
+	case bytes.HasPrefix(head, bomUTF16LE):
+		_, _ = br.Discard(len(bomUTF16LE))
+		return &utf16Reader{r: br, order: binary.LittleEndian}
+	case bytes.HasPrefix(head, bomUTF16BE):
+		_, _ = br.Discard(len(bomUTF16BE))
+		return &utf16Reader{r: br, order: binary.BigEndian}
+	}
+
+	// Peek only what the first read buffered, so a slow stream is not held
+	// up waiting for a full sample.
+	sample, _ := br.Peek(min(br.Buffered(), utf16Sample))
+	switch guessUTF16(sample[:len(sample)&^1]) {
+	case EncodingUTF16LE:
+		return &utf16Reader{r: br, order: binary.LittleEndian}
+	case EncodingUTF16BE:
+		return &utf16Reader{r: br, order: binary.BigEndian}
+	}
+	return br
+}
+
+// utf16Sample is the number of leading bytes inspected for UTF-16 without a
+// byte order mark.
+const utf16Sample = 512
+
+// guessUTF16 detects mostly-ASCII UTF-16 text without a BOM, which has a
+// zero in every other byte, and returns its encoding or "" for other text.
+func guessUTF16(sample []byte) Encoding {
+	if len(sample) < 4 {
+		return ""
+	}
+	var evenZeros, oddZeros int
+	for i, b := range sample {
+		if b != 0 {
+			continue
+		}
+		if i%2 == 0 {
+			evenZeros++
+		} else {
+			oddZeros++
+		}
+	}
+	half := len(sample) / 2
+	switch {
+	case oddZeros*10 >= half*4 && evenZeros*10 < half:
+		return EncodingUTF16LE
+	case evenZeros*10 >= half*4 && oddZeros*10 < half:
+		return EncodingUTF16BE
+	}
+	return ""
+}
+
+// decodeUTF16 converts UTF-16 bytes in the given byte order to UTF-8.
+func decodeUTF16(data []byte, order binary.ByteOrder) string {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[2*i:])
+	}
+	return string(utf16.Decode(units))
+}
+
+// decodeLatin1 converts Latin-1 (Windows-1252) bytes to UTF-8.
+func decodeLatin1(data []byte) string {
+	var sb strings.Builder
+	sb.Grow(len(data))
+	for _, b := range data {
+		if b >= 0x80 && b < 0xA0 {
+			sb.WriteRune(windows1252[b-0x80])
+		} else {
+			sb.WriteRune(rune(b))
+		}
+	}
+	return sb.String()
+}
+
+// utf16Reader transcodes a UTF-16 byte stream to UTF-8.
+type utf16Reader struct {
+	r     *bufio.Reader
+	order binary.ByteOrder
+	out   []byte
+	err   error
+}
+
+// Read implements io.Reader.
+func (u *utf16Reader) Read(p []byte) (int, error) {
+	for len(u.out) == 0 && u.err == nil {
+		u.fill()
+	}
+	if len(u.out) == 0 {
+		return 0, u.err
+	}
+	n := copy(p, u.out)
+	u.out = u.out[n:]
+	return n, nil
+}
+
+// fill decodes the next chunk of UTF-16 code units into the output buffer.
+func (u *utf16Reader) fill() {
+	var buf [4]byte
+	for i := 0; i < 512 && u.err == nil; i++ {
+		if _, err := io.ReadFull(u.r, buf[:2]); err != nil {
+			u.err = eofOf(err)
+			return
+		}
+		r := rune(u.order.Uint16(buf[:2]))
+		if utf16.IsSurrogate(r) {
+			if _, err := io.ReadFull(u.r, buf[2:4]); err != nil {
+				u.err = eofOf(err)
+				u.out = utf8.AppendRune(u.out, utf8.RuneError)
+				return
+			}
+			r = utf16.DecodeRune(r, rune(u.order.Uint16(buf[2:4])))
+		}
+		u.out = utf8.AppendRune(u.out, r)
+	}
+}
+
+// eofOf treats a truncated trailing code unit as the end of input.
+func eofOf(err error) error {
+	if err == io.ErrUnexpectedEOF {
+		return io.EOF
+	}
+	return err
+}
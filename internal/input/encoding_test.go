@@ -0,0 +1,96 @@
+package input
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+// utf16Bytes encodes s as UTF-16 with an optional byte order mark.
+func utf16Bytes(s string, bigEndian, bom bool) []byte {
+	units := utf16.Encode([]rune(s))
+	if bom {
+		units = append([]uint16{0xFEFF}, units...)
+	}
+	out := make([]byte, 0, 2*len(units))
+	for _, u := range units {
+		if bigEndian {
+			out = append(out, byte(u>>8), byte(u))
+		} else {
+			out = append(out, byte(u), byte(u>>8))
+		}
+	}
+	return out
+}
+
+func TestDecode(t *testing.T) {
+	text := "old pond\nfrog jumps in—\nsplash café"
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantEnc Encoding
+		want    string
+	}{
+		{"plain utf-8", []byte(text), EncodingUTF8, text},
+		{"utf-8 with bom", append([]byte{0xEF, 0xBB, 0xBF}, text...), EncodingUTF8, text},
+		{"utf-16le with bom", utf16Bytes(text, false, true), EncodingUTF16LE, text},
+		{"utf-16be with bom", utf16Bytes(text, true, true), EncodingUTF16BE, text},
+		{"utf-16le without bom", utf16Bytes(text, false, false), EncodingUTF16LE, text},
+		{"utf-16be without bom", utf16Bytes(text, true, false), EncodingUTF16BE, text},
+		{"latin-1", []byte("caf\xe9 na\xefve"), EncodingLatin1, "café naïve"},
+		{"windows-1252 smart quotes", []byte("\x93don\x92t\x94"), EncodingLatin1, "“don’t”"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, enc := Decode(tt.data)
+			if enc != tt.wantEnc {
+				t.Errorf("Encoding = %s, want %s", enc, tt.wantEnc)
+			}
+			if got != tt.want {
+				t.Errorf("Decode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDecodingReader(t *testing.T) {
+	text := "a\nb 🐸\nc"
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"utf-8", []byte(text)},
+		{"utf-8 bom", append([]byte{0xEF, 0xBB, 0xBF}, text...)},
+		{"utf-16le bom", utf16Bytes(text, false, true)},
+		{"utf-16be bom", utf16Bytes(text, true, true)},
+		{"utf-16le without bom", utf16Bytes(text, false, false)},
+		{"utf-16be without bom", utf16Bytes(text, true, false)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(NewDecodingReader(strings.NewReader(string(tt.data))))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(got) != text {
+				t.Errorf("Read %q, want %q", got, text)
+			}
+		})
+	}
+}
+
+func TestParser_ParseFromReader_UTF16(t *testing.T) {
+	data := utf16Bytes("old pond\nfrog jumps in\nsplash", false, true)
+	h, err := New(false).ParseFromReader(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if h.Lines[1] != "frog jumps in" {
+		t.Errorf("Line 2 = %q, want %q", h.Lines[1], "frog jumps in")
+	}
+}
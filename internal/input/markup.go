@@ -0,0 +1,378 @@
+// Package input provides extraction of prose from Markdown and HTML documents.
+package input
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Markup is the format of a prose document.
+type Markup string
+
+// Supported document formats.
+const (
+	MarkupText     Markup = "text"
+	MarkupMarkdown Markup = "markdown"
+	MarkupHTML     Markup = "html"
+)
+
+// ParseMarkup returns the document format with the given name, also
+// accepting "md" and "htm".
+func ParseMarkup(name string) (Markup, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "text", "txt":
+		return MarkupText, nil
+	case "markdown", "md":
+		return MarkupMarkdown, nil
+	case "html", "htm":
+		return MarkupHTML, nil
+	}
+	return "", fmt.Errorf("unknown document format %q (want text, markdown or html)", name)
+}
+
+// MarkupForPath guesses a document's format from its file extension,
+// defaulting to plain text.
+func MarkupForPath(path string) Markup {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown", ".mdown":
+		return MarkupMarkdown
+	case ".html", ".htm", ".xhtml":
+		return MarkupHTML
+	}
+	return MarkupText
+}
+
+// htmlBlocks are the HTML elements that end a paragraph.
+var htmlBlocks = map[string]bool{
+	"p": true, "div": true, "br": true, "hr": true, "li": true, "ul": true, "ol": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"blockquote": true, "pre": true, "table": true, "tr": true, "td": true, "th": true,
+	"section": true, "article": true, "header": true, "footer": true, "aside": true,
+	"nav": true, "title": true, "dt": true, "dd": true, "figcaption": true, "body": true,
+}
+
+// htmlSkipped are the HTML elements whose content is not prose.
+var htmlSkipped = []string{"script", "style", "noscript", "template"}
+
+// entities maps the HTML character references common in prose to their text.
+var entities = map[string]string{
+	"amp": "&", "lt": "<", "gt": ">", "quot": "\"", "apos": "'", "nbsp": " ",
+	"mdash": "—", "ndash": "–", "hellip": "…", "lsquo": "‘", "rsquo": "’",
+	"ldquo": "“", "rdquo": "”", "eacute": "é", "copy": "©",
+}
+
+// Markdown line patterns.
+var (
+	mdFence     = regexp.MustCompile("^\\s*(```|~~~)")
+	mdHeading   = regexp.MustCompile(`^\s{0,3}#{1,6}(\s+|$)`)
+	mdRule      = regexp.MustCompile(`^\s{0,3}([-*_=]\s*){3,}$`)
+	mdQuote     = regexp.MustCompile(`^\s{0,3}(>\s?)+`)
+	mdListItem  = regexp.MustCompile(`^\s*([-*+]|\d{1,9}[.)])\s+`)
+	mdReference = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s`)
+	mdImage     = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	mdLink      = regexp.MustCompile(`\[([^\]]*)\](\([^)]*\)|\[[^\]]*\])`)
+	mdCode      = regexp.MustCompile("`[^`]*`")
+	mdAutolink  = regexp.MustCompile(`<(https?://|mailto:)[^>]*>`)
+)
+
+// stripper builds prose from a document, recording for each byte of the
+// prose the byte offset in the document it came from.
+type stripper struct {
+	src     string
+	out     []byte
+	offsets []int
+}
+
+// keep copies src[start:end] to the prose.
+func (s *stripper) keep(start, end int) {
+	s.out = append(s.out, s.src[start:end]...)
+	for i := start; i < end; i++ {
+		s.offsets = append(s.offsets, i)
+	}
+}
+
+// emit adds text that replaces the document bytes src[start:end]. Its last
+// byte maps to the end of the replaced bytes so that spans ending on it
+// cover them.
+func (s *stripper) emit(text string, start, end int) {
+	s.out = append(s.out, text...)
+	for range len(text) {
+		s.offsets = append(s.offsets, start)
+	}
+	if text != "" {
+		s.offsets[len(s.offsets)-1] = end - 1
+	}
+}
+
+// breakParagraph ends the current paragraph with a blank line.
+func (s *stripper) breakParagraph(at int) {
+	for len(s.out) > 0 && !s.blank() {
+		s.out = append(s.out, '\n')
+		s.offsets = append(s.offsets, at)
+	}
+}
+
+// newline keeps the line break at src[at] unless the prose is empty or
+// already ends with a blank line.
+func (s *stripper) newline(at int) {
+	if len(s.out) > 0 && !s.blank() {
+		s.keep(at, at+1)
+	}
+}
+
+// blank reports whether the prose ends with a blank line.
+func (s *stripper) blank() bool {
+	n := len(s.out)
+	return n >= 2 && s.out[n-1] == '\n' && s.out[n-2] == '\n'
+}
+
+// result returns the prose and its offsets, plus one past the end.
+func (s *stripper) result() (string, []int) {
+	return string(s.out), append(s.offsets, len(s.src))
+}
+
+// StripMarkup returns the prose of a document: Markdown syntax or HTML tags
+// are removed, character references decoded, code and scripts dropped, and
+// headings, list items and other blocks end with a blank line so that they
+// read as separate paragraphs. offsets maps each byte of the prose, plus
+// one past its end, to the byte offset in text it came from. Plain text is
+// returned unchanged with nil offsets.
+func StripMarkup(text string, m Markup) (string, []int) {
+	s := &stripper{src: text}
+	switch m {
+	case MarkupMarkdown:
+		s.markdown()
+	case MarkupHTML:
+		s.html(0, len(text))
+	default:
+		return text, nil
+	}
+	return s.result()
+}
+
+// markdown strips Markdown block and inline syntax line by line.
+func (s *stripper) markdown() {
+	inFence := false
+	for start := 0; start < len(s.src); {
+		end := strings.IndexByte(s.src[start:], '\n')
+		if end < 0 {
+			end = len(s.src)
+		} else {
+			end += start
+		}
+		line := s.src[start:end]
+
+		switch {
+		case mdFence.MatchString(line):
+			inFence = !inFence
+			s.breakParagraph(start)
+		case inFence, mdRule.MatchString(line), mdReference.MatchString(line), strings.HasPrefix(strings.TrimSpace(line), "|"):
+			s.breakParagraph(start)
+		default:
+			content := start
+			if loc := mdQuote.FindStringIndex(line); loc != nil {
+				content += loc[1]
+			}
+			heading := false
+			if loc := mdHeading.FindStringIndex(s.src[content:end]); loc != nil {
+				s.breakParagraph(start)
+				content += loc[1]
+				heading = true
+			} else if loc := mdListItem.FindStringIndex(s.src[content:end]); loc != nil {
+				s.breakParagraph(start)
+				content += loc[1]
+			}
+			s.markdownInline(content, strings.TrimRight(s.src[content:end], "# \t\r"), heading)
+		}
+
+		if end < len(s.src) {
+			s.newline(end)
+		}
+		start = end + 1
+	}
+}
+
+// markdownInline strips inline syntax from the line content starting at
+// offset start. A heading is followed by a paragraph break.
+func (s *stripper) markdownInline(start int, content string, heading bool) {
+	end := start + len(content)
+	type cut struct {
+		start, end int // removed range
+		keep       []int
+	}
+	var cuts []cut
+	taken := func(a, b int) bool {
+		for _, c := range cuts {
+			if a < c.end && b > c.start {
+				return true
+			}
+		}
+		return false
+	}
+	for _, loc := range mdCode.FindAllStringIndex(content, -1) {
+		cuts = append(cuts, cut{start: loc[0], end: loc[1]})
+	}
+	for _, loc := range mdImage.FindAllStringIndex(content, -1) {
+		if !taken(loc[0], loc[1]) {
+			cuts = append(cuts, cut{start: loc[0], end: loc[1]})
+		}
+	}
+	for _, loc := range mdAutolink.FindAllStringIndex(content, -1) {
+		if !taken(loc[0], loc[1]) {
+			cuts = append(cuts, cut{start: loc[0], end: loc[1]})
+		}
+	}
+	for _, loc := range mdLink.FindAllStringSubmatchIndex(content, -1) {
+		if !taken(loc[0], loc[1]) {
+			cuts = append(cuts, cut{start: loc[0], end: loc[1], keep: []int{loc[2], loc[3]}})
+		}
+	}
+
+	pos := start
+	for pos < end {
+		next, nc := end, -1
+		for i, c := range cuts {
+			if start+c.start >= pos && start+c.start < next {
+				next, nc = start+c.start, i
+			}
+		}
+		s.inlineText(pos, next)
+		if nc < 0 {
+			break
+		}
+		c := cuts[nc]
+		if c.keep != nil {
+			s.inlineText(start+c.keep[0], start+c.keep[1])
+		}
+		pos = start + c.end
+	}
+	if heading {
+		s.breakParagraph(end)
+	}
+}
+
+// inlineText keeps Markdown text, dropping emphasis markers, inline HTML
+// and decoding character references.
+func (s *stripper) inlineText(start, end int) {
+	for i := start; i < end; {
+		switch c := s.src[i]; {
+		case c == '*' || c == '~' || (c == '_' && !(i > start && i+1 < end && isAlnum(s.src[i-1]) && isAlnum(s.src[i+1]))):
+			i++
+		case c == '\\' && i+1 < end:
+			s.keep(i+1, i+2)
+			i += 2
+		case c == '<':
+			i = s.tag(i, end)
+		case c == '&':
+			i = s.entity(i, end)
+		default:
+			s.keep(i, i+1)
+			i++
+		}
+	}
+}
+
+// html strips tags from src[start:end], breaking paragraphs at block
+// elements and dropping comments and the content of skipped elements.
+func (s *stripper) html(start, end int) {
+	for i := start; i < end; {
+		switch s.src[i] {
+		case '<':
+			i = s.tag(i, end)
+		case '&':
+			i = s.entity(i, end)
+		default:
+			s.keep(i, i+1)
+			i++
+		}
+	}
+}
+
+// tag handles the markup starting with '<' at i and returns the offset
+// after it. Text that is not a tag is kept.
+func (s *stripper) tag(i, end int) int {
+	if strings.HasPrefix(s.src[i:end], "<!--") {
+		if j := strings.Index(s.src[i:end], "-->"); j >= 0 {
+			return i + j + 3
+		}
+		return end
+	}
+
+	j := i + 1
+	if j < end && s.src[j] == '/' {
+		j++
+	}
+	nameStart := j
+	for j < end && (isWordByte(s.src[j]) || s.src[j] == '!') {
+		j++
+	}
+	name := strings.ToLower(s.src[nameStart:j])
+	closeIdx := strings.IndexByte(s.src[i:end], '>')
+	if name == "" || closeIdx < 0 {
+		s.keep(i, i+1)
+		return i + 1
+	}
+	after := i + closeIdx + 1
+
+	closing := s.src[i+1] == '/'
+	for _, skipped := range htmlSkipped {
+		if name == skipped && !closing {
+			if k := strings.Index(strings.ToLower(s.src[after:end]), "</"+skipped); k >= 0 {
+				if m := strings.IndexByte(s.src[after+k:end], '>'); m >= 0 {
+					return after + k + m + 1
+				}
+			}
+			return end
+		}
+	}
+	if htmlBlocks[name] {
+		s.breakParagraph(i)
+	}
+	return after
+}
+
+// entity decodes the character reference starting with '&' at i and
+// returns the offset after it. An unknown reference is kept as text.
+func (s *stripper) entity(i, end int) int {
+	semi := strings.IndexByte(s.src[i:min(end, i+12)], ';')
+	if semi < 0 {
+		s.keep(i, i+1)
+		return i + 1
+	}
+	name := s.src[i+1 : i+semi]
+	after := i + semi + 1
+
+	text, ok := entities[name]
+	if !ok && strings.HasPrefix(name, "#") {
+		num := name[1:]
+		base := 10
+		if strings.HasPrefix(num, "x") || strings.HasPrefix(num, "X") {
+			num, base = num[1:], 16
+		}
+		if n, err := strconv.ParseInt(num, base, 32); err == nil && utf8.ValidRune(rune(n)) {
+			text, ok = string(rune(n)), true
+		}
+	}
+	if !ok {
+		s.keep(i, i+1)
+		return i + 1
+	}
+	s.emit(text, i, after)
+	return after
+}
+
+// isAlnum reports whether b is an ASCII letter or digit.
+func isAlnum(b byte) bool {
+	r := rune(b)
+	return r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// isWordByte reports whether b is an ASCII letter, digit or underscore.
+func isWordByte(b byte) bool {
+	return isAlnum(b) || b == '_'
+}
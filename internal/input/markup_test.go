@@ -0,0 +1,105 @@
+package input
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMarkup(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Markup
+		wantErr bool
+	}{
+		{"text", MarkupText, false},
+		{"MD", MarkupMarkdown, false},
+		{"markdown", MarkupMarkdown, false},
+		{"htm", MarkupHTML, false},
+		{"pdf", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMarkup(tt.name)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseMarkup(%q) = %q, %v, want %q", tt.name, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkupForPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want Markup
+	}{
+		{"post.md", MarkupMarkdown},
+		{"NEWS.Markdown", MarkupMarkdown},
+		{"index.html", MarkupHTML},
+		{"notes.txt", MarkupText},
+		{"README", MarkupText},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := MarkupForPath(tt.path); got != tt.want {
+				t.Errorf("MarkupForPath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripMarkup(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		markup Markup
+		want   string
+	}{
+		{"text unchanged", "*old* <b>pond</b>", MarkupText, "*old* <b>pond</b>"},
+		{"markdown emphasis", "An *old* __silent__ pond", MarkupMarkdown, "An old silent pond"},
+		{"markdown snake case kept", "see snake_case here", MarkupMarkdown, "see snake_case here"},
+		{"markdown heading", "# Spring Notes\nA frog jumps", MarkupMarkdown, "Spring Notes\n\nA frog jumps"},
+		{"markdown list", "Intro\n- one item\n- two item", MarkupMarkdown, "Intro\n\none item\n\ntwo item"},
+		{"markdown links and images", "A [frog](http://x) ![img](a.png) jumps", MarkupMarkdown, "A frog  jumps"},
+		{"markdown code", "Run `go test` now\n```\ncode here\n```\nDone", MarkupMarkdown, "Run  now\n\nDone"},
+		{"markdown quote", "> quoted pond", MarkupMarkdown, "quoted pond"},
+		{"html blocks", "<h1>Title</h1><p>One <i>frog</i></p>", MarkupHTML, "Title\n\nOne frog\n\n"},
+		{"html script", "a<script>var x = 1;</script> b<!-- note -->", MarkupHTML, "a b"},
+		{"html entities", "rock &amp; roll&#8212;&rdquo; &bogus; &", MarkupHTML, "rock & roll—” &bogus; &"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, offsets := StripMarkup(tt.input, tt.markup)
+			if got != tt.want {
+				t.Errorf("StripMarkup(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if tt.markup == MarkupText {
+				if offsets != nil {
+					t.Errorf("offsets = %v, want nil for plain text", offsets)
+				}
+				return
+			}
+			if len(offsets) != len(got)+1 || offsets[len(got)] != len(tt.input) {
+				t.Fatalf("offsets = %v, want %d entries ending at %d", offsets, len(got)+1, len(tt.input))
+			}
+		})
+	}
+}
+
+func TestStripMarkup_Offsets(t *testing.T) {
+	input := "<p>An <b>old</b> pond &amp; frog</p>"
+	prose, offsets := StripMarkup(input, MarkupHTML)
+	for _, word := range []string{"An", "old", "pond", "frog"} {
+		i := strings.Index(prose, word)
+		if got := input[offsets[i] : offsets[i+len(word)-1]+1]; got != word {
+			t.Errorf("%q maps to %q", word, got)
+		}
+	}
+	// A decoded reference ends where the reference ends.
+	amp := strings.Index(prose, "&")
+	if got := input[offsets[amp]-4 : offsets[amp]+1]; got != "&amp;" {
+		t.Errorf("& maps to %q, want %q", got, "&amp;")
+	}
+}
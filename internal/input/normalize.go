@@ -0,0 +1,101 @@
+// Package input provides Unicode normalization for haiku text.
+package input
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalization selects the Unicode normalization applied to input text.
+type Normalization int
+
+const (
+	// NormalizeNFC composes characters into their canonical precomposed
+	// forms ("cafe\u0301" -> "café"). It is the zero value and the parser
+	// default.
+	NormalizeNFC Normalization = iota
+	// NormalizeNFKC additionally folds compatibility characters such as
+	// ligatures, fullwidth forms, superscripts and Unicode spaces.
+	NormalizeNFKC
+	// NormalizeNone leaves text exactly as read.
+	NormalizeNone
+)
+
+// ParseNormalization converts a form name ("nfc", "nfkc" or "none") into a Normalization.
+func ParseNormalization(name string) (Normalization, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "nfc":
+		return NormalizeNFC, nil
+	case "nfkc":
+		return NormalizeNFKC, nil
+	case "none":
+		return NormalizeNone, nil
+	default:
+		return NormalizeNFC, fmt.Errorf("unknown normalization %q (want nfc, nfkc or none)", name)
+	}
+}
+
+// typographic maps word-processor punctuation to the plain ASCII forms the
+// tokenizer understands. Em and en dashes are kept since they mark kireji.
+var typographic = map[rune]string{
+	'‘': "'", '’': "'", '‚': "'", '‛': "'", '′': "'", 'ʼ': "'", '´': "'", '`': "'",
+	'“': `"`, '”': `"`, '„': `"`, '‟': `"`, '″': `"`, '«': `"`, '»': `"`,
+	'‐': "-", '‑': "-", '‒': "-",
+}
+
+// Normalize maps typographic punctuation and applies the given Unicode
+// normalization form. Zero-width characters and soft hyphens are removed.
+func Normalize(text string, form Normalization) string {
+	out, _ := normalize(text, form)
+	return out
+}
+
+// normalize is Normalize that also returns, for each byte of the result and
+// one past its end, the byte offset in text it was produced from.
+func normalize(text string, form Normalization) (string, []int) {
+	if form == NormalizeNone {
+		return text, nil
+	}
+
+	// Map punctuation and drop invisible characters rune by rune.
+	mapped := make([]byte, 0, len(text))
+	src := make([]int, 0, len(text)+1)
+	for i, r := range text {
+		if r == '\u200B' || r == '\u200C' || r == '\u200D' || r == '\uFEFF' || r == '\u00AD' {
+			continue
+		}
+		s, ok := typographic[r]
+		if !ok {
+			s = string(r)
+		}
+		mapped = append(mapped, s...)
+		for range len(s) {
+			src = append(src, i)
+		}
+	}
+	src = append(src, len(text))
+
+	// Normalize segment by segment, so every byte of a segment maps back to
+	// where the segment started.
+	f := norm.NFC
+	if form == NormalizeNFKC {
+		f = norm.NFKC
+	}
+	out := make([]byte, 0, len(mapped))
+	offsets := make([]int, 0, len(mapped)+1)
+	var it norm.Iter
+	it.InitString(f, string(mapped))
+	for !it.Done() {
+		start := src[it.Pos()]
+		seg := it.Next()
+		out = append(out, seg...)
+		for range len(seg) {
+			offsets = append(offsets, start)
+		}
+	}
+
+	offsets = append(offsets, len(text))
+	return string(out), offsets
+}
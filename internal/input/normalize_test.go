@@ -0,0 +1,140 @@
+package input
+
+import (
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		form  Normalization
+		want  string
+	}{
+		{"none leaves text alone", "don’t café", NormalizeNone, "don’t café"},
+		{"curly apostrophe", "don’t", NormalizeNFC, "don't"},
+		{"smart quotes", "“old pond”", NormalizeNFC, `"old pond"`},
+		{"combining acute", "cafe\u0301", NormalizeNFC, "café"},
+		{"combining diaeresis", "nai\u0308ve", NormalizeNFC, "naïve"},
+		{"precomposed unchanged", "café", NormalizeNFC, "café"},
+		{"dashes kept", "pond—frog – splash", NormalizeNFC, "pond—frog – splash"},
+		{"zero width removed", "fro\u200Bg", NormalizeNFC, "frog"},
+		{"ligature kept by nfc", "ﬁreﬂy", NormalizeNFC, "ﬁreﬂy"},
+		{"ligature folded by nfkc", "ﬁreﬂy", NormalizeNFKC, "firefly"},
+		{"fullwidth folded by nfkc", "ｏｌｄ\u3000ｐｏｎｄ", NormalizeNFKC, "old pond"},
+		{"nbsp folded by nfkc", "old\u00A0pond", NormalizeNFKC, "old pond"},
+		{"stacked accents composed", "Vie\u0323\u0302t", NormalizeNFC, "Việt"},
+		{"hangul jamo composed", "\u1112\u1161\u11AB", NormalizeNFC, "한"},
+		{"superscript kept by nfc", "x²", NormalizeNFC, "x²"},
+		{"superscript folded by nfkc", "x²", NormalizeNFKC, "x2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.input, tt.form); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize_Offsets(t *testing.T) {
+	input := "“ﬁre” café"
+	out, offsets := normalize(input, NormalizeNFKC)
+
+	if out != `"fire" café` {
+		t.Fatalf("normalize() = %q", out)
+	}
+	if len(offsets) != len(out)+1 {
+		t.Fatalf("Got %d offsets for %d bytes", len(offsets), len(out))
+	}
+
+	// "f" and "i" both come from the ligature, which starts at byte 3.
+	if offsets[1] != 3 || offsets[2] != 3 {
+		t.Errorf("Ligature offsets = %d, %d, want 3, 3", offsets[1], offsets[2])
+	}
+	if offsets[len(out)] != len(input) {
+		t.Errorf("End offset = %d, want %d", offsets[len(out)], len(input))
+	}
+}
+
+func TestParser_Normalization(t *testing.T) {
+	text := "  “don’t go”\ncafe\u0301 au lait\nﬁreﬂies—"
+	parser := New(false)
+	parser.SetNormalization(NormalizeNFKC)
+
+	h, err := parser.ParseFromString(text)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []string{`"don't go"`, "café au lait", "fireflies—"}
+	for i, line := range h.Lines {
+		if line != want[i] {
+			t.Errorf("Line %d = %q, want %q", i, line, want[i])
+		}
+	}
+
+	if h.Source != text {
+		t.Errorf("Source = %q, want the original text", h.Source)
+	}
+
+	// Positions in normalized lines map back to the original bytes.
+	tests := []struct {
+		line, start, end int
+		want             string
+	}{
+		{0, 1, 6, "don’t"},
+		{1, 0, 5, "café"},
+		{2, 0, 9, "ﬁreﬂies"},
+		{2, 9, 12, "—"},
+	}
+	for _, tt := range tests {
+		span := h.Locate(tt.line, tt.start, tt.end)
+		if got := text[span.Start:span.End]; got != tt.want {
+			t.Errorf("Locate(%d, %d, %d) covers %q, want %q", tt.line, tt.start, tt.end, got, tt.want)
+		}
+	}
+
+	if h.LineSources[0].Indent != "  " || h.LineSources[0].Column != 3 {
+		t.Errorf("Line 1 source = %+v, want 2-space indent at column 3", h.LineSources[0])
+	}
+}
+
+func TestParser_Latin1String(t *testing.T) {
+	h, err := New(false).ParseFromString("caf\xe9\nna\xefve\ndon\x92t")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []string{"café", "naïve", "don't"}
+	for i, line := range h.Lines {
+		if line != want[i] {
+			t.Errorf("Line %d = %q, want %q", i, line, want[i])
+		}
+	}
+}
+
+func TestParseNormalization(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Normalization
+		wantErr bool
+	}{
+		{"", NormalizeNFC, false},
+		{"NFC", NormalizeNFC, false},
+		{"nfkc", NormalizeNFKC, false},
+		{"none", NormalizeNone, false},
+		{"fold", NormalizeNFC, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseNormalization(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseNormalization(%q) error = %v, wantErr %t", tt.name, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseNormalization(%q) = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
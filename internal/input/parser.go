@@ -2,13 +2,13 @@
 package input
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/thornzero/haikugo/internal/haiku"
 )
@@ -16,40 +16,66 @@ import (
 // Parser handles different input sources and formats for haiku text.
 type Parser struct {
 	autosplit bool
+	norm      Normalization
 }
 
 // New creates a new Parser with the specified autosplit setting.
+// Input is normalized with NormalizeNFC by default; see SetNormalization.
 func New(autosplit bool) *Parser {
 	return &Parser{autosplit: autosplit}
 }
 
+// SetNormalization selects the Unicode normalization applied before parsing.
+func (p *Parser) SetNormalization(form Normalization) {
+	p.norm = form
+}
+
+// GetNormalization returns the Unicode normalization applied before parsing.
+func (p *Parser) GetNormalization() Normalization {
+	return p.norm
+}
+
 // ParseFromFile reads and parses a haiku from the specified file path.
+// UTF-16 and Latin-1 files are transcoded and any byte order mark is removed.
 func (p *Parser) ParseFromFile(filename string) (*haiku.Haiku, error) {
 	content, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
-	return p.ParseFromString(string(content))
+	text, _ := Decode(content)
+	return p.ParseFromString(text)
 }
 
 // ParseFromReader reads and parses a haiku from an io.Reader.
+// The input encoding is detected as for ParseFromFile.
 func (p *Parser) ParseFromReader(r io.Reader) (*haiku.Haiku, error) {
-	content, err := readAll(r)
+	content, err := io.ReadAll(r)
 	if err != nil {
 		return nil, err
 	}
-	return p.ParseFromString(content)
+	text, _ := Decode(content)
+	return p.ParseFromString(text)
 }
 
-// ParseFromString parses a haiku from a string.
+// ParseFromString parses a haiku from a string. Invalid UTF-8 is read as
+// Latin-1, and the text is normalized before it is split into lines. The
+// returned haiku keeps text as its Source with lines mapped back to it.
 func (p *Parser) ParseFromString(text string) (*haiku.Haiku, error) {
-	lines, source := p.prepareLines(text)
+	if !utf8.ValidString(text) {
+		text = decodeLatin1([]byte(text))
+	}
+
+	normalized, offsets := normalize(text, p.norm)
+	lines, source := p.prepareLines(normalized)
 
 	if len(lines) != 3 {
 		return nil, fmt.Errorf("haiku must have exactly 3 lines, got %d (source=%s)", len(lines), source)
 	}
 
-	return haiku.NewHaiku(lines), nil
+	if offsets == nil {
+		return haiku.FromSource(text, lines), nil
+	}
+	return haiku.FromNormalized(text, normalized, offsets, lines), nil
 }
 
 // ParseFromStdin reads and parses a haiku from standard input.
@@ -67,24 +93,6 @@ func (p *Parser) ParseFromStdin() (*haiku.Haiku, error) {
 	return p.ParseFromReader(os.Stdin)
 }
 
-// readAll reads all content from an io.Reader as a string.
-func readAll(r io.Reader) (string, error) {
-	var sb strings.Builder
-	scanner := bufio.NewScanner(r)
-	first := true
-
-	for scanner.Scan() {
-		if !first {
-			sb.WriteByte('\n')
-		} else {
-			first = false
-		}
-		sb.WriteString(scanner.Text())
-	}
-
-	return sb.String(), scanner.Err()
-}
-
 // prepareLines processes input text into exactly 3 lines for haiku analysis.
 func (p *Parser) prepareLines(text string) ([]string, string) {
 	trimmed := strings.TrimSpace(text)
@@ -220,6 +220,32 @@ func TestSplitAndTrim(t *testing.T) {
 	}
 }
 
+func TestParser_ParseFromString_Source(t *testing.T) {
+	text := "\n  old pond\n\n      frog jumps in\nsplash\n"
+	h, err := New(false).ParseFromString(text)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if h.Source != text {
+		t.Errorf("Source = %q, want original text", h.Source)
+	}
+
+	wantLines := []int{2, 4, 5}
+	wantIndents := []string{"  ", "      ", ""}
+	for i, ls := range h.LineSources {
+		if ls.Line != wantLines[i] {
+			t.Errorf("Line %d source line = %d, want %d", i, ls.Line, wantLines[i])
+		}
+		if ls.Indent != wantIndents[i] {
+			t.Errorf("Line %d indent = %q, want %q", i, ls.Indent, wantIndents[i])
+		}
+		if got := text[ls.Offset : ls.Offset+len(h.Lines[i])]; got != h.Lines[i] {
+			t.Errorf("Line %d offset points at %q, want %q", i, got, h.Lines[i])
+		}
+	}
+}
+
 func TestFilterNonEmpty(t *testing.T) {
 	tests := []struct {
 		input    []string
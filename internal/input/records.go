@@ -0,0 +1,124 @@
+// Package input provides batch record readers for CSV and JSONL sources.
+package input
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// FieldMapping names the CSV columns or JSON fields that hold each record field.
+// A CSV column may be given either as a header name or as a zero-based index;
+// a CSV file whose poem column is given by index is read without a header.
+// Empty names fall back to DefaultFieldMapping.
+type FieldMapping struct {
+	ID     string
+	Author string
+	Poem   string
+}
+
+// DefaultFieldMapping is used for any FieldMapping entry left empty.
+var DefaultFieldMapping = FieldMapping{ID: "id", Author: "author", Poem: "poem"}
+
+// Record is a single poem read from a batch source.
+// Err is set when the record could not be read or parsed; other records
+// in the same batch are unaffected.
+type Record struct {
+	ID     string
+	Author string
+	Haiku  *haiku.Haiku
+	Err    error
+}
+
+// withDefaults fills empty mapping entries from DefaultFieldMapping.
+func (m FieldMapping) withDefaults() FieldMapping {
+	if m.ID == "" {
+		m.ID = DefaultFieldMapping.ID
+	}
+	if m.Author == "" {
+		m.Author = DefaultFieldMapping.Author
+	}
+	if m.Poem == "" {
+		m.Poem = DefaultFieldMapping.Poem
+	}
+	return m
+}
+
+// ParseCSV reads poem records from CSV data. The first row must be a header
+// unless every mapped column is given as a numeric index. Quoted fields may
+// contain embedded newlines, which become the poem's line breaks.
+func (p *Parser) ParseCSV(r io.Reader, m FieldMapping) ([]Record, error) {
+	return collect(p.NewScanner(r, FormatCSV, m))
+}
+
+// ParseJSONL reads poem records from newline-delimited JSON. The poem field
+// may be a single string with embedded newlines or an array of line strings.
+// Malformed lines produce a per-record error rather than aborting the batch.
+func (p *Parser) ParseJSONL(r io.Reader, m FieldMapping) ([]Record, error) {
+	return collect(p.NewScanner(r, FormatJSONL, m))
+}
+
+// collect drains a Scanner into a slice of records.
+func collect(s *Scanner) ([]Record, error) {
+	var records []Record
+	for s.Scan() {
+		records = append(records, s.Record())
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// resolveColumn finds a column by header name, falling back to a numeric index.
+func resolveColumn(header map[string]int, name string) (int, bool) {
+	if i, ok := header[strings.ToLower(name)]; ok {
+		return i, true
+	}
+	if i, err := strconv.Atoi(name); err == nil && i >= 0 {
+		return i, true
+	}
+	return 0, false
+}
+
+// jsonScalar renders a JSON string or number as a plain string.
+func jsonScalar(raw json.RawMessage) (string, bool) {
+	if len(raw) == 0 {
+		return "", false
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return strings.TrimSpace(s), true
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n.String(), true
+	}
+
+	return "", false
+}
+
+// jsonPoem decodes a poem field given either as a string or an array of lines.
+func jsonPoem(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 {
+		return "", errors.New("missing poem field")
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s, nil
+	}
+
+	var lines []string
+	if err := json.Unmarshal(raw, &lines); err == nil {
+		return strings.Join(lines, "\n"), nil
+	}
+
+	return "", errors.New("poem field must be a string or an array of strings")
+}
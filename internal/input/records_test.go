@@ -0,0 +1,169 @@
+package input
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParser_ParseCSV(t *testing.T) {
+	data := "id,author,poem\n" +
+		"a1,Basho,\"old pond\nfrog jumps in\nsplash\"\n" +
+		"a2,Buson,\"only two\nlines\"\n" +
+		"a3,Issa,\"one\ntwo\nthree\"\n"
+
+	records, err := New(false).ParseCSV(strings.NewReader(data), FieldMapping{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("Got %d records, want 3", len(records))
+	}
+
+	wantIDs := []string{"a1", "a2", "a3"}
+	for i, rec := range records {
+		if rec.ID != wantIDs[i] {
+			t.Errorf("Record %d ID = %q, want %q", i, rec.ID, wantIDs[i])
+		}
+	}
+
+	if records[0].Err != nil || records[0].Haiku == nil {
+		t.Fatalf("Record a1 should parse, got error %v", records[0].Err)
+	}
+	if records[0].Haiku.Lines[1] != "frog jumps in" {
+		t.Errorf("Record a1 line 2 = %q, want %q", records[0].Haiku.Lines[1], "frog jumps in")
+	}
+	if records[0].Author != "Basho" {
+		t.Errorf("Record a1 author = %q, want %q", records[0].Author, "Basho")
+	}
+
+	if records[1].Err == nil {
+		t.Error("Record a2 should have a per-record error")
+	}
+	if records[2].Err != nil {
+		t.Errorf("Record a3 should parse despite a2 failing, got %v", records[2].Err)
+	}
+}
+
+func TestParser_ParseCSV_Mapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		mapping FieldMapping
+		wantIDs []string
+		wantErr bool
+	}{
+		{
+			name:    "custom header names",
+			data:    "Entry,Writer,Text\n7,Ann,\"a\nb\nc\"\n",
+			mapping: FieldMapping{ID: "entry", Author: "writer", Poem: "text"},
+			wantIDs: []string{"7"},
+		},
+		{
+			name:    "numeric columns without header",
+			data:    "x1,\"a\nb\nc\"\nx2,\"d\ne\nf\"\n",
+			mapping: FieldMapping{ID: "0", Author: "9", Poem: "1"},
+			wantIDs: []string{"x1", "x2"},
+		},
+		{
+			name:    "missing id column falls back to ordinal",
+			data:    "poem\n\"a\nb\nc\"\n\"d\ne\nf\"\n",
+			mapping: FieldMapping{},
+			wantIDs: []string{"1", "2"},
+		},
+		{
+			name:    "unknown poem column",
+			data:    "id,body\n1,text\n",
+			mapping: FieldMapping{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := New(false).ParseCSV(strings.NewReader(tt.data), tt.mapping)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(records) != len(tt.wantIDs) {
+				t.Fatalf("Got %d records, want %d", len(records), len(tt.wantIDs))
+			}
+			for i, rec := range records {
+				if rec.ID != tt.wantIDs[i] {
+					t.Errorf("Record %d ID = %q, want %q", i, rec.ID, tt.wantIDs[i])
+				}
+				if rec.Err != nil {
+					t.Errorf("Record %d unexpected error: %v", i, rec.Err)
+				}
+			}
+		})
+	}
+}
+
+func TestParser_ParseJSONL(t *testing.T) {
+	data := `{"id": "p1", "author": "Basho", "poem": "old pond\nfrog jumps in\nsplash"}
+{"id": 2, "poem": ["first line", "second line", "third line"]}
+
+not json at all
+{"id": "p4", "poem": 42}
+{"id": "p5", "poem": "just one line"}
+`
+
+	records, err := New(false).ParseJSONL(strings.NewReader(data), FieldMapping{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(records) != 5 {
+		t.Fatalf("Got %d records, want 5", len(records))
+	}
+
+	tests := []struct {
+		id      string
+		wantErr bool
+	}{
+		{"p1", false},
+		{"2", false},
+		{"4", true}, // malformed line keyed by its line number
+		{"p4", true},
+		{"p5", true},
+	}
+
+	for i, tt := range tests {
+		rec := records[i]
+		if rec.ID != tt.id {
+			t.Errorf("Record %d ID = %q, want %q", i, rec.ID, tt.id)
+		}
+		if (rec.Err != nil) != tt.wantErr {
+			t.Errorf("Record %s error = %v, wantErr %t", rec.ID, rec.Err, tt.wantErr)
+		}
+	}
+
+	if records[1].Haiku == nil || records[1].Haiku.Lines[2] != "third line" {
+		t.Error("Array poem field should become the haiku lines")
+	}
+}
+
+func TestParser_ParseJSONL_Mapping(t *testing.T) {
+	data := `{"uid": "z", "by": "Issa", "body": "a\nb\nc"}`
+	mapping := FieldMapping{ID: "uid", Author: "by", Poem: "body"}
+
+	records, err := New(false).ParseJSONL(strings.NewReader(data), mapping)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(records) != 1 {
+		t.Fatalf("Got %d records, want 1", len(records))
+	}
+	if records[0].ID != "z" || records[0].Author != "Issa" || records[0].Err != nil {
+		t.Errorf("Got record %+v, want ID z by Issa without error", records[0])
+	}
+}
@@ -0,0 +1,372 @@
+// Package input provides incremental poem scanning for large batch inputs.
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format identifies how poems are laid out in a batch stream.
+type Format string
+
+const (
+	// FormatStanza separates poems by one or more blank lines.
+	FormatStanza Format = "stanza"
+	// FormatLines holds one poem per line, usually combined with autosplit.
+	FormatLines Format = "lines"
+	// FormatJSONL holds one JSON object per line.
+	FormatJSONL Format = "jsonl"
+	// FormatCSV holds one poem per CSV row.
+	FormatCSV Format = "csv"
+)
+
+// maxLineSize bounds a single input line so that one corrupt line cannot
+// exhaust memory. A longer line is skipped and reported on its record.
+const maxLineSize = 1024 * 1024
+
+// ParseFormat converts a format name into a Format.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(name))); f {
+	case FormatStanza, FormatLines, FormatJSONL, FormatCSV:
+		return f, nil
+	case "":
+		return FormatStanza, nil
+	default:
+		return "", fmt.Errorf("unknown input format %q (want stanza, lines, jsonl or csv)", name)
+	}
+}
+
+// Scanner reads poem records one at a time from a stream without
+// buffering the whole input. Each record is parsed independently, so a
+// malformed poem yields a Record with Err set and scanning continues.
+type Scanner struct {
+	parser  *Parser
+	format  Format
+	mapping FieldMapping
+
+	lines *lineReader
+	csv   *csv.Reader
+
+	ordinal int
+	header  map[string]int
+	pending []string
+	rec     Record
+	err     error
+	started bool
+}
+
+// NewScanner returns a Scanner that reads poems in the given format from r.
+// The mapping is used for the JSONL and CSV formats.
+func (p *Parser) NewScanner(r io.Reader, format Format, m FieldMapping) *Scanner {
+	s := &Scanner{parser: p, format: format, mapping: m.withDefaults()}
+	r = NewDecodingReader(r)
+
+	if format == FormatCSV {
+		s.csv = csv.NewReader(r)
+		s.csv.FieldsPerRecord = -1
+		s.csv.ReuseRecord = true
+	} else {
+		s.lines = &lineReader{r: bufio.NewReaderSize(r, 64*1024)}
+	}
+
+	return s
+}
+
+// Scan advances to the next record. It returns false at the end of input
+// or on a read error, which is then reported by Err.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	switch s.format {
+	case FormatLines:
+		return s.scanLine()
+	case FormatJSONL:
+		return s.scanJSONL()
+	case FormatCSV:
+		return s.scanCSV()
+	default:
+		return s.scanStanza()
+	}
+}
+
+// Record returns the most recent record read by Scan.
+func (s *Scanner) Record() Record {
+	return s.rec
+}
+
+// Err returns the first read error encountered, if any.
+func (s *Scanner) Err() error {
+	if s.err == io.EOF {
+		return nil
+	}
+	return s.err
+}
+
+// nextID returns the ordinal ID for the next record.
+func (s *Scanner) nextID() string {
+	s.ordinal++
+	return strconv.Itoa(s.ordinal)
+}
+
+// scanStanza reads the next blank-line separated block of text.
+func (s *Scanner) scanStanza() bool {
+	var block []string
+	var tooLong error
+	for s.lines.Scan() {
+		line := s.lines.Text()
+		if err := s.lines.TooLong(); err != nil {
+			if tooLong == nil {
+				tooLong = err
+			}
+			block = append(block, "")
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			if len(block) > 0 {
+				break
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+
+	if len(block) == 0 {
+		s.err = s.lines.Err()
+		if s.err == nil {
+			s.err = io.EOF
+		}
+		return false
+	}
+
+	s.rec = Record{ID: s.nextID()}
+	if tooLong != nil {
+		s.rec.Err = tooLong
+		return true
+	}
+	s.rec.Haiku, s.rec.Err = s.parser.ParseFromString(strings.Join(block, "\n"))
+	return true
+}
+
+// scanLine reads the next non-empty line as a whole poem.
+func (s *Scanner) scanLine() bool {
+	for s.lines.Scan() {
+		line := s.lines.Text()
+		if err := s.lines.TooLong(); err != nil {
+			s.rec = Record{ID: s.nextID(), Err: err}
+			return true
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		s.rec = Record{ID: s.nextID()}
+		s.rec.Haiku, s.rec.Err = s.parser.ParseFromString(line)
+		return true
+	}
+
+	s.err = s.lines.Err()
+	if s.err == nil {
+		s.err = io.EOF
+	}
+	return false
+}
+
+// scanJSONL decodes the next non-empty line as a JSON record.
+func (s *Scanner) scanJSONL() bool {
+	for s.lines.Scan() {
+		lineNo := s.lines.LineNo()
+		if err := s.lines.TooLong(); err != nil {
+			s.rec = Record{ID: strconv.Itoa(lineNo), Err: err}
+			return true
+		}
+		text := strings.TrimSpace(s.lines.Text())
+		if text == "" {
+			continue
+		}
+
+		s.rec = Record{ID: strconv.Itoa(lineNo)}
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(text), &obj); err != nil {
+			s.rec.Err = fmt.Errorf("line %d: %w", lineNo, err)
+			return true
+		}
+
+		if id, ok := jsonScalar(obj[s.mapping.ID]); ok && id != "" {
+			s.rec.ID = id
+		}
+		if author, ok := jsonScalar(obj[s.mapping.Author]); ok {
+			s.rec.Author = author
+		}
+
+		poem, err := jsonPoem(obj[s.mapping.Poem])
+		if err != nil {
+			s.rec.Err = fmt.Errorf("record %s: %w", s.rec.ID, err)
+		} else {
+			s.parseAuthored(poem)
+		}
+		return true
+	}
+
+	s.err = s.lines.Err()
+	if s.err == nil {
+		s.err = io.EOF
+	}
+	return false
+}
+
+// scanCSV reads the next CSV row, consuming the header on first use.
+func (s *Scanner) scanCSV() bool {
+	if !s.started {
+		s.started = true
+		if !s.readCSVHeader() {
+			return false
+		}
+	}
+
+	var row []string
+	if s.pending != nil {
+		row, s.pending = s.pending, nil
+	} else {
+		var err error
+		row, err = s.csv.Read()
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			// The reader resumes after a malformed row.
+			s.rec = Record{ID: s.nextID(), Err: err}
+			return true
+		}
+		if err != nil {
+			s.err = err
+			return false
+		}
+	}
+
+	m := s.mapping
+	s.rec = Record{ID: s.nextID()}
+	if col, ok := resolveColumn(s.header, m.ID); ok && col < len(row) && strings.TrimSpace(row[col]) != "" {
+		s.rec.ID = strings.TrimSpace(row[col])
+	}
+	if col, ok := resolveColumn(s.header, m.Author); ok && col < len(row) {
+		s.rec.Author = strings.TrimSpace(row[col])
+	}
+
+	col, _ := resolveColumn(s.header, m.Poem)
+	if col >= len(row) {
+		s.rec.Err = fmt.Errorf("record %s: missing poem column", s.rec.ID)
+	} else {
+		s.parseAuthored(row[col])
+	}
+	return true
+}
+
+// readCSVHeader reads the first row and decides whether it is a header.
+// A poem column given by index means the file has no header, so the first
+// row is kept as data; a poem column given by name must be in the header.
+func (s *Scanner) readCSVHeader() bool {
+	first, err := s.csv.Read()
+	if err != nil {
+		s.err = err
+		return false
+	}
+
+	if i, err := strconv.Atoi(s.mapping.Poem); err == nil && i >= 0 {
+		s.pending = append([]string(nil), first...)
+		s.header = map[string]int{}
+		return true
+	}
+
+	s.header = make(map[string]int, len(first))
+	for i, name := range first {
+		s.header[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := resolveColumn(s.header, s.mapping.Poem); !ok {
+		s.err = fmt.Errorf("poem column %q not found in CSV header", s.mapping.Poem)
+		return false
+	}
+	return true
+}
+
+// parseAuthored parses the record's poem and attributes it to the record's author.
+func (s *Scanner) parseAuthored(text string) {
+	s.rec.Haiku, s.rec.Err = s.parser.ParseFromString(text)
+	if s.rec.Haiku != nil {
+		s.rec.Haiku.Author = s.rec.Author
+	}
+}
+
+// lineReader reads a stream line by line like bufio.Scanner, but skips a
+// line longer than maxLineSize and reports it through TooLong instead of
+// stopping, so one corrupt line does not end a batch.
+type lineReader struct {
+	r       *bufio.Reader
+	line    []byte
+	lineNo  int
+	tooLong bool
+	err     error
+}
+
+// Scan reads the next line. It returns false at the end of input or on a
+// read error, which is then reported by Err.
+func (l *lineReader) Scan() bool {
+	if l.err != nil {
+		return false
+	}
+	l.line, l.tooLong = l.line[:0], false
+	read := false
+	for {
+		chunk, err := l.r.ReadSlice('\n')
+		read = read || len(chunk) > 0
+		if !l.tooLong {
+			l.line = append(l.line, chunk...)
+			if len(bytes.TrimRight(l.line, "\r\n")) > maxLineSize {
+				l.line, l.tooLong = l.line[:0], true
+			}
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil && (err != io.EOF || !read) {
+			l.err = err
+			return false
+		}
+		break
+	}
+	l.lineNo++
+	l.line = bytes.TrimSuffix(bytes.TrimSuffix(l.line, []byte("\n")), []byte("\r"))
+	return true
+}
+
+// Text returns the line read by Scan without its line ending, or "" for
+// a line that was too long.
+func (l *lineReader) Text() string {
+	return string(l.line)
+}
+
+// LineNo returns the 1-based number of the line read by Scan.
+func (l *lineReader) LineNo() int {
+	return l.lineNo
+}
+
+// TooLong returns an error when the line read by Scan exceeded maxLineSize.
+func (l *lineReader) TooLong() error {
+	if !l.tooLong {
+		return nil
+	}
+	return fmt.Errorf("line %d: longer than %d bytes", l.lineNo, maxLineSize)
+}
+
+// Err returns the first read error, or nil at the end of input.
+func (l *lineReader) Err() error {
+	if l.err == io.EOF {
+		return nil
+	}
+	return l.err
+}
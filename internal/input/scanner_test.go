@@ -0,0 +1,180 @@
+package input
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScanner_Formats(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		format    Format
+		autosplit bool
+		wantIDs   []string
+		wantErrs  []bool
+	}{
+		{
+			name:     "stanzas separated by blank lines",
+			input:    "a\nb\nc\n\n\nd\ne\nf\n\nonly one\n",
+			format:   FormatStanza,
+			wantIDs:  []string{"1", "2", "3"},
+			wantErrs: []bool{false, false, true},
+		},
+		{
+			name:      "one poem per line with autosplit",
+			input:     "old pond / frog jumps in / splash\n\nno separators here\n",
+			format:    FormatLines,
+			autosplit: true,
+			wantIDs:   []string{"1", "2"},
+			wantErrs:  []bool{false, true},
+		},
+		{
+			name:     "jsonl",
+			input:    `{"id":"x","poem":"a\nb\nc"}` + "\n" + `{"id":"y"}` + "\n",
+			format:   FormatJSONL,
+			wantIDs:  []string{"x", "y"},
+			wantErrs: []bool{false, true},
+		},
+		{
+			name:     "csv",
+			input:    "id,poem\nr1,\"a\nb\nc\"\nr2,\"a\"\n",
+			format:   FormatCSV,
+			wantIDs:  []string{"r1", "r2"},
+			wantErrs: []bool{false, true},
+		},
+		{
+			name:     "csv with a malformed row",
+			input:    "id,poem\nr1,\"a\nb\nc\"\nr2,a \"b\" c\nr3,\"a\nb\nc\"\n",
+			format:   FormatCSV,
+			wantIDs:  []string{"r1", "2", "r3"},
+			wantErrs: []bool{false, true, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(tt.autosplit).NewScanner(strings.NewReader(tt.input), tt.format, FieldMapping{})
+
+			var got []Record
+			for s.Scan() {
+				got = append(got, s.Record())
+			}
+			if err := s.Err(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("Got %d records, want %d", len(got), len(tt.wantIDs))
+			}
+			for i, rec := range got {
+				if rec.ID != tt.wantIDs[i] {
+					t.Errorf("Record %d ID = %q, want %q", i, rec.ID, tt.wantIDs[i])
+				}
+				if (rec.Err != nil) != tt.wantErrs[i] {
+					t.Errorf("Record %d error = %v, wantErr %t", i, rec.Err, tt.wantErrs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestScanner_LongLine(t *testing.T) {
+	long := strings.Repeat("x", maxLineSize+1)
+	tests := []struct {
+		name     string
+		input    string
+		format   Format
+		wantIDs  []string
+		wantErrs []bool
+	}{
+		{"stanza", "a\nb\nc\n\na\n" + long + "\nc\n\nd\ne\nf\n", FormatStanza, []string{"1", "2", "3"}, []bool{false, true, false}},
+		{"lines", "a / b / c\n" + long + "\r\nd / e / f", FormatLines, []string{"1", "2", "3"}, []bool{false, true, false}},
+		{"jsonl", long + "\n" + `{"id":"y","poem":"a\nb\nc"}` + "\n", FormatJSONL, []string{"1", "y"}, []bool{true, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(true).NewScanner(strings.NewReader(tt.input), tt.format, FieldMapping{})
+			var got []Record
+			for s.Scan() {
+				got = append(got, s.Record())
+			}
+			if err := s.Err(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("Got %d records, want %d", len(got), len(tt.wantIDs))
+			}
+			for i, rec := range got {
+				if rec.ID != tt.wantIDs[i] || (rec.Err != nil) != tt.wantErrs[i] {
+					t.Errorf("Record %d = %q, %v, want %q with error %t", i, rec.ID, rec.Err, tt.wantIDs[i], tt.wantErrs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestScanner_CSVWithoutHeader(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		mapping   FieldMapping
+		wantPoems []string
+	}{
+		{"cell equal to the index", "1,\"a\nb\nc\"\n2,\"d\ne\nf\"\n", FieldMapping{ID: "0", Poem: "1"}, []string{"a", "d"}},
+		{"second row named like the default", "x,\"a\nb\nc\"\npoem,\"d\ne\nf\"\n", FieldMapping{Poem: "1"}, []string{"a", "d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recs, err := New(false).ParseCSV(strings.NewReader(tt.input), tt.mapping)
+			if err != nil {
+				t.Fatalf("ParseCSV() error = %v", err)
+			}
+			if len(recs) != len(tt.wantPoems) {
+				t.Fatalf("Got %d records, want %d", len(recs), len(tt.wantPoems))
+			}
+			for i, rec := range recs {
+				if rec.Err != nil || rec.Haiku.Lines[0] != tt.wantPoems[i] {
+					t.Errorf("Record %d = %+v, want a poem starting %q", i, rec, tt.wantPoems[i])
+				}
+			}
+		})
+	}
+}
+
+func TestScanner_CSVMissingPoemColumn(t *testing.T) {
+	s := New(false).NewScanner(strings.NewReader("id,text\n1,a\n"), FormatCSV, FieldMapping{})
+	if s.Scan() {
+		t.Fatal("Scan should fail when the poem column is missing")
+	}
+	if s.Err() == nil {
+		t.Error("Expected an error for the missing poem column")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Format
+		wantErr bool
+	}{
+		{"", FormatStanza, false},
+		{"stanza", FormatStanza, false},
+		{"LINES", FormatLines, false},
+		{"jsonl", FormatJSONL, false},
+		{"csv", FormatCSV, false},
+		{"xml", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFormat(%q) error = %v, wantErr %t", tt.name, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseFormat(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+// Package journal provides a file-backed store of haiku drafts and their analyses.
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thornzero/haikugo/internal/analyzer"
+	"github.com/thornzero/haikugo/internal/haiku"
+	"github.com/thornzero/haikugo/internal/project"
+)
+
+// DefaultPath is the project-relative location of the journal.
+const DefaultPath = project.Dir + "/journal.jsonl"
+
+// Draft is a poem to save, with its metadata and analysis.
+type Draft struct {
+	Lines   []string
+	Title   string
+	Author  string
+	Tags    []string
+	Note    string
+	Metrics *haiku.Metrics
+}
+
+// Revision is one saved version of a poem.
+type Revision struct {
+	Number  int            `json:"number"`
+	Saved   time.Time      `json:"saved"`
+	Lines   []string       `json:"lines"`
+	Note    string         `json:"note,omitempty"`
+	Metrics *haiku.Metrics `json:"metrics,omitempty"`
+}
+
+// Entry is a poem in the journal with all of its revisions, oldest first.
+// Title, author and tags are those of the latest revision.
+type Entry struct {
+	ID        string     `json:"id"`
+	Title     string     `json:"title,omitempty"`
+	Author    string     `json:"author,omitempty"`
+	Tags      []string   `json:"tags,omitempty"`
+	Created   time.Time  `json:"created"`
+	Updated   time.Time  `json:"updated"`
+	Revisions []Revision `json:"revisions"`
+}
+
+// Latest returns the entry's most recent revision.
+func (e *Entry) Latest() Revision {
+	return e.Revisions[len(e.Revisions)-1]
+}
+
+// record is one line of the journal file: a revision of an entry with the
+// entry's metadata at the time it was saved.
+type record struct {
+	ID     string   `json:"id"`
+	Title  string   `json:"title,omitempty"`
+	Author string   `json:"author,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+	Revision
+}
+
+// Journal is an append-only store of entries kept in a JSON Lines file,
+// one line per saved revision.
+type Journal struct {
+	path    string
+	entries []*Entry
+	byID    map[string]*Entry
+	now     func() time.Time
+}
+
+// Open reads the journal at path. A missing file is an empty journal,
+// created by the first Add.
+func Open(path string) (*Journal, error) {
+	j := &Journal{path: path, byID: make(map[string]*Entry), now: time.Now}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	lineNo := 0
+	for sc.Scan() {
+		lineNo++
+		if strings.TrimSpace(sc.Text()) == "" {
+			continue
+		}
+		var rec record
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("%s: line %d: %w", path, lineNo, err)
+		}
+		if rec.ID == "" || len(rec.Lines) == 0 {
+			return nil, fmt.Errorf("%s: line %d: revision without an ID or lines", path, lineNo)
+		}
+		j.apply(rec)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return j, nil
+}
+
+// apply adds a record to the in-memory entries.
+func (j *Journal) apply(rec record) {
+	e := j.byID[rec.ID]
+	if e == nil {
+		e = &Entry{ID: rec.ID, Created: rec.Saved}
+		j.byID[rec.ID] = e
+		j.entries = append(j.entries, e)
+	}
+	e.Title, e.Author, e.Tags = rec.Title, rec.Author, rec.Tags
+	e.Updated = rec.Saved
+	e.Revisions = append(e.Revisions, rec.Revision)
+}
+
+// Path returns the file the journal is stored in.
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// Add saves a draft as a new entry, numbered after the existing ones.
+func (j *Journal) Add(d Draft) (*Entry, error) {
+	id := 1
+	for _, e := range j.entries {
+		if n, err := strconv.Atoi(e.ID); err == nil && n >= id {
+			id = n + 1
+		}
+	}
+	return j.save(strconv.Itoa(id), d)
+}
+
+// Revise saves a draft as the next revision of the entry with the given ID.
+// Empty metadata keeps that of the previous revision.
+func (j *Journal) Revise(id string, d Draft) (*Entry, error) {
+	e, ok := j.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("no journal entry %q", id)
+	}
+	if d.Title == "" {
+		d.Title = e.Title
+	}
+	if d.Author == "" {
+		d.Author = e.Author
+	}
+	if d.Tags == nil {
+		d.Tags = e.Tags
+	}
+	return j.save(id, d)
+}
+
+// save appends a revision of entry id to the journal file.
+func (j *Journal) save(id string, d Draft) (*Entry, error) {
+	if len(d.Lines) == 0 {
+		return nil, errors.New("cannot save an empty poem")
+	}
+	number := 1
+	if e := j.byID[id]; e != nil {
+		number = len(e.Revisions) + 1
+	}
+	rec := record{
+		ID:     id,
+		Title:  strings.TrimSpace(d.Title),
+		Author: strings.TrimSpace(d.Author),
+		Tags:   normalizeTags(d.Tags),
+		Revision: Revision{
+			Number:  number,
+			Saved:   j.now().UTC().Truncate(time.Second),
+			Lines:   d.Lines,
+			Note:    strings.TrimSpace(d.Note),
+			Metrics: d.Metrics,
+		},
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	j.apply(rec)
+	return j.byID[id], nil
+}
+
+// normalizeTags lower-cases and trims tags, dropping empty and repeated ones.
+func normalizeTags(tags []string) []string {
+	var result []string
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t != "" && !slices.Contains(result, t) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// Get returns the entry with the given ID.
+func (j *Journal) Get(id string) (*Entry, bool) {
+	e, ok := j.byID[id]
+	return e, ok
+}
+
+// Entries returns all entries in the order they were created.
+func (j *Journal) Entries() []*Entry {
+	return slices.Clone(j.entries)
+}
+
+// Len returns the number of entries.
+func (j *Journal) Len() int {
+	return len(j.entries)
+}
+
+// Query selects entries by their latest revision. Zero fields match every
+// entry.
+type Query struct {
+	Text     string    // words that must all start a word of the lines, title, note or tags
+	Season   string    // season of one of the season words
+	Form     string    // syllable pattern, such as "5-7-5"
+	Valid    *bool     // whether the poem is valid 5-7-5 within its analysis tolerance
+	Tag      string    // a tag the entry has
+	Since    time.Time // saved at or after
+	Until    time.Time // saved before
+	MinScore float64   // overall score at least
+	MaxScore float64   // overall score at most; 0 means no limit
+}
+
+// Search returns the entries whose latest revision matches q, most
+// recently saved first.
+func (j *Journal) Search(q Query) ([]*Entry, error) {
+	if q.Season != "" {
+		season, err := analyzer.ParseSeason(q.Season)
+		if err != nil {
+			return nil, err
+		}
+		q.Season = season
+	}
+	if q.Form != "" {
+		form, err := haiku.ParseForm(q.Form)
+		if err != nil {
+			return nil, err
+		}
+		q.Form = form
+	}
+	terms := strings.Fields(strings.ToLower(strings.Join(analyzer.ExtractWords(q.Text), " ")))
+	tag := strings.ToLower(strings.TrimSpace(q.Tag))
+
+	var result []*Entry
+	for _, e := range j.entries {
+		rev := e.Latest()
+		m := rev.Metrics
+		switch {
+		case tag != "" && !slices.Contains(e.Tags, tag),
+			!q.Since.IsZero() && rev.Saved.Before(q.Since),
+			!q.Until.IsZero() && !rev.Saved.Before(q.Until),
+			len(terms) > 0 && !matchesText(e, rev, terms):
+			continue
+		case q.Season == "" && q.Form == "" && q.Valid == nil && q.MinScore == 0 && q.MaxScore == 0:
+		case m == nil,
+			q.Season != "" && !slices.ContainsFunc(m.SeasonWords, func(w string) bool { return analyzer.SeasonOf(w) == q.Season }),
+			q.Form != "" && haiku.Form(m.LineSyllables) != q.Form,
+			q.Valid != nil && m.Valid575 != *q.Valid,
+			m.Score.Overall < q.MinScore,
+			q.MaxScore > 0 && m.Score.Overall > q.MaxScore:
+			continue
+		}
+		result = append(result, e)
+	}
+	slices.SortStableFunc(result, func(a, b *Entry) int {
+		return b.Updated.Compare(a.Updated)
+	})
+	return result, nil
+}
+
+// matchesText reports whether every term starts a word of the revision's
+// lines or note, or of the entry's title or tags.
+func matchesText(e *Entry, rev Revision, terms []string) bool {
+	text := strings.Join(append(append([]string{e.Title, rev.Note}, rev.Lines...), e.Tags...), " ")
+	var words []string
+	for _, w := range analyzer.ExtractWords(text) {
+		words = append(words, strings.ToLower(w))
+	}
+	for _, term := range terms {
+		if !slices.ContainsFunc(words, func(w string) bool { return strings.HasPrefix(w, term) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// Find looks for DefaultPath in dir and each of its parents and returns
+// the first match.
+func Find(dir string) (string, bool) {
+	return project.Find(dir, DefaultPath)
+}
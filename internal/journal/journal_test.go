@@ -0,0 +1,193 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thornzero/haikugo/internal/analyzer"
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// draft analyzes a poem for saving.
+func draft(text string, tags ...string) Draft {
+	lines := strings.Split(text, "\n")
+	return Draft{Lines: lines, Tags: tags, Metrics: analyzer.New(0).Analyze(haiku.NewHaiku(lines))}
+}
+
+// clock returns a time source starting at start and advancing a day per call.
+func clock(start time.Time) func() time.Time {
+	now := start.Add(-24 * time.Hour)
+	return func() time.Time {
+		now = now.Add(24 * time.Hour)
+		return now
+	}
+}
+
+func openJournal(t *testing.T) (*Journal, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "nested", "journal.jsonl")
+	j, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	j.now = clock(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+	return j, path
+}
+
+func TestJournal_AddRevise(t *testing.T) {
+	j, path := openJournal(t)
+
+	first := draft("an old silent pond\na frog jumps into the pond\nsplash silence again", "Basho", " frogs ", "basho")
+	first.Title, first.Author = "Old pond", "Basho"
+	e, err := j.Add(first)
+	if err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	if e.ID != "1" || !reflect.DeepEqual(e.Tags, []string{"basho", "frogs"}) {
+		t.Errorf("Add() = ID %q, tags %q, want ID 1 with normalized tags", e.ID, e.Tags)
+	}
+
+	if _, err := j.Add(draft("the autumn moon shines\nover the pond a cold wind\nthe frog sleeps alone")); err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	revised := draft("an old quiet pond\na frog leaps into the pond\nsplash! silence again")
+	revised.Note = "quieter"
+	e, err = j.Revise("1", revised)
+	if err != nil {
+		t.Fatalf("Revise() error: %v", err)
+	}
+	if len(e.Revisions) != 2 || e.Latest().Number != 2 || e.Latest().Note != "quieter" {
+		t.Errorf("Revise() revisions = %+v, want a second revision", e.Revisions)
+	}
+	if e.Title != "Old pond" || e.Author != "Basho" || len(e.Tags) != 2 {
+		t.Errorf("Revise() metadata = %q %q %q, want it kept", e.Title, e.Author, e.Tags)
+	}
+	if !e.Updated.After(e.Created) {
+		t.Errorf("Updated %v is not after Created %v", e.Updated, e.Created)
+	}
+
+	if _, err := j.Revise("9", revised); err == nil {
+		t.Error("Revise() of a missing entry should fail")
+	}
+	if _, err := j.Add(Draft{}); err == nil {
+		t.Error("Add() of an empty poem should fail")
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if !reflect.DeepEqual(reopened.Entries(), j.Entries()) {
+		t.Error("Reopened journal differs from the saved one")
+	}
+	if got, ok := reopened.Get("1"); !ok || got.Latest().Lines[0] != "an old quiet pond" {
+		t.Errorf("Get(1) = %+v, %v, want the revised poem", got, ok)
+	}
+	if e, err := reopened.Add(draft("a\nb\nc")); err != nil || e.ID != "3" {
+		t.Errorf("Add() after reopening = %v, %v, want ID 3", e, err)
+	}
+}
+
+func TestOpen_Errors(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{
+		"bad.jsonl":     "not json\n",
+		"noid.jsonl":    `{"number":1,"lines":["a","b","c"]}` + "\n",
+		"nolines.jsonl": `{"id":"1","number":1}` + "\n",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Open(path); err == nil || !strings.Contains(err.Error(), path) {
+			t.Errorf("Open(%s) error = %v, want one naming the file", name, err)
+		}
+	}
+}
+
+func TestJournal_Search(t *testing.T) {
+	j, _ := openJournal(t)
+	poems := []Draft{
+		draft("an old silent pond\na frog jumps into the pond\nsplash silence again", "classic"),  // 1: 5-7-5, no season
+		draft("cicadas shrilling\ninto the rocks it seeps\nthe summer heat", "classic", "summer"), // 2: summer
+		draft("a cold winter night\nthe snow falls into the pond\nsilence in the pines"),          // 3: winter
+		draft("snow\non the frog\nsilent"), // 4: short
+	}
+	poems[0].Title = "Old pond"
+	poems[2].Note = "first frost draft"
+	for _, d := range poems {
+		if _, err := j.Add(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	valid, invalid := true, false
+	score := poems[2].Metrics.Score.Overall
+
+	tests := []struct {
+		name string
+		q    Query
+		want []string
+	}{
+		{"all, newest first", Query{}, []string{"4", "3", "2", "1"}},
+		{"text", Query{Text: "pond"}, []string{"3", "1"}},
+		{"text prefix and title", Query{Text: "old fro"}, []string{"1"}},
+		{"text in note", Query{Text: "FROST"}, []string{"3"}},
+		{"tag", Query{Tag: "Classic"}, []string{"2", "1"}},
+		{"season", Query{Season: "winter"}, []string{"4", "3"}},
+		{"season alias", Query{Season: "summer"}, []string{"2"}},
+		{"form", Query{Form: "5 7 5"}, []string{"3", "1"}},
+		{"valid", Query{Valid: &valid}, []string{"3", "1"}},
+		{"invalid", Query{Valid: &invalid}, []string{"4", "2"}},
+		{"since", Query{Since: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)}, []string{"4", "3"}},
+		{"until", Query{Until: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)}, []string{"1"}},
+		{"score", Query{MinScore: score, MaxScore: score}, []string{"3"}},
+		{"combined", Query{Text: "pond", Valid: &valid, Season: "winter"}, []string{"3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := j.Search(tt.q)
+			if err != nil {
+				t.Fatalf("Search() error: %v", err)
+			}
+			var ids []string
+			for _, e := range entries {
+				ids = append(ids, e.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("Search(%+v) = %v, want %v", tt.q, ids, tt.want)
+			}
+		})
+	}
+
+	for _, q := range []Query{{Season: "monsoon"}, {Form: "five-seven-five"}} {
+		if _, err := j.Search(q); err == nil {
+			t.Errorf("Search(%+v) should fail", q)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, DefaultPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, ok := Find(sub); !ok || got != path {
+		t.Errorf("Find(%q) = %q, %v, want %q", sub, got, ok, path)
+	}
+	if _, ok := Find(t.TempDir()); ok {
+		t.Error("Find() outside a project should fail")
+	}
+}
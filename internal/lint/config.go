@@ -0,0 +1,104 @@
+// Package lint provides rule configuration files.
+package lint
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/thornzero/haikugo/internal/project"
+)
+
+// DefaultConfigPath is the project-relative location of the rules file.
+const DefaultConfigPath = project.Dir + "/lint.tsv"
+
+// Config overrides the default severity of rules, or disables them.
+type Config struct {
+	severities map[string]Severity // rule ID -> severity
+}
+
+// NewConfig creates a Config that keeps every rule's default severity.
+func NewConfig() *Config {
+	return &Config{severities: make(map[string]Severity)}
+}
+
+// Set sets the severity of the rule with the given ID; SeverityOff
+// disables it.
+func (c *Config) Set(id string, s Severity) error {
+	id = strings.ToLower(strings.TrimSpace(id))
+	if _, ok := Lookup(id); !ok {
+		return fmt.Errorf("unknown rule %q", id)
+	}
+	if _, err := ParseSeverity(string(s)); err != nil {
+		return err
+	}
+	c.severities[id] = s
+	return nil
+}
+
+// Severity returns the configured severity of r, or its default when the
+// config is nil or does not mention it.
+func (c *Config) Severity(r Rule) Severity {
+	if c != nil {
+		if s, ok := c.severities[r.ID]; ok {
+			return s
+		}
+	}
+	return r.Severity
+}
+
+// ReadConfig parses a rules file, one "rule<TAB>severity" per line, where
+// severity is off, info, warning or error. Blank lines and lines starting
+// with '#' are ignored.
+func ReadConfig(r io.Reader) (*Config, error) {
+	c := NewConfig()
+	sc := bufio.NewScanner(r)
+	lineNo := 0
+
+	for sc.Scan() {
+		lineNo++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: want rule<TAB>severity, got %q", lineNo, line)
+		}
+		s, err := ParseSeverity(fields[1])
+		if err == nil {
+			err = c.Set(fields[0], s)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// LoadConfig reads a rules file from path.
+func LoadConfig(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	c, err := ReadConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+// FindConfig looks for DefaultConfigPath in dir and its parents.
+func FindConfig(dir string) (string, bool) {
+	return project.Find(dir, DefaultConfigPath)
+}
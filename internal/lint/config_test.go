@@ -0,0 +1,78 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]Severity
+		wantErr bool
+	}{
+		{"entries", "# rule\tseverity\nsimile\toff\n\nmissing-kigo\terror\n", map[string]Severity{"simile": SeverityOff, "missing-kigo": SeverityError, "end-rhyme": SeverityInfo}, false},
+		{"missing severity", "simile\n", nil, true},
+		{"unknown rule", "metaphor\toff\n", nil, true},
+		{"unknown severity", "simile\tfatal\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ReadConfig(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for id, want := range tt.want {
+				r, _ := Lookup(id)
+				if got := c.Severity(r); got != want {
+					t.Errorf("Severity(%s) = %q, want %q", id, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_Nil(t *testing.T) {
+	var c *Config
+	r, _ := Lookup("syllable-mismatch")
+	if got := c.Severity(r); got != SeverityError {
+		t.Errorf("nil Config Severity() = %q, want error", got)
+	}
+}
+
+func TestLoadFindConfig(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, DefaultConfigPath)
+	nested := filepath.Join(root, "poems", "spring")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := FindConfig(nested); ok {
+		t.Fatal("FindConfig() found a rules file before one was written")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("end-rhyme\twarning\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	found, ok := FindConfig(nested)
+	if !ok || found != path {
+		t.Fatalf("FindConfig() = %q, %v, want %q, true", found, ok, path)
+	}
+
+	c, err := LoadConfig(found)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	r, _ := Lookup("end-rhyme")
+	if got := c.Severity(r); got != SeverityWarning {
+		t.Errorf("Severity(end-rhyme) = %q, want warning", got)
+	}
+}
@@ -0,0 +1,135 @@
+// Package lint provides haiku craft checks built on analysis metrics.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// Severity ranks how serious a finding is.
+type Severity string
+
+// Severities from least to most serious. SeverityOff disables a rule.
+const (
+	SeverityOff     Severity = "off"
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// severityRanks orders the severities for threshold comparisons.
+var severityRanks = map[Severity]int{SeverityOff: 0, SeverityInfo: 1, SeverityWarning: 2, SeverityError: 3}
+
+// ParseSeverity returns the severity with the given name.
+func ParseSeverity(name string) (Severity, error) {
+	s := Severity(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := severityRanks[s]; !ok {
+		return "", fmt.Errorf("unknown severity %q (want off, info, warning or error)", name)
+	}
+	return s, nil
+}
+
+// AtLeast reports whether s is as serious as threshold. Nothing reaches an
+// off threshold.
+func (s Severity) AtLeast(threshold Severity) bool {
+	return threshold != SeverityOff && severityRanks[s] >= severityRanks[threshold]
+}
+
+// Rule is a registered check. Check reports the rule's findings for an
+// analyzed haiku; the linter fills in the rule ID, the configured severity
+// and, when a finding has none, the rule's Message.
+type Rule struct {
+	ID       string
+	Severity Severity
+	Message  string
+	Check    func(h *haiku.Haiku, m *haiku.Metrics) []Finding
+}
+
+// Finding is a rule violation tied to a position in the source.
+type Finding struct {
+	Rule     string     `json:"rule"`
+	Severity Severity   `json:"severity"`
+	Message  string     `json:"message"`
+	Text     string     `json:"text,omitempty"`
+	Span     haiku.Span `json:"span"`
+}
+
+// registry holds the registered rules by ID.
+var registry = map[string]Rule{}
+
+// Register adds a rule to the registry. It panics if the rule is
+// incomplete or its ID is already registered.
+func Register(r Rule) {
+	if r.ID == "" || r.Check == nil || r.Severity == SeverityOff {
+		panic(fmt.Sprintf("lint: incomplete rule %q", r.ID))
+	}
+	if _, err := ParseSeverity(string(r.Severity)); err != nil {
+		panic(fmt.Sprintf("lint: rule %q: %v", r.ID, err))
+	}
+	if _, ok := registry[r.ID]; ok {
+		panic(fmt.Sprintf("lint: rule %q registered twice", r.ID))
+	}
+	registry[r.ID] = r
+}
+
+// Rules returns the registered rules sorted by ID.
+func Rules() []Rule {
+	rules := make([]Rule, 0, len(registry))
+	for _, r := range registry {
+		rules = append(rules, r)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules
+}
+
+// Lookup returns the registered rule with the given ID.
+func Lookup(id string) (Rule, bool) {
+	r, ok := registry[id]
+	return r, ok
+}
+
+// Lint runs every enabled rule over an analyzed haiku and returns the
+// findings in source order. A nil config uses the default severities, and
+// nil metrics, from a poem that could not be analyzed, have no findings.
+func Lint(h *haiku.Haiku, m *haiku.Metrics, c *Config) []Finding {
+	findings := []Finding{}
+	if m == nil {
+		return findings
+	}
+	for _, r := range Rules() {
+		severity := c.Severity(r)
+		if severity == SeverityOff {
+			continue
+		}
+		for _, f := range r.Check(h, m) {
+			f.Rule, f.Severity = r.ID, severity
+			if f.Message == "" {
+				f.Message = r.Message
+			}
+			findings = append(findings, f)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Span.Start != findings[j].Span.Start {
+			return findings[i].Span.Start < findings[j].Span.Start
+		}
+		return findings[i].Rule < findings[j].Rule
+	})
+	return findings
+}
+
+// MaxSeverity returns the most serious severity among findings, or
+// SeverityOff when there are none.
+func MaxSeverity(findings []Finding) Severity {
+	worst := SeverityOff
+	for _, f := range findings {
+		if severityRanks[f.Severity] > severityRanks[worst] {
+			worst = f.Severity
+		}
+	}
+	return worst
+}
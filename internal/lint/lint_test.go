@@ -0,0 +1,131 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/thornzero/haikugo/internal/analyzer"
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// analyze parses and analyzes a three-line poem for linting.
+func analyze(source string) (*haiku.Haiku, *haiku.Metrics) {
+	h := haiku.FromSource(source, splitLines(source))
+	return h, analyzer.New(0).Analyze(h)
+}
+
+func splitLines(s string) []string {
+	var lines []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			lines = append(lines, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(lines, s[start:])
+}
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Severity
+		wantErr bool
+	}{
+		{"error", SeverityError, false},
+		{" Warning ", SeverityWarning, false},
+		{"info", SeverityInfo, false},
+		{"off", SeverityOff, false},
+		{"fatal", "", true},
+	}
+
+	for _, tt := range tests {
+		s, err := ParseSeverity(tt.name)
+		if s != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("ParseSeverity(%q) = %q, %v, want %q, error %v", tt.name, s, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSeverity_AtLeast(t *testing.T) {
+	tests := []struct {
+		s, threshold Severity
+		want         bool
+	}{
+		{SeverityError, SeverityWarning, true},
+		{SeverityWarning, SeverityWarning, true},
+		{SeverityInfo, SeverityWarning, false},
+		{SeverityError, SeverityOff, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.s.AtLeast(tt.threshold); got != tt.want {
+			t.Errorf("%q.AtLeast(%q) = %v, want %v", tt.s, tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestRegister_Panics(t *testing.T) {
+	check := func(*haiku.Haiku, *haiku.Metrics) []Finding { return nil }
+	for _, r := range []Rule{
+		{ID: "", Severity: SeverityInfo, Check: check},
+		{ID: "no-check", Severity: SeverityInfo},
+		{ID: "bad-severity", Severity: "fatal", Check: check},
+		{ID: "simile", Severity: SeverityInfo, Check: check},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) did not panic", r.ID)
+				}
+			}()
+			Register(r)
+		}()
+	}
+}
+
+func TestRules(t *testing.T) {
+	rules := Rules()
+	for i := 1; i < len(rules); i++ {
+		if rules[i-1].ID >= rules[i].ID {
+			t.Errorf("Rules() not sorted: %q before %q", rules[i-1].ID, rules[i].ID)
+		}
+	}
+	for _, r := range rules {
+		if r.Message == "" || r.Severity == SeverityOff {
+			t.Errorf("rule %q has no message or default severity", r.ID)
+		}
+	}
+}
+
+func TestLint_Config(t *testing.T) {
+	h, m := analyze("I feel so sad\nmy heart is heavy tonight\nfrogs jump in the pond")
+
+	c := NewConfig()
+	if err := c.Set("telling-emotion", SeverityError); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Set("first-person", SeverityOff); err != nil {
+		t.Fatal(err)
+	}
+
+	findings := Lint(h, m, c)
+	seen := map[string]Severity{}
+	for i, f := range findings {
+		seen[f.Rule] = f.Severity
+		if i > 0 && findings[i-1].Span.Start > f.Span.Start {
+			t.Errorf("findings out of source order: %+v before %+v", findings[i-1], f)
+		}
+	}
+	if seen["telling-emotion"] != SeverityError {
+		t.Errorf("telling-emotion severity = %q, want error", seen["telling-emotion"])
+	}
+	if _, ok := seen["first-person"]; ok {
+		t.Error("disabled rule first-person reported findings")
+	}
+	if MaxSeverity(findings) != SeverityError {
+		t.Errorf("MaxSeverity() = %q, want error", MaxSeverity(findings))
+	}
+	if MaxSeverity(nil) != SeverityOff {
+		t.Errorf("MaxSeverity(nil) = %q, want off", MaxSeverity(nil))
+	}
+}
@@ -0,0 +1,251 @@
+// Package lint provides the built-in craft rules.
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thornzero/haikugo/internal/analyzer"
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// targetSyllables is the traditional syllable count of each line.
+var targetSyllables = []int{5, 7, 5}
+
+// maxFirstPerson is the number of first-person pronouns allowed in a poem.
+const maxFirstPerson = 1
+
+// firstPersonWords are the first-person singular pronouns and determiners.
+var firstPersonWords = map[string]bool{"i": true, "me": true, "my": true, "mine": true, "myself": true}
+
+// emotionWords name feelings outright instead of evoking them.
+var emotionWords = map[string]bool{
+	"afraid": true, "anger": true, "angry": true, "anxious": true, "anxiety": true,
+	"bittersweet": true, "despair": true, "depressed": true,
+	"fear": true, "glad": true, "grief": true, "happiness": true, "happy": true,
+	"heartbroken": true, "joy": true, "joyful": true, "lonely": true,
+	"loneliness": true, "melancholy": true, "miserable": true, "nostalgia": true,
+	"nostalgic": true, "sad": true, "sadness": true, "sorrow": true,
+	"sorrowful": true, "unhappy": true, "wistful": true,
+}
+
+// beForms are the forms of "be", after which "like" compares ("is like").
+var beForms = map[string]bool{"be": true, "is": true, "are": true, "was": true, "were": true, "been": true}
+
+func init() {
+	Register(Rule{
+		ID:       "syllable-mismatch",
+		Severity: SeverityError,
+		Message:  "line does not match the 5-7-5 pattern",
+		Check:    checkSyllables,
+	})
+	Register(Rule{
+		ID:       "missing-kigo",
+		Severity: SeverityInfo,
+		Message:  "no season word (kigo)",
+		Check:    checkKigo,
+	})
+	Register(diagnosticRule(analyzer.KindAdjectiveOverload, SeverityWarning, "too many adjectives; let one precise noun carry the image"))
+	Register(diagnosticRule(analyzer.KindExcessArticles, SeverityInfo, "more than one article in a line"))
+	Register(diagnosticRule(analyzer.KindPastTense, SeverityInfo, "past tense; haiku usually favor the present moment"))
+	Register(diagnosticRule(analyzer.KindIambicRun, SeverityWarning, "sing-song iambic rhythm"))
+	Register(Rule{
+		ID:       "first-person",
+		Severity: SeverityWarning,
+		Message:  "first person used more than once",
+		Check:    checkFirstPerson,
+	})
+	Register(Rule{
+		ID:       "telling-emotion",
+		Severity: SeverityWarning,
+		Message:  "names an emotion instead of showing it",
+		Check:    checkEmotion,
+	})
+	Register(Rule{
+		ID:       "simile",
+		Severity: SeverityInfo,
+		Message:  "simile; haiku usually set images side by side instead of comparing them",
+		Check:    checkSimile,
+	})
+	Register(Rule{
+		ID:       "cliche",
+		Severity: SeverityWarning,
+		Message:  "overused phrase",
+		Check:    checkCliches,
+	})
+	Register(Rule{
+		ID:       "end-rhyme",
+		Severity: SeverityInfo,
+		Message:  "line ends rhyme",
+		Check:    checkEndRhyme,
+	})
+}
+
+// diagnosticRule reports the analyzer's diagnostics of one kind under a
+// rule of the same ID.
+func diagnosticRule(kind string, s Severity, message string) Rule {
+	return Rule{
+		ID:       kind,
+		Severity: s,
+		Message:  message,
+		Check: func(_ *haiku.Haiku, m *haiku.Metrics) []Finding {
+			var findings []Finding
+			for _, d := range m.Diagnostics {
+				if d.Kind == kind {
+					findings = append(findings, Finding{Text: d.Text, Span: d.Span})
+				}
+			}
+			return findings
+		},
+	}
+}
+
+// checkSyllables reports each line whose count is off by more than the
+// analysis tolerance.
+func checkSyllables(h *haiku.Haiku, m *haiku.Metrics) []Finding {
+	var findings []Finding
+	for i, n := range m.LineSyllables {
+		diff := n - targetSyllables[i]
+		if diff > m.Tolerance || -diff > m.Tolerance {
+			findings = append(findings, Finding{
+				Message: fmt.Sprintf("line has %d syllables, want %d", n, targetSyllables[i]),
+				Text:    m.Lines[i],
+				Span:    h.Locate(i, 0, len(m.Lines[i])),
+			})
+		}
+	}
+	return findings
+}
+
+// checkKigo reports a poem without a season word at its first line.
+func checkKigo(h *haiku.Haiku, m *haiku.Metrics) []Finding {
+	if len(m.SeasonWords) > 0 {
+		return nil
+	}
+	return []Finding{{Span: h.Locate(0, 0, len(m.Lines[0]))}}
+}
+
+// checkFirstPerson reports every first-person word after the allowed one.
+func checkFirstPerson(_ *haiku.Haiku, m *haiku.Metrics) []Finding {
+	var uses []haiku.WordToken
+	for _, words := range m.Tokens {
+		for _, w := range words {
+			if firstPersonWords[head(w.Text)] {
+				uses = append(uses, w)
+			}
+		}
+	}
+	if len(uses) <= maxFirstPerson {
+		return nil
+	}
+
+	var findings []Finding
+	for _, w := range uses[maxFirstPerson:] {
+		findings = append(findings, Finding{
+			Message: fmt.Sprintf("first person used %d times", len(uses)),
+			Text:    w.Text,
+			Span:    w.Span,
+		})
+	}
+	return findings
+}
+
+// checkEmotion reports words that name a feeling.
+func checkEmotion(_ *haiku.Haiku, m *haiku.Metrics) []Finding {
+	var findings []Finding
+	for _, words := range m.Tokens {
+		for _, w := range words {
+			if emotionWords[head(w.Text)] {
+				findings = append(findings, Finding{
+					Message: fmt.Sprintf("%q names an emotion instead of showing it", w.Text),
+					Text:    w.Text,
+					Span:    w.Span,
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// checkSimile reports comparisons with "like" used as a preposition or
+// after "be", "as if", "as though" and "as ... as".
+func checkSimile(h *haiku.Haiku, m *haiku.Metrics) []Finding {
+	var findings []Finding
+	for _, words := range m.Tokens {
+		for j, w := range words {
+			last := -1
+			switch word := head(w.Text); {
+			case word == "like" && j+1 < len(words) && (w.POS == analyzer.TagAdposition || j > 0 && beForms[head(words[j-1].Text)]):
+				last = j + 1
+			case word == "as" && j+1 < len(words) && (head(words[j+1].Text) == "if" || head(words[j+1].Text) == "though"):
+				last = j + 1
+			case word == "as" && j+2 < len(words) && head(words[j+2].Text) == "as":
+				last = j + 2
+			}
+			if last >= 0 {
+				text, span := tokenRange(h, words[j:last+1])
+				findings = append(findings, Finding{Text: text, Span: span})
+			}
+		}
+	}
+	return findings
+}
+
+// checkCliches reports phrases from the cliché corpus with their tier.
+func checkCliches(_ *haiku.Haiku, m *haiku.Metrics) []Finding {
+	var findings []Finding
+	for _, c := range m.Cliches {
+		findings = append(findings, Finding{
+			Message: fmt.Sprintf("overused phrase %q (%s)", c.Phrase, c.Tier),
+			Text:    c.Text,
+			Span:    c.Span,
+		})
+	}
+	return findings
+}
+
+// checkEndRhyme reports rhyming line ends at the word that completes the rhyme.
+func checkEndRhyme(_ *haiku.Haiku, m *haiku.Metrics) []Finding {
+	var findings []Finding
+	for _, d := range m.Sounds {
+		if d.Kind != analyzer.SoundRhyme {
+			continue
+		}
+		texts := make([]string, len(d.Words))
+		for i, w := range d.Words {
+			texts[i] = w.Text
+		}
+		last := d.Words[len(d.Words)-1]
+		findings = append(findings, Finding{
+			Message: "line ends rhyme: " + strings.Join(texts, ", "),
+			Text:    last.Text,
+			Span:    last.Span,
+		})
+	}
+	return findings
+}
+
+// head returns a word lowercased and without a contraction's clitic, so
+// that "I'm" matches "i".
+func head(word string) string {
+	word = strings.ToLower(word)
+	if i := strings.IndexAny(word, "'’"); i > 0 {
+		word = word[:i]
+	}
+	return word
+}
+
+// tokenRange returns the source text and span covering consecutive words
+// of one line.
+func tokenRange(h *haiku.Haiku, words []haiku.WordToken) (string, haiku.Span) {
+	first, last := words[0].Span, words[len(words)-1].Span
+	span := haiku.Span{Line: first.Line, Column: first.Column, Start: first.Start, End: last.End}
+	if span.End <= len(h.Source) {
+		return h.Source[span.Start:span.End], span
+	}
+	texts := make([]string, len(words))
+	for i, w := range words {
+		texts[i] = w.Text
+	}
+	return strings.Join(texts, " "), span
+}
@@ -0,0 +1,49 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRules_Findings(t *testing.T) {
+	tests := []struct {
+		rule   string
+		source string
+		want   []string
+	}{
+		{"syllable-mismatch", "an old and silent pond\na frog jumps into the pond\nsplash! silence again", []string{"an old and silent pond"}},
+		{"syllable-mismatch", "an old silent pond\na frog jumps into the pond\nsplash! silence again", nil},
+		{"missing-kigo", "an old silent pond\na frog jumps into the pond\nsplash! silence again", []string{""}},
+		{"missing-kigo", "cherry blossoms fall\ndancing in the spring breeze\npetals kiss the earth", nil},
+		{"adjective-overload", "the cold dark lonely night\na frog jumps into the pond\nsilence again", []string{"cold dark lonely"}},
+		{"past-tense", "an old silent pond\na frog jumped into the pond\nsplash! silence again", []string{"jumped"}},
+		{"first-person", "I watch the moon\nmy shadow on the water\nI am alone", []string{"my", "I"}},
+		{"first-person", "I watch the moon\nshadows on the water\nalone", nil},
+		{"telling-emotion", "sad autumn evening\nthe crow is lonely tonight\nrain", []string{"sad", "lonely"}},
+		{"simile", "the moon like a coin\nher heart is like a stone\nas cold as the sea", []string{"like a", "like a", "as cold as"}},
+		{"simile", "I like the moon\nfrogs jump as the rain falls\nsilence", nil},
+		{"cliche", "an old pond\nthe leaves of autumn drifting\nsilence", []string{"old pond", "leaves of autumn"}},
+		{"end-rhyme", "stars burning bright\nthe moon hangs low in the sky\nsilent winter night", []string{"night"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			h, m := analyze(tt.source)
+			r, ok := Lookup(tt.rule)
+			if !ok {
+				t.Fatalf("rule %q not registered", tt.rule)
+			}
+
+			var got []string
+			for _, f := range r.Check(h, m) {
+				got = append(got, f.Text)
+				if f.Text != "" && tt.source[f.Span.Start:f.Span.End] != f.Text {
+					t.Errorf("finding %q maps to source text %q", f.Text, tt.source[f.Span.Start:f.Span.End])
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s findings = %q, want %q", tt.rule, got, tt.want)
+			}
+		})
+	}
+}
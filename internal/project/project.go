@@ -0,0 +1,31 @@
+// Package project provides lookup of the per-project files kept under .haikugo.
+package project
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Dir is the directory, at the root of a project, that holds its syllable
+// dictionary, lint rules, scoring rubric and journal.
+const Dir = ".haikugo"
+
+// Find looks for name, a path relative to a project root, in dir and each
+// of its parents and returns the first match.
+func Find(dir, name string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	for {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,39 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, Dir, "rules.tsv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	want := filepath.Join(root, Dir, "words.tsv")
+	if err := os.WriteFile(want, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		dir, name string
+		want      string
+		found     bool
+	}{
+		{root, Dir + "/words.tsv", want, true},
+		{nested, Dir + "/words.tsv", want, true},
+		{nested, Dir + "/missing.tsv", "", false},
+		{nested, Dir + "/rules.tsv", "", false}, // a directory is not a match
+	}
+	for _, tt := range tests {
+		got, ok := Find(tt.dir, tt.name)
+		if got != tt.want || ok != tt.found {
+			t.Errorf("Find(%q, %q) = %q, %t, want %q, %t", tt.dir, tt.name, got, ok, tt.want, tt.found)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Package score provides weighted scoring rubrics.
+package score
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/thornzero/haikugo/internal/project"
+)
+
+// DefaultPath is the project-relative location of the rubric file.
+const DefaultPath = project.Dir + "/rubric.tsv"
+
+// Criteria scored by the rubric.
+const (
+	CriterionStructure   = "structure"
+	CriterionKigo        = "kigo"
+	CriterionCut         = "cut"
+	CriterionConcrete    = "concreteness"
+	CriterionEconomy     = "economy"
+	CriterionSound       = "sound"
+	CriterionOriginality = "originality"
+)
+
+// criteria lists the criteria in the order they are reported.
+var criteria = []string{
+	CriterionStructure, CriterionKigo, CriterionCut, CriterionConcrete,
+	CriterionEconomy, CriterionSound, CriterionOriginality,
+}
+
+// defaultWeights favor structure, concrete imagery and originality.
+var defaultWeights = map[string]float64{
+	CriterionStructure:   3,
+	CriterionKigo:        1,
+	CriterionCut:         1,
+	CriterionConcrete:    2,
+	CriterionEconomy:     1,
+	CriterionSound:       1,
+	CriterionOriginality: 2,
+}
+
+// Criteria returns the scored criteria in report order.
+func Criteria() []string {
+	result := make([]string, len(criteria))
+	copy(result, criteria)
+	return result
+}
+
+// Rubric weights each criterion's sub-score in the overall score.
+type Rubric struct {
+	weights map[string]float64
+}
+
+// Default creates a Rubric with the default weights.
+func Default() *Rubric {
+	r := &Rubric{weights: make(map[string]float64, len(defaultWeights))}
+	for c, w := range defaultWeights {
+		r.weights[c] = w
+	}
+	return r
+}
+
+// Set sets the weight of a criterion. A zero weight leaves it out of the
+// overall score; negative, NaN and infinite weights are rejected.
+func (r *Rubric) Set(criterion string, weight float64) error {
+	criterion = strings.ToLower(strings.TrimSpace(criterion))
+	if _, ok := defaultWeights[criterion]; !ok {
+		return fmt.Errorf("unknown criterion %q", criterion)
+	}
+	if weight < 0 {
+		return fmt.Errorf("criterion %q: weight must not be negative, got %g", criterion, weight)
+	}
+	if math.IsNaN(weight) || math.IsInf(weight, 0) {
+		return fmt.Errorf("criterion %q: weight must be a finite number, got %g", criterion, weight)
+	}
+	r.weights[criterion] = weight
+	return nil
+}
+
+// Weight returns the weight of a criterion. A nil Rubric has the default weights.
+func (r *Rubric) Weight(criterion string) float64 {
+	if r == nil {
+		return defaultWeights[criterion]
+	}
+	return r.weights[criterion]
+}
+
+// Read parses weights in TSV form, one "criterion<TAB>weight" per line.
+// Criteria that are not listed keep their default weight. Blank lines and
+// lines starting with '#' are ignored.
+func Read(r io.Reader) (*Rubric, error) {
+	rubric := Default()
+	sc := bufio.NewScanner(r)
+	lineNo := 0
+
+	for sc.Scan() {
+		lineNo++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: want criterion<TAB>weight, got %q", lineNo, line)
+		}
+		w, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid weight %q", lineNo, fields[1])
+		}
+		if err := rubric.Set(fields[0], w); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return rubric, nil
+}
+
+// Load reads a rubric from the TSV file at path.
+func Load(path string) (*Rubric, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Find looks for DefaultPath in dir and its parents.
+func Find(dir string) (string, bool) {
+	return project.Find(dir, DefaultPath)
+}
@@ -0,0 +1,111 @@
+package score
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRubric_Weight(t *testing.T) {
+	r := Default()
+	if err := r.Set("Kigo", 0); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	tests := []struct {
+		rubric    *Rubric
+		criterion string
+		want      float64
+	}{
+		{r, CriterionKigo, 0},
+		{r, CriterionStructure, 3},
+		{nil, CriterionKigo, 1},
+		{nil, CriterionOriginality, 2},
+		{nil, "rhyme", 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.rubric.Weight(tt.criterion); got != tt.want {
+			t.Errorf("Weight(%q) = %g, want %g", tt.criterion, got, tt.want)
+		}
+	}
+}
+
+func TestRubric_SetErrors(t *testing.T) {
+	r := Default()
+	for _, tt := range []struct {
+		criterion string
+		weight    float64
+	}{
+		{"rhyme", 1},
+		{CriterionSound, -1},
+	} {
+		if err := r.Set(tt.criterion, tt.weight); err == nil {
+			t.Errorf("Set(%q, %g) succeeded, want error", tt.criterion, tt.weight)
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]float64
+		wantErr bool
+	}{
+		{"weights", "# criterion\tweight\nstructure\t5\n\nsound\t0.5\n", map[string]float64{CriterionStructure: 5, CriterionSound: 0.5, CriterionKigo: 1}, false},
+		{"missing weight", "structure\n", nil, true},
+		{"invalid weight", "structure\theavy\n", nil, true},
+		{"unknown criterion", "rhyme\t1\n", nil, true},
+		{"NaN weight", "structure\tNaN\n", nil, true},
+		{"infinite weight", "structure\tInf\n", nil, true},
+		{"positive infinite weight", "sound\t+Inf\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Read(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for c, want := range tt.want {
+				if got := r.Weight(c); got != want {
+					t.Errorf("Weight(%s) = %g, want %g", c, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadFind(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, DefaultPath)
+	nested := filepath.Join(root, "entries", "2024")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := Find(nested); ok {
+		t.Fatal("Find() found a rubric before one was written")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("originality\t4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	found, ok := Find(nested)
+	if !ok || found != path {
+		t.Fatalf("Find() = %q, %v, want %q, true", found, ok, path)
+	}
+
+	r, err := Load(found)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := r.Weight(CriterionOriginality); got != 4 {
+		t.Errorf("Weight(originality) = %g, want 4", got)
+	}
+}
@@ -0,0 +1,231 @@
+// Package stats provides corpus-level statistics over analyzed haiku.
+package stats
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/thornzero/haikugo/internal/analyzer"
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+const (
+	// DefaultMaxTolerance is the largest syllable tolerance validity is
+	// reported for.
+	DefaultMaxTolerance = 2
+	// MaxTolerance is the largest tolerance accepted.
+	MaxTolerance = 7
+	// DefaultTop is the number of kigo, kireji and words listed.
+	DefaultTop = 20
+	// NoSeason labels poems without a season word in the season distribution.
+	NoSeason = "none"
+	// UnknownAuthor labels poems without an author.
+	UnknownAuthor = "(unknown)"
+)
+
+// Options configures a Collector.
+type Options struct {
+	MaxTolerance *int // largest tolerance reported; nil means DefaultMaxTolerance
+	Top          int  // kigo, kireji and words listed; 0 means DefaultTop, negative all
+}
+
+// Count is how many poems, or for vocabulary how many occurrences, have a
+// value, with their fraction of all poems or words.
+type Count struct {
+	Value    string  `json:"value"`
+	Count    int     `json:"count"`
+	Fraction float64 `json:"fraction"`
+}
+
+// Validity is how many poems are valid 5-7-5 within a syllable tolerance.
+type Validity struct {
+	Tolerance int     `json:"tolerance"`
+	Valid     int     `json:"valid"`
+	Fraction  float64 `json:"fraction"`
+}
+
+// Author summarizes the poems of one author.
+type Author struct {
+	Author         string  `json:"author"`
+	Poems          int     `json:"poems"`
+	LexicalDensity float64 `json:"lexical_density"`
+}
+
+// Report is the statistics of a corpus. Seasons counts a poem once for
+// each season it has a word of, so its fractions can add up to more
+// than 1.
+type Report struct {
+	Poems       int        `json:"poems"`
+	Errors      int        `json:"errors"`
+	Words       int        `json:"words"`
+	UniqueWords int        `json:"unique_words"`
+	Patterns    []Count    `json:"patterns"`
+	Validity    []Validity `json:"validity"`
+	Seasons     []Count    `json:"seasons"`
+	Kigo        []Count    `json:"kigo"`
+	Kireji      []Count    `json:"kireji"`
+	Vocabulary  []Count    `json:"vocabulary"`
+	Authors     []Author   `json:"authors"`
+}
+
+// authorTotals accumulates an author's poems and lexical density.
+type authorTotals struct {
+	poems   int
+	density float64
+}
+
+// Collector accumulates statistics over analyzed poems.
+type Collector struct {
+	top        int
+	poems      int
+	errors     int
+	words      int
+	patterns   map[string]int
+	validators []*analyzer.Analyzer
+	valid      []int
+	seasons    map[string]int
+	kigo       map[string]int
+	kireji     map[string]int
+	vocabulary map[string]int
+	authors    map[string]*authorTotals
+}
+
+// New creates an empty Collector.
+func New(opts Options) (*Collector, error) {
+	c := &Collector{
+		top:        opts.Top,
+		patterns:   make(map[string]int),
+		seasons:    make(map[string]int),
+		kigo:       make(map[string]int),
+		kireji:     make(map[string]int),
+		vocabulary: make(map[string]int),
+		authors:    make(map[string]*authorTotals),
+	}
+	maxTolerance := DefaultMaxTolerance
+	if opts.MaxTolerance != nil {
+		maxTolerance = *opts.MaxTolerance
+	}
+	if maxTolerance < 0 || maxTolerance > MaxTolerance {
+		return nil, fmt.Errorf("max tolerance must be between 0 and %d, got %d", MaxTolerance, maxTolerance)
+	}
+	if c.top == 0 {
+		c.top = DefaultTop
+	}
+	for t := range maxTolerance + 1 {
+		c.validators = append(c.validators, analyzer.New(t))
+	}
+	c.valid = make([]int, maxTolerance+1)
+	return c, nil
+}
+
+// Add counts a poem by author with its analysis.
+func (c *Collector) Add(author string, m *haiku.Metrics) {
+	c.poems++
+	c.patterns[haiku.Form(m.LineSyllables)]++
+	for t, v := range c.validators {
+		if v.IsValid575(m.LineSyllables) {
+			c.valid[t]++
+		}
+	}
+
+	seasons := make(map[string]bool)
+	for _, w := range m.SeasonWords {
+		w = strings.ToLower(w)
+		c.kigo[w]++
+		if s := analyzer.SeasonOf(w); s != "" {
+			seasons[s] = true
+		}
+	}
+	if len(seasons) == 0 {
+		seasons[NoSeason] = true
+	}
+	for s := range seasons {
+		c.seasons[s]++
+	}
+	for _, k := range m.KirejiHits {
+		c.kireji[k]++
+	}
+
+	for _, line := range m.Lines {
+		for _, w := range analyzer.ExtractWords(line) {
+			c.vocabulary[strings.ReplaceAll(strings.ToLower(w), "’", "'")]++
+			c.words++
+		}
+	}
+
+	author = strings.TrimSpace(author)
+	if author == "" {
+		author = UnknownAuthor
+	}
+	a := c.authors[author]
+	if a == nil {
+		a = &authorTotals{}
+		c.authors[author] = a
+	}
+	a.poems++
+	a.density += m.LexicalDensity
+}
+
+// AddError counts a record that could not be read or parsed.
+func (c *Collector) AddError() {
+	c.errors++
+}
+
+// Report returns the statistics of the poems added so far.
+func (c *Collector) Report() Report {
+	r := Report{
+		Poems:       c.poems,
+		Errors:      c.errors,
+		Words:       c.words,
+		UniqueWords: len(c.vocabulary),
+		Patterns:    counts(c.patterns, c.poems, -1),
+		Seasons:     counts(c.seasons, c.poems, -1),
+		Kigo:        counts(c.kigo, c.poems, c.top),
+		Kireji:      counts(c.kireji, c.poems, c.top),
+		Vocabulary:  counts(c.vocabulary, c.words, c.top),
+		Authors:     make([]Author, 0, len(c.authors)),
+	}
+	for t, n := range c.valid {
+		r.Validity = append(r.Validity, Validity{Tolerance: t, Valid: n, Fraction: fraction(n, c.poems)})
+	}
+	for name, a := range c.authors {
+		r.Authors = append(r.Authors, Author{Author: name, Poems: a.poems, LexicalDensity: round(a.density / float64(a.poems))})
+	}
+	slices.SortFunc(r.Authors, func(a, b Author) int {
+		return cmp.Or(b.Poems-a.Poems, strings.Compare(a.Author, b.Author))
+	})
+	return r
+}
+
+// counts returns the values of m, most frequent first and then
+// alphabetically, limited to top when top is positive.
+func counts(m map[string]int, total, top int) []Count {
+	result := make([]Count, 0, len(m))
+	for v, n := range m {
+		result = append(result, Count{Value: v, Count: n, Fraction: fraction(n, total)})
+	}
+	slices.SortFunc(result, func(a, b Count) int {
+		return cmp.Or(b.Count-a.Count, strings.Compare(a.Value, b.Value))
+	})
+	if top > 0 && len(result) > top {
+		result = result[:top]
+	}
+	return result
+}
+
+// fraction returns n/total rounded to three decimal places, or 0 for an
+// empty total.
+func fraction(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return round(float64(n) / float64(total))
+}
+
+// round rounds x to three decimal places.
+func round(x float64) float64 {
+	return math.Round(x*1000) / 1000
+}
@@ -0,0 +1,119 @@
+package stats
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/thornzero/haikugo/internal/analyzer"
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// corpus pairs poems with their authors.
+var corpus = []struct {
+	author, text string
+}{
+	{"Basho", "an old silent pond\na frog jumps into the pond\nsplash silence again"},
+	{"Basho", "the autumn moon shines\nover the pond a cold wind\nthe frog sleeps alone"},
+	{"Issa", "a cold winter night\nthe snow drifts into the pond\nsilence in the pines"},
+	{"", "snow\non the frog\nsilent"},
+	{"Issa", "cicadas shrilling\ninto the rocks it seeps\nthe summer heat"},
+}
+
+func collect(t *testing.T, opts Options) Report {
+	t.Helper()
+	c, err := New(opts)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	a := analyzer.New(0)
+	for _, p := range corpus {
+		c.Add(p.author, a.Analyze(haiku.NewHaiku(strings.Split(p.text, "\n"))))
+	}
+	c.AddError()
+	return c.Report()
+}
+
+func TestCollector_Report(t *testing.T) {
+	r := collect(t, Options{})
+
+	if r.Poems != 5 || r.Errors != 1 {
+		t.Errorf("Poems, Errors = %d, %d, want 5, 1", r.Poems, r.Errors)
+	}
+	if r.Words != 56 || r.UniqueWords != 34 {
+		t.Errorf("Words, UniqueWords = %d, %d, want 56, 34", r.Words, r.UniqueWords)
+	}
+
+	wantPatterns := []Count{{"5-7-5", 3, 0.6}, {"1-3-2", 1, 0.2}, {"5-6-4", 1, 0.2}}
+	if !reflect.DeepEqual(r.Patterns, wantPatterns) {
+		t.Errorf("Patterns = %v, want %v", r.Patterns, wantPatterns)
+	}
+	wantValidity := []Validity{{0, 3, 0.6}, {1, 4, 0.8}, {2, 4, 0.8}}
+	if !reflect.DeepEqual(r.Validity, wantValidity) {
+		t.Errorf("Validity = %v, want %v", r.Validity, wantValidity)
+	}
+
+	seasons := make(map[string]int)
+	for _, s := range r.Seasons {
+		seasons[s.Value] = s.Count
+	}
+	if seasons["winter"] != 3 || seasons["summer"] != 1 || seasons[NoSeason] != 1 {
+		t.Errorf("Seasons = %v, want winter 3, summer 1 and none 1", r.Seasons)
+	}
+	if len(r.Kigo) == 0 || r.Kigo[0] != (Count{"cold", 2, 0.4}) {
+		t.Errorf("Kigo = %v, want cold first", r.Kigo)
+	}
+	if len(r.Vocabulary) == 0 || r.Vocabulary[0] != (Count{"the", 10, 0.179}) {
+		t.Errorf("Vocabulary = %v, want \"the\" first", r.Vocabulary)
+	}
+	for i := 1; i < len(r.Vocabulary); i++ {
+		if r.Vocabulary[i].Count > r.Vocabulary[i-1].Count {
+			t.Errorf("Vocabulary not sorted by count: %v", r.Vocabulary)
+		}
+	}
+
+	var authors []string
+	for _, a := range r.Authors {
+		authors = append(authors, a.Author)
+		if a.LexicalDensity <= 0 || a.LexicalDensity > 1 {
+			t.Errorf("Author %+v has lexical density out of range", a)
+		}
+	}
+	if want := []string{"Basho", "Issa", UnknownAuthor}; !reflect.DeepEqual(authors, want) {
+		t.Errorf("Authors = %v, want %v", authors, want)
+	}
+}
+
+func TestCollector_Options(t *testing.T) {
+	tolerance := func(n int) *int { return &n }
+	r := collect(t, Options{MaxTolerance: tolerance(3), Top: 2})
+	if len(r.Validity) != 4 || len(r.Kigo) != 2 || len(r.Vocabulary) != 2 {
+		t.Errorf("Report has %d tolerances, %d kigo, %d words, want 4, 2, 2", len(r.Validity), len(r.Kigo), len(r.Vocabulary))
+	}
+	if r := collect(t, Options{Top: -1}); len(r.Vocabulary) != r.UniqueWords {
+		t.Errorf("Top -1 listed %d of %d words", len(r.Vocabulary), r.UniqueWords)
+	}
+	if r := collect(t, Options{MaxTolerance: tolerance(0)}); len(r.Validity) != 1 || r.Validity[0].Tolerance != 0 {
+		t.Errorf("MaxTolerance 0 reported %v, want exact validity only", r.Validity)
+	}
+	if r := collect(t, Options{}); len(r.Validity) != DefaultMaxTolerance+1 {
+		t.Errorf("Default MaxTolerance reported %d tolerances, want %d", len(r.Validity), DefaultMaxTolerance+1)
+	}
+
+	for _, opts := range []Options{{MaxTolerance: tolerance(-1)}, {MaxTolerance: tolerance(MaxTolerance + 1)}} {
+		if _, err := New(opts); err == nil {
+			t.Errorf("New(%+v) should fail", opts)
+		}
+	}
+}
+
+func TestCollector_Empty(t *testing.T) {
+	c, err := New(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := c.Report()
+	if r.Poems != 0 || len(r.Patterns) != 0 || r.Validity[0].Fraction != 0 {
+		t.Errorf("Empty report = %+v", r)
+	}
+}
@@ -0,0 +1,50 @@
+// Package haikugo provides the accidental haiku finder for prose documents.
+package haikugo
+
+import (
+	"github.com/thornzero/haikugo/internal/haiku"
+	"github.com/thornzero/haikugo/internal/input"
+)
+
+// Accidental is a run of consecutive words in prose that splits exactly
+// into 5-7-5 syllables, with its span in the document.
+type Accidental = haiku.Accidental
+
+// Markup is the format of a prose document.
+type Markup = input.Markup
+
+// Supported document formats.
+const (
+	MarkupText     = input.MarkupText
+	MarkupMarkdown = input.MarkupMarkdown
+	MarkupHTML     = input.MarkupHTML
+)
+
+// ParseMarkup returns the document format with the given name, also
+// accepting "md" and "htm".
+func ParseMarkup(name string) (Markup, error) {
+	return input.ParseMarkup(name)
+}
+
+// MarkupForPath guesses a document's format from its file extension,
+// defaulting to plain text.
+func MarkupForPath(path string) Markup {
+	return input.MarkupForPath(path)
+}
+
+// AccidentalOptions configures FindAccidental.
+type AccidentalOptions struct {
+	// Markup is the document format; the zero value reads plain text.
+	Markup Markup
+	// CrossSentences also finds runs that start or end mid-sentence.
+	CrossSentences bool
+}
+
+// FindAccidental returns the runs of consecutive words in a prose document
+// that split exactly into 5-7-5 syllables on word boundaries. Markup and
+// code are skipped, runs never cross paragraphs, and spans locate each run
+// in text.
+func (a *Analyzer) FindAccidental(text string, opts AccidentalOptions) []Accidental {
+	prose, offsets := input.StripMarkup(text, opts.Markup)
+	return a.analyzer.FindAccidental(text, prose, offsets, opts.CrossSentences)
+}
@@ -0,0 +1,35 @@
+package haikugo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzer_FindAccidental(t *testing.T) {
+	doc := "# Notes\n\nAn *old* silent pond. A frog jumps into the [pond](http://example.com).\nSplash! Silence again.\n"
+
+	found := NewAnalyzer(0).FindAccidental(doc, AccidentalOptions{Markup: MarkupMarkdown})
+	if len(found) != 1 {
+		t.Fatalf("FindAccidental() = %+v, want one haiku", found)
+	}
+	h := found[0]
+	expected := []string{"An old silent pond.", "A frog jumps into the pond.", "Splash! Silence again."}
+	if !reflect.DeepEqual(h.Lines, expected) || !h.Sentence {
+		t.Errorf("FindAccidental() = %+v, want %q on sentence boundaries", h, expected)
+	}
+	if got := doc[h.Span.Start:h.Span.End]; got != "An *old* silent pond. A frog jumps into the [pond](http://example.com).\nSplash! Silence again." {
+		t.Errorf("Span %+v covers %q", h.Span, got)
+	}
+	if h.Span.Line != 3 || h.Span.Column != 1 {
+		t.Errorf("Span position = %d:%d, want 3:1", h.Span.Line, h.Span.Column)
+	}
+}
+
+func TestMarkupForPath(t *testing.T) {
+	if m := MarkupForPath("column.htm"); m != MarkupHTML {
+		t.Errorf("MarkupForPath() = %q, want %q", m, MarkupHTML)
+	}
+	if _, err := ParseMarkup("rtf"); err == nil {
+		t.Error("ParseMarkup(\"rtf\") error = nil")
+	}
+}
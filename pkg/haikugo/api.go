@@ -34,6 +34,124 @@ type Haiku struct {
 // Metrics wraps the internal metrics structure for public use.
 type Metrics = haiku.Metrics
 
+// Span locates a range of text in the original source.
+type Span = haiku.Span
+
+// LineSource records where a haiku line came from in the original source.
+type LineSource = haiku.LineSource
+
+// Diagnostic is an analysis finding tied to a position in the source.
+type Diagnostic = haiku.Diagnostic
+
+// WordToken explains how a single token of a line was counted.
+type WordToken = haiku.WordToken
+
+// LineMeter describes the stress pattern and meter of a line.
+type LineMeter = haiku.LineMeter
+
+// LineGrammar is the grammatical profile of a line.
+type LineGrammar = haiku.LineGrammar
+
+// Cliche is an overused phrase from the corpus found in a line.
+type Cliche = haiku.Cliche
+
+// Frequency tiers of overused phrases, reported in Cliche.Tier.
+const (
+	ClicheHigh   = analyzer.ClicheHigh
+	ClicheMedium = analyzer.ClicheMedium
+	ClicheLow    = analyzer.ClicheLow
+)
+
+// AddCliche adds an overused phrase to the corpus with a frequency tier,
+// or changes the tier of a phrase that matches the same words.
+func AddCliche(phrase, tier string) error {
+	return analyzer.AddCliche(phrase, tier)
+}
+
+// SoundDevice is a pattern of repeated sound across words.
+type SoundDevice = haiku.SoundDevice
+
+// SoundWord is a word taking part in a sound device.
+type SoundWord = haiku.SoundWord
+
+// Sound device kinds reported in SoundDevice.Kind.
+const (
+	SoundAlliteration  = analyzer.SoundAlliteration
+	SoundAssonance     = analyzer.SoundAssonance
+	SoundConsonance    = analyzer.SoundConsonance
+	SoundInternalRhyme = analyzer.SoundInternalRhyme
+	SoundRhyme         = analyzer.SoundRhyme
+	SoundNearRhyme     = analyzer.SoundNearRhyme
+)
+
+// Senses reported in WordToken.Senses and Metrics.Senses.
+const (
+	SenseSight = analyzer.SenseSight
+	SenseSound = analyzer.SenseSound
+	SenseTouch = analyzer.SenseTouch
+	SenseSmell = analyzer.SenseSmell
+	SenseTaste = analyzer.SenseTaste
+)
+
+// Part-of-speech tags reported in WordToken.POS.
+const (
+	TagNoun         = analyzer.TagNoun
+	TagVerb         = analyzer.TagVerb
+	TagAuxiliary    = analyzer.TagAuxiliary
+	TagAdjective    = analyzer.TagAdjective
+	TagAdverb       = analyzer.TagAdverb
+	TagDeterminer   = analyzer.TagDeterminer
+	TagAdposition   = analyzer.TagAdposition
+	TagPronoun      = analyzer.TagPronoun
+	TagConjunction  = analyzer.TagConjunction
+	TagParticle     = analyzer.TagParticle
+	TagNumeral      = analyzer.TagNumeral
+	TagInterjection = analyzer.TagInterjection
+)
+
+// Tenses reported in LineGrammar.Tense.
+const (
+	TensePresent = analyzer.TensePresent
+	TensePast    = analyzer.TensePast
+	TenseMixed   = analyzer.TenseMixed
+)
+
+// Counting methods reported in WordToken.Method.
+const (
+	MethodOverride   = analyzer.MethodOverride
+	MethodDialect    = analyzer.MethodDialect
+	MethodException  = analyzer.MethodException
+	MethodMorphology = analyzer.MethodMorphology
+	MethodHeuristic  = analyzer.MethodHeuristic
+)
+
+// ExplainLine breaks a single line into tokens and reports how each was counted.
+func ExplainLine(line string) []WordToken {
+	return analyzer.ExplainLine(line)
+}
+
+// Metrical feet reported in LineMeter.Foot.
+const (
+	FootIamb    = analyzer.FootIamb
+	FootTrochee = analyzer.FootTrochee
+	FootAnapest = analyzer.FootAnapest
+	FootDactyl  = analyzer.FootDactyl
+)
+
+// ScanLine reports the stress pattern and meter of a single line.
+func ScanLine(line string) LineMeter {
+	return analyzer.ScanLine(line)
+}
+
+// SyllableSeparator joins syllables in WordToken.Syllabified.
+const SyllableSeparator = analyzer.SyllableSeparator
+
+// Syllabify divides a word into its syllables, e.g. "middle" -> ["mid", "dle"].
+// The number of parts matches the word's syllable count.
+func Syllabify(word string) []string {
+	return analyzer.Syllabify(word)
+}
+
 // NewAnalyzer creates a new haiku analyzer with the specified syllable tolerance.
 // Tolerance allows for flexibility in the 5-7-5 pattern (e.g., tolerance=1 allows 4-6, 6-8, 4-6).
 func NewAnalyzer(tolerance int) *Analyzer {
@@ -63,6 +181,85 @@ func ParseHaikuWithAutosplit(text string) (*Haiku, error) {
 	return &Haiku{haiku: h}, nil
 }
 
+// Normalization selects the Unicode normalization applied to input text.
+type Normalization = input.Normalization
+
+// Supported normalization forms. NFC is the default for all Parse functions.
+const (
+	NormalizeNone = input.NormalizeNone
+	NormalizeNFC  = input.NormalizeNFC
+	NormalizeNFKC = input.NormalizeNFKC
+)
+
+// ParseNormalization converts a form name ("nfc", "nfkc" or "none") into a Normalization.
+func ParseNormalization(name string) (Normalization, error) {
+	return input.ParseNormalization(name)
+}
+
+// Dialect names a regional pronunciation profile used when counting syllables.
+type Dialect = analyzer.Dialect
+
+// Supported dialect profiles. General American is the default.
+const (
+	DialectGeneralAmerican = analyzer.DialectGeneralAmerican
+	DialectRP              = analyzer.DialectRP
+	DialectAustralian      = analyzer.DialectAustralian
+	DialectSouthernUS      = analyzer.DialectSouthernUS
+)
+
+// Dialects returns the supported dialect profiles.
+func Dialects() []Dialect {
+	return analyzer.Dialects()
+}
+
+// ParseDialect converts a profile name or alias ("rp", "en-gb", "southern", ...) into a Dialect.
+func ParseDialect(name string) (Dialect, error) {
+	return analyzer.ParseDialect(name)
+}
+
+// SetDialect selects the dialect profile used for syllable counting.
+func (a *Analyzer) SetDialect(d Dialect) {
+	a.analyzer.SetDialect(d)
+}
+
+// GetDialect returns the dialect profile used for syllable counting.
+func (a *Analyzer) GetDialect() Dialect {
+	return a.analyzer.GetDialect()
+}
+
+// ParseOptions configures ParseHaikuWithOptions.
+type ParseOptions struct {
+	// Autosplit splits single-line input into 3 lines on common separators.
+	Autosplit bool
+	// Normalization is the Unicode normalization applied before parsing.
+	Normalization Normalization
+}
+
+// ParseHaikuWithOptions parses a haiku from a string using the given options.
+func ParseHaikuWithOptions(text string, opts ParseOptions) (*Haiku, error) {
+	parser := input.New(opts.Autosplit)
+	parser.SetNormalization(opts.Normalization)
+	h, err := parser.ParseFromString(text)
+	if err != nil {
+		return nil, err
+	}
+	return &Haiku{haiku: h}, nil
+}
+
+// DecodeText converts raw input bytes to UTF-8 text. UTF-16 (with or without
+// a byte order mark) and Latin-1 are detected and transcoded, and any byte
+// order mark is removed.
+func DecodeText(data []byte) string {
+	text, _ := input.Decode(data)
+	return text
+}
+
+// NormalizeText maps typographic punctuation such as curly quotes to plain
+// ASCII and applies the given Unicode normalization form.
+func NormalizeText(text string, form Normalization) string {
+	return input.Normalize(text, form)
+}
+
 // ParseHaikuFromFile reads and parses a haiku from a file.
 func ParseHaikuFromFile(filename string) (*Haiku, error) {
 	parser := input.New(false)
@@ -107,6 +304,31 @@ func (h *Haiku) Text() string {
 	return h.haiku.Text()
 }
 
+// Source returns the original text the haiku was parsed from,
+// including indentation and blank lines.
+func (h *Haiku) Source() string {
+	if h.haiku.Source == "" {
+		return h.haiku.Text()
+	}
+	return h.haiku.Source
+}
+
+// LineSources returns the source position, indentation and original text of each line.
+func (h *Haiku) LineSources() []LineSource {
+	return h.haiku.LineSources
+}
+
+// Author returns the poem's author, used to select per-author overrides.
+func (h *Haiku) Author() string {
+	return h.haiku.Author
+}
+
+// SetAuthor attributes the poem to an author so that their syllable
+// overrides apply when it is analyzed.
+func (h *Haiku) SetAuthor(author string) {
+	h.haiku.Author = author
+}
+
 // IsValid returns true if the haiku has exactly 3 lines.
 func (h *Haiku) IsValid() bool {
 	return h.haiku.IsValid()
@@ -1,6 +1,7 @@
 package haikugo
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -181,3 +182,198 @@ func TestHaiku_Methods(t *testing.T) {
 		t.Error("IsValid() should return true for 3-line haiku")
 	}
 }
+
+func TestHaiku_Source(t *testing.T) {
+	text := "  old pond\n\n    frog jumps in—\nsplash"
+	haiku, err := ParseHaiku(text)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if haiku.Source() != text {
+		t.Errorf("Source() = %q, want %q", haiku.Source(), text)
+	}
+
+	sources := haiku.LineSources()
+	if len(sources) != 3 || sources[1].Line != 3 || sources[1].Indent != "    " {
+		t.Errorf("LineSources() = %+v, want line 2 at source line 3 with 4-space indent", sources)
+	}
+
+	metrics := NewAnalyzer(0).Analyze(haiku)
+	for _, d := range metrics.Diagnostics {
+		if got := text[d.Span.Start:d.Span.End]; got != d.Text {
+			t.Errorf("Diagnostic %q maps to %q", d.Text, got)
+		}
+	}
+}
+
+func TestParseHaikuWithOptions(t *testing.T) {
+	text := "ｏｌｄ ｐｏｎｄ\nthe frog’s leap\nsplash"
+
+	haiku, err := ParseHaikuWithOptions(text, ParseOptions{Normalization: NormalizeNFKC})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if haiku.Lines()[0] != "old pond" || haiku.Lines()[1] != "the frog's leap" {
+		t.Errorf("Lines() = %q, want normalized text", haiku.Lines())
+	}
+
+	raw, err := ParseHaikuWithOptions(text, ParseOptions{Normalization: NormalizeNone})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if raw.Lines()[1] != "the frog’s leap" {
+		t.Errorf("NormalizeNone changed line 2 to %q", raw.Lines()[1])
+	}
+}
+
+func TestDecodeText(t *testing.T) {
+	if got := DecodeText([]byte("\xEF\xBB\xBFcaf\xC3\xA9")); got != "café" {
+		t.Errorf("DecodeText(utf-8 bom) = %q, want %q", got, "café")
+	}
+	if got := DecodeText([]byte("caf\xe9")); got != "café" {
+		t.Errorf("DecodeText(latin-1) = %q, want %q", got, "café")
+	}
+}
+
+func TestExplainLine(t *testing.T) {
+	words := ExplainLine("the fire")
+	if len(words) != 2 {
+		t.Fatalf("ExplainLine returned %d tokens, want 2", len(words))
+	}
+
+	fire := words[1]
+	if fire.Method != MethodException || fire.MinSyllables != 1 || fire.MaxSyllables != 2 {
+		t.Errorf("fire = %+v, want exception with range 1-2", fire)
+	}
+}
+
+func TestSyllabify(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected []string
+	}{
+		{"middle", []string{"mid", "dle"}},
+		{"creation", []string{"cre", "a", "tion"}},
+		{"Silence.", []string{"Si", "lence."}},
+		{"frog", []string{"frog"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if result := Syllabify(tt.word); !slices.Equal(result, tt.expected) {
+				t.Errorf("Syllabify(%q) = %q, want %q", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScanLine(t *testing.T) {
+	m := ScanLine("the petals fall along the stream")
+	if m.Pattern != "x / x / x / x /" || m.Foot != FootIamb || !m.SingSong {
+		t.Errorf("ScanLine = %+v, want sing-song iambs", m)
+	}
+
+	if m := ScanLine("a frog jumps into the pond"); m.SingSong {
+		t.Errorf("ScanLine = %+v, want no sing-song", m)
+	}
+}
+
+func TestAnalyze_Sounds(t *testing.T) {
+	h, err := ParseHaiku("silver streams in spring\nthe moon at noon is bright\na light on the night")
+	if err != nil {
+		t.Fatalf("ParseHaiku() error = %v", err)
+	}
+
+	kinds := make(map[string]bool)
+	for _, d := range NewAnalyzer(0).Analyze(h).Sounds {
+		kinds[d.Kind] = true
+	}
+	for _, kind := range []string{SoundAlliteration, SoundInternalRhyme, SoundRhyme} {
+		if !kinds[kind] {
+			t.Errorf("Sounds missing %s", kind)
+		}
+	}
+}
+
+func TestAnalyze_Imagery(t *testing.T) {
+	h, err := ParseHaiku("cold rain on my hands\nthe sweet smell of pine needles\nlove and hope remain")
+	if err != nil {
+		t.Fatalf("ParseHaiku() error = %v", err)
+	}
+
+	m := NewAnalyzer(0).Analyze(h)
+	if m.Concreteness <= 1 || m.Concreteness >= 5 {
+		t.Errorf("Concreteness = %.2f, want between 1 and 5", m.Concreteness)
+	}
+	if !slices.Equal(m.AbstractWords, []string{"love", "hope"}) {
+		t.Errorf("AbstractWords = %v, want [love hope]", m.AbstractWords)
+	}
+	if !slices.Contains(m.Senses, SenseSmell) || !slices.Contains(m.Senses, SenseTaste) {
+		t.Errorf("Senses = %v, want smell and taste", m.Senses)
+	}
+}
+
+func TestAnalyze_Grammar(t *testing.T) {
+	h, err := ParseHaiku("the cold dark lonely night\na crow flew over the field\nsilence again")
+	if err != nil {
+		t.Fatalf("ParseHaiku() error = %v", err)
+	}
+
+	m := NewAnalyzer(0).Analyze(h)
+	if len(m.Grammar) != 3 {
+		t.Fatalf("len(Grammar) = %d, want 3", len(m.Grammar))
+	}
+	if g := m.Grammar[0]; g.Adjectives != 3 || g.Clause {
+		t.Errorf("Grammar[0] = %+v, want 3 adjectives and no clause", g)
+	}
+	if g := m.Grammar[1]; g.Tense != TensePast || !g.Clause {
+		t.Errorf("Grammar[1] = %+v, want a past-tense clause", g)
+	}
+	if pos := m.Tokens[1][2].POS; pos != TagVerb {
+		t.Errorf("Tokens[1][2].POS = %q, want %q", pos, TagVerb)
+	}
+}
+
+func TestAnalyze_Cliches(t *testing.T) {
+	h, err := ParseHaiku("an old pond\nthe leaves of autumn drifting\nby the rusty gates")
+	if err != nil {
+		t.Fatalf("ParseHaiku() error = %v", err)
+	}
+
+	if err := AddCliche("rusty gate", ClicheLow); err != nil {
+		t.Fatalf("AddCliche() error = %v", err)
+	}
+	m := NewAnalyzer(0).Analyze(h)
+
+	var got []string
+	for _, c := range m.Cliches {
+		got = append(got, c.Text+" ("+c.Tier+")")
+	}
+	want := []string{"old pond (high)", "leaves of autumn (high)", "rusty gates (low)"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Cliches = %v, want %v", got, want)
+	}
+}
+
+func TestAnalyzer_SetDialect(t *testing.T) {
+	h, err := ParseHaiku("an hour by the fire\na frog jumps into the pond\nsplash silence again")
+	if err != nil {
+		t.Fatalf("ParseHaiku() error = %v", err)
+	}
+
+	a := NewAnalyzer(0)
+	for _, d := range Dialects() {
+		a.SetDialect(d)
+		m := a.Analyze(h)
+		if m.Dialect != string(d) {
+			t.Errorf("Metrics.Dialect = %q, want %q", m.Dialect, d)
+		}
+	}
+
+	d, _ := ParseDialect("en-gb")
+	a.SetDialect(d)
+	if got := a.Analyze(h).LineSyllables[0]; got != 7 {
+		t.Errorf("RP line 1 = %d, want 7", got)
+	}
+}
@@ -0,0 +1,51 @@
+// Package haikugo provides near-duplicate detection across a haiku corpus.
+package haikugo
+
+import "github.com/thornzero/haikugo/internal/dedupe"
+
+// SimilarityIndex finds near-duplicate poems by MinHash and
+// locality-sensitive hashing over word shingles, confirmed by edit distance.
+type SimilarityIndex = dedupe.Index
+
+// SimilarityOptions configures a SimilarityIndex: the edit similarity at
+// which poems are near-duplicates and the number of words per shingle.
+type SimilarityOptions = dedupe.Options
+
+// SimilarityEntry is a poem in a SimilarityIndex.
+type SimilarityEntry = dedupe.Entry
+
+// DuplicatePair is a confirmed pair of near-duplicates with their shingle
+// overlap and edit similarity.
+type DuplicatePair = dedupe.Pair
+
+// DuplicateCluster is a group of poems linked by near-duplicate pairs.
+type DuplicateCluster = dedupe.Cluster
+
+// SimilarityMatch is an indexed poem found near-duplicate to a query.
+type SimilarityMatch = dedupe.Match
+
+// Similarity defaults.
+const (
+	DefaultSimilarityThreshold = dedupe.DefaultThreshold
+	DefaultShingleSize         = dedupe.DefaultShingleSize
+)
+
+// NewSimilarityIndex creates an empty index of poems.
+func NewSimilarityIndex(opts SimilarityOptions) (*SimilarityIndex, error) {
+	return dedupe.New(opts)
+}
+
+// FindDuplicates groups the near-duplicate poems among records, skipping
+// records that failed to parse.
+func FindDuplicates(records []Record, opts SimilarityOptions) ([]DuplicateCluster, error) {
+	ix, err := dedupe.New(opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		if rec.Err == nil {
+			ix.Add(SimilarityEntry{ID: rec.ID, Author: rec.Author, Lines: rec.Haiku.Lines()})
+		}
+	}
+	return ix.Clusters(), nil
+}
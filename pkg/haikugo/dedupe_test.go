@@ -0,0 +1,47 @@
+package haikugo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	poems := []string{
+		"an old silent pond\na frog jumps into the pond\nsplash silence again",
+		"the old temple bell\nrings over the quiet hills\na crow in the rain",
+		"An old silent pond\na frog leaps into the pond\nsplash! silence again",
+	}
+	var records []Record
+	for i, text := range poems {
+		h, err := ParseHaiku(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, Record{ID: string(rune('a' + i)), Author: "Basho", Haiku: h})
+	}
+	records = append(records, Record{ID: "broken", Err: errors.New("not a haiku")})
+
+	clusters, err := FindDuplicates(records, SimilarityOptions{})
+	if err != nil {
+		t.Fatalf("FindDuplicates() error = %v", err)
+	}
+	if len(clusters) != 1 || len(clusters[0].Entries) != 2 {
+		t.Fatalf("FindDuplicates() = %+v, want one pair", clusters)
+	}
+	if c := clusters[0]; c.Entries[0].ID != "a" || c.Entries[1].ID != "c" || c.Entries[0].Author != "Basho" {
+		t.Errorf("Cluster entries = %+v, want a and c by Basho", c.Entries)
+	}
+
+	ix, err := NewSimilarityIndex(SimilarityOptions{Threshold: 0.99})
+	if err != nil {
+		t.Fatalf("NewSimilarityIndex() error = %v", err)
+	}
+	ix.Add(SimilarityEntry{ID: "a", Lines: records[0].Haiku.Lines()})
+	if got := ix.Query(records[2].Haiku.Lines()); len(got) != 0 {
+		t.Errorf("Query() at threshold 0.99 = %+v, want none", got)
+	}
+
+	if _, err := FindDuplicates(records, SimilarityOptions{ShingleSize: 9}); err == nil {
+		t.Error("FindDuplicates() with shingle size 9: error = nil")
+	}
+}
@@ -0,0 +1,48 @@
+// Package haikugo provides user syllable override dictionaries.
+package haikugo
+
+import (
+	"io"
+
+	"github.com/thornzero/haikugo/internal/dict"
+)
+
+// Dictionary holds user syllable overrides, optionally scoped per author.
+type Dictionary = dict.Dictionary
+
+// DictionaryEntry is a single syllable override.
+type DictionaryEntry = dict.Entry
+
+// DefaultDictionaryPath is the project-relative location of the override dictionary.
+const DefaultDictionaryPath = dict.DefaultPath
+
+// NewDictionary creates an empty override dictionary.
+func NewDictionary() *Dictionary {
+	return dict.New()
+}
+
+// ReadDictionary parses overrides in TSV form, one "word<TAB>count[<TAB>author]" per line.
+func ReadDictionary(r io.Reader) (*Dictionary, error) {
+	return dict.Read(r)
+}
+
+// LoadDictionary reads an override dictionary from a TSV file.
+func LoadDictionary(path string) (*Dictionary, error) {
+	return dict.Load(path)
+}
+
+// FindDictionary looks for DefaultDictionaryPath in dir and its parents.
+func FindDictionary(dir string) (string, bool) {
+	return dict.Find(dir)
+}
+
+// SetOverrides sets the user dictionary whose syllable counts take precedence
+// over every built-in counter. A nil dictionary disables overrides.
+func (a *Analyzer) SetOverrides(d *Dictionary) {
+	a.analyzer.SetOverrides(d)
+}
+
+// GetOverrides returns the user override dictionary, or nil if none is set.
+func (a *Analyzer) GetOverrides() *Dictionary {
+	return a.analyzer.GetOverrides()
+}
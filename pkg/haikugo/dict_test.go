@@ -0,0 +1,62 @@
+package haikugo
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzer_SetOverrides(t *testing.T) {
+	d, err := ReadDictionary(strings.NewReader("ouachita\t3\nhour\t2\tissa\n"))
+	if err != nil {
+		t.Fatalf("ReadDictionary() error = %v", err)
+	}
+
+	a := NewAnalyzer(0)
+	a.SetOverrides(d)
+	if a.GetOverrides() != d {
+		t.Error("GetOverrides() did not return the dictionary set")
+	}
+
+	h, err := ParseHaiku("the Ouachita\nan hour before the first frost\nsplash silence again")
+	if err != nil {
+		t.Fatalf("ParseHaiku() error = %v", err)
+	}
+	if got := a.Analyze(h).LineSyllables; got[0] != 4 || got[1] != 7 {
+		t.Errorf("LineSyllables = %v, want [4 7 5]", got)
+	}
+
+	h.SetAuthor("Issa")
+	if h.Author() != "Issa" {
+		t.Errorf("Author() = %q, want Issa", h.Author())
+	}
+	if got := a.Analyze(h).LineSyllables; got[1] != 8 {
+		t.Errorf("LineSyllables for Issa = %v, want [4 8 5]", got)
+	}
+}
+
+func TestAnalyzeStream_AuthorOverrides(t *testing.T) {
+	d := NewDictionary()
+	d.Set("hour", 2, "issa")
+
+	a := NewAnalyzer(0)
+	a.SetOverrides(d)
+
+	input := `{"id":"a","author":"Basho","poem":["an hour","old pond","frog"]}` + "\n" +
+		`{"id":"b","author":"Issa","poem":["an hour","old pond","frog"]}` + "\n"
+	var got []int
+	err := a.AnalyzeStream(context.Background(), strings.NewReader(input),
+		StreamOptions{Format: FormatJSONL}, func(res RecordResult) error {
+			if res.Metrics == nil {
+				t.Fatalf("Record %s error: %s", res.ID, res.Error)
+			}
+			got = append(got, res.Metrics.TotalSyllables)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("AnalyzeStream() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != 5 || got[1] != 6 {
+		t.Errorf("TotalSyllables = %v, want [5 6]", got)
+	}
+}
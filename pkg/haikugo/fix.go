@@ -0,0 +1,37 @@
+// Package haikugo provides syllable-fix suggestions.
+package haikugo
+
+import (
+	"github.com/thornzero/haikugo/internal/analyzer"
+	"github.com/thornzero/haikugo/internal/haiku"
+)
+
+// LineFix lists the rewrites that bring a line of a haiku to its target syllable count.
+type LineFix = haiku.LineFix
+
+// Rewrite is a suggested rewrite of a line, its syllable count and the edits that produce it.
+type Rewrite = haiku.Rewrite
+
+// Edit is one change made by a rewrite, replacing the text From by To.
+type Edit = haiku.Edit
+
+// Edit kinds reported in Edit.Kind.
+const (
+	EditDropArticle = analyzer.EditDropArticle
+	EditAddArticle  = analyzer.EditAddArticle
+	EditContract    = analyzer.EditContract
+	EditExpand      = analyzer.EditExpand
+	EditSynonym     = analyzer.EditSynonym
+)
+
+// FixLine suggests rewrites that bring line to target syllables, ranked
+// from the fewest and least intrusive edits.
+func (a *Analyzer) FixLine(line string, target int) LineFix {
+	return a.analyzer.FixLine(line, target)
+}
+
+// Fix suggests rewrites for each line of the haiku whose count is off
+// 5-7-5 by more than the tolerance.
+func (a *Analyzer) Fix(h *Haiku) []LineFix {
+	return a.analyzer.Fix(h.haiku)
+}
@@ -0,0 +1,41 @@
+package haikugo
+
+import "testing"
+
+func TestAnalyzer_Fix(t *testing.T) {
+	h, err := ParseHaiku("an old silent pond\na frog jumps into the cold pond\nsplash! silence again")
+	if err != nil {
+		t.Fatalf("ParseHaiku() error = %v", err)
+	}
+
+	fixes := NewAnalyzer(0).Fix(h)
+	if len(fixes) != 1 || fixes[0].Line != 2 || fixes[0].Syllables != 8 || fixes[0].Target != 7 {
+		t.Fatalf("Fix() = %+v, want line 2 from 8 to 7 syllables", fixes)
+	}
+	r := fixes[0].Rewrites[0]
+	if r.Syllables != 7 || len(r.Edits) != 1 || r.Edits[0].Kind != EditDropArticle {
+		t.Errorf("first rewrite = %+v, want one %s edit reaching 7", r, EditDropArticle)
+	}
+}
+
+func TestAnalyzer_FixLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		target   int
+		expected string
+	}{
+		{"I do not hear frogs", 4, "I don't hear frogs"},
+		{"it is cold", 2, "it's cold"},
+		{"old pond", 3, "the old pond"},
+	}
+
+	a := NewAnalyzer(0)
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			rewrites := a.FixLine(tt.line, tt.target).Rewrites
+			if len(rewrites) == 0 || rewrites[0].Text != tt.expected {
+				t.Errorf("FixLine(%q, %d) = %+v, want %q first", tt.line, tt.target, rewrites, tt.expected)
+			}
+		})
+	}
+}
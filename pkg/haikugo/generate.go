@@ -0,0 +1,32 @@
+// Package haikugo provides the template-based haiku generator.
+package haikugo
+
+import "github.com/thornzero/haikugo/internal/generate"
+
+// GenerateOptions configures Generate: the season of the season word, the
+// sense the image nouns engage, the seed and the number of poems.
+type GenerateOptions = generate.Options
+
+// Poem is a generated haiku with its season, templates and analysis.
+type Poem = generate.Poem
+
+// Template is a fragment or phrase grammar template used by Generate.
+type Template = generate.Template
+
+// Template kinds.
+const (
+	TemplateFragment = generate.KindFragment
+	TemplatePhrase   = generate.KindPhrase
+)
+
+// Templates returns the built-in grammar templates.
+func Templates() []Template {
+	return generate.Templates()
+}
+
+// Generate fills grammar templates with season words and lexicon words,
+// returning distinct poems that the analyzer counts exactly 5-7-5. The same
+// seed and options give the same poems.
+func (a *Analyzer) Generate(opts GenerateOptions) ([]Poem, error) {
+	return generate.Generate(a.analyzer, opts)
+}
@@ -0,0 +1,41 @@
+package haikugo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzer_Generate(t *testing.T) {
+	a := NewAnalyzer(0)
+	poems, err := a.Generate(GenerateOptions{Season: SeasonAutumn, Seed: 10, Count: 3})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(poems) != 3 {
+		t.Fatalf("Generate() returned %d poems, want 3", len(poems))
+	}
+	for _, p := range poems {
+		if !p.Metrics.Valid575 || p.Season != SeasonAutumn {
+			t.Errorf("Generate() poem %q: valid %v, season %q", p.Lines, p.Metrics.Valid575, p.Season)
+		}
+	}
+
+	again, _ := a.Generate(GenerateOptions{Season: SeasonAutumn, Seed: 10, Count: 3})
+	if !reflect.DeepEqual(poems[0].Lines, again[0].Lines) {
+		t.Errorf("Generate() with the same seed gave %q, then %q", poems[0].Lines, again[0].Lines)
+	}
+
+	if _, err := a.Generate(GenerateOptions{Season: "monsoon"}); err == nil {
+		t.Error("Generate() with an unknown season: error = nil")
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	kinds := make(map[string]int)
+	for _, tmpl := range Templates() {
+		kinds[tmpl.Kind]++
+	}
+	if kinds[TemplateFragment] == 0 || kinds[TemplatePhrase] == 0 {
+		t.Errorf("Templates() kinds = %v, want fragments and phrases", kinds)
+	}
+}
@@ -0,0 +1,39 @@
+// Package haikugo provides a file-backed journal of haiku drafts.
+package haikugo
+
+import "github.com/thornzero/haikugo/internal/journal"
+
+// Journal is an append-only store of poems with their revisions and
+// analyses, kept in a JSON Lines file.
+type Journal = journal.Journal
+
+// JournalEntry is a poem in the journal with all of its revisions.
+type JournalEntry = journal.Entry
+
+// JournalRevision is one saved version of a poem.
+type JournalRevision = journal.Revision
+
+// JournalDraft is a poem to save, with its metadata and analysis.
+type JournalDraft = journal.Draft
+
+// JournalQuery selects journal entries by text, season, form, validity,
+// tag, date and score.
+type JournalQuery = journal.Query
+
+// DefaultJournalPath is the project-relative location of the journal.
+const DefaultJournalPath = journal.DefaultPath
+
+// OpenJournal reads the journal at path. A missing file is an empty journal.
+func OpenJournal(path string) (*Journal, error) {
+	return journal.Open(path)
+}
+
+// FindJournal looks for DefaultJournalPath in dir and its parents.
+func FindJournal(dir string) (string, bool) {
+	return journal.Find(dir)
+}
+
+// Draft analyzes a haiku and returns it as a journal draft by its author.
+func (a *Analyzer) Draft(h *Haiku) JournalDraft {
+	return JournalDraft{Lines: h.Lines(), Author: h.Author(), Metrics: a.Analyze(h)}
+}
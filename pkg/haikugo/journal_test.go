@@ -0,0 +1,46 @@
+package haikugo
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultJournalPath)
+	j, err := OpenJournal(path)
+	if err != nil {
+		t.Fatalf("OpenJournal() error = %v", err)
+	}
+
+	h, err := ParseHaiku("an old silent pond\na frog jumps into the pond\nsplash silence again")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.SetAuthor("Basho")
+	d := NewAnalyzer(0).Draft(h)
+	d.Tags = []string{"classic"}
+	e, err := j.Add(d)
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if e.Author != "Basho" || e.Latest().Metrics == nil || !e.Latest().Metrics.Valid575 {
+		t.Errorf("Add() = %+v, want an analyzed poem by Basho", e)
+	}
+
+	reopened, err := OpenJournal(path)
+	if err != nil {
+		t.Fatalf("OpenJournal() error = %v", err)
+	}
+	valid := true
+	found, err := reopened.Search(JournalQuery{Text: "frog", Tag: "classic", Form: "5-7-5", Valid: &valid})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(found) != 1 || found[0].ID != e.ID {
+		t.Errorf("Search() = %+v, want entry %s", found, e.ID)
+	}
+
+	if got, ok := FindJournal(filepath.Join(filepath.Dir(filepath.Dir(path)), "sub")); !ok || got != path {
+		t.Errorf("FindJournal() = %q, %v, want %q", got, ok, path)
+	}
+}
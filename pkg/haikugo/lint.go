@@ -0,0 +1,73 @@
+// Package haikugo provides haiku craft linting.
+package haikugo
+
+import (
+	"io"
+
+	"github.com/thornzero/haikugo/internal/lint"
+)
+
+// LintRule is a registered craft check with an ID, default severity and message.
+type LintRule = lint.Rule
+
+// LintFinding is a rule violation tied to a position in the source.
+type LintFinding = lint.Finding
+
+// LintConfig overrides the default severity of rules, or disables them.
+type LintConfig = lint.Config
+
+// Severity ranks how serious a lint finding is.
+type Severity = lint.Severity
+
+// Severities from least to most serious. SeverityOff disables a rule.
+const (
+	SeverityOff     = lint.SeverityOff
+	SeverityInfo    = lint.SeverityInfo
+	SeverityWarning = lint.SeverityWarning
+	SeverityError   = lint.SeverityError
+)
+
+// DefaultLintConfigPath is the project-relative location of the rules file.
+const DefaultLintConfigPath = lint.DefaultConfigPath
+
+// ParseSeverity returns the severity with the given name.
+func ParseSeverity(name string) (Severity, error) {
+	return lint.ParseSeverity(name)
+}
+
+// LintRules returns the registered rules sorted by ID.
+func LintRules() []LintRule {
+	return lint.Rules()
+}
+
+// NewLintConfig creates a config that keeps every rule's default severity.
+func NewLintConfig() *LintConfig {
+	return lint.NewConfig()
+}
+
+// ReadLintConfig parses a rules file, one "rule<TAB>severity" per line.
+func ReadLintConfig(r io.Reader) (*LintConfig, error) {
+	return lint.ReadConfig(r)
+}
+
+// LoadLintConfig reads a rules file from path.
+func LoadLintConfig(path string) (*LintConfig, error) {
+	return lint.LoadConfig(path)
+}
+
+// FindLintConfig looks for DefaultLintConfigPath in dir and its parents.
+func FindLintConfig(dir string) (string, bool) {
+	return lint.FindConfig(dir)
+}
+
+// Lint runs every enabled rule over a haiku and the metrics Analyze
+// returned for it. A nil config uses the default severities.
+func Lint(h *Haiku, m *Metrics, c *LintConfig) []LintFinding {
+	return lint.Lint(h.haiku, m, c)
+}
+
+// MaxSeverity returns the most serious severity among findings, or
+// SeverityOff when there are none.
+func MaxSeverity(findings []LintFinding) Severity {
+	return lint.MaxSeverity(findings)
+}
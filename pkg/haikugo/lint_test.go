@@ -0,0 +1,40 @@
+package haikugo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	h, err := ParseHaiku("I feel so sad\nmy heart is like a stone\nthe cold dark lonely night")
+	if err != nil {
+		t.Fatalf("ParseHaiku() error = %v", err)
+	}
+	m := NewAnalyzer(0).Analyze(h)
+
+	c, err := ReadLintConfig(strings.NewReader("syllable-mismatch\toff\nsimile\terror\n"))
+	if err != nil {
+		t.Fatalf("ReadLintConfig() error = %v", err)
+	}
+
+	rules := map[string]Severity{}
+	for _, f := range Lint(h, m, c) {
+		rules[f.Rule] = f.Severity
+	}
+	for rule, want := range map[string]Severity{
+		"telling-emotion":    SeverityWarning,
+		"first-person":       SeverityWarning,
+		"simile":             SeverityError,
+		"adjective-overload": SeverityWarning,
+	} {
+		if rules[rule] != want {
+			t.Errorf("finding %s severity = %q, want %q", rule, rules[rule], want)
+		}
+	}
+	if _, ok := rules["syllable-mismatch"]; ok {
+		t.Error("disabled rule syllable-mismatch reported findings")
+	}
+	if len(LintRules()) == 0 {
+		t.Error("LintRules() is empty")
+	}
+}
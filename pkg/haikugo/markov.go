@@ -0,0 +1,51 @@
+// Package haikugo provides the Markov-chain haiku generator.
+package haikugo
+
+import (
+	"io"
+
+	"github.com/thornzero/haikugo/internal/generate"
+)
+
+// MarkovModel is an n-gram model of the lines of a haiku corpus.
+type MarkovModel = generate.Model
+
+// MarkovOptions configures GenerateMarkov: the seed, the number of poems
+// and the near-copy threshold.
+type MarkovOptions = generate.MarkovOptions
+
+// Markov model defaults and limits.
+const (
+	MinMarkovOrder     = generate.MinOrder
+	MaxMarkovOrder     = generate.MaxOrder
+	DefaultMarkovOrder = generate.DefaultOrder
+	DefaultMaxOverlap  = generate.DefaultMaxOverlap
+)
+
+// TrainMarkov builds a model of the given order from three-line poems.
+func TrainMarkov(poems [][]string, order int) (*MarkovModel, error) {
+	return generate.Train(poems, order)
+}
+
+// TrainMarkovCorpus builds a model of the given order from the poems in a
+// file or directory, read as by ReadCorpus.
+func TrainMarkovCorpus(path string, order int) (*MarkovModel, error) {
+	return generate.TrainCorpus(path, order)
+}
+
+// ReadMarkovModel reads a model in the JSON form written by its Write method.
+func ReadMarkovModel(r io.Reader) (*MarkovModel, error) {
+	return generate.ReadModel(r)
+}
+
+// LoadMarkovModel reads a model from a file written by its Save method.
+func LoadMarkovModel(path string) (*MarkovModel, error) {
+	return generate.LoadModel(path)
+}
+
+// GenerateMarkov samples distinct poems from m whose lines the analyzer
+// counts exactly 5-7-5, rejecting near-copies of the training poems. The
+// same seed and model give the same poems.
+func (a *Analyzer) GenerateMarkov(m *MarkovModel, opts MarkovOptions) ([]Poem, error) {
+	return m.Generate(a.analyzer, opts)
+}
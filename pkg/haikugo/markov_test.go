@@ -0,0 +1,53 @@
+package haikugo
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzer_GenerateMarkov(t *testing.T) {
+	corpus := [][]string{
+		{"an old silent pond", "a frog jumps into the pond", "splash silence again"},
+		{"the autumn moon shines", "over the pond a cold wind", "the frog sleeps alone"},
+		{"a cold winter night", "the snow falls into the pond", "silence in the pines"},
+		{"the old temple bell", "rings over the quiet hills", "a crow in the rain"},
+		{"spring rain on the roof", "a crow sleeps in the old pine", "the bell rings again"},
+	}
+	m, err := TrainMarkov(corpus, 1)
+	if err != nil {
+		t.Fatalf("TrainMarkov() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "model.json")
+	if err := m.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := LoadMarkovModel(path)
+	if err != nil {
+		t.Fatalf("LoadMarkovModel() error = %v", err)
+	}
+
+	a := NewAnalyzer(0)
+	poems, err := a.GenerateMarkov(loaded, MarkovOptions{Seed: 3, Count: 2})
+	if err != nil {
+		t.Fatalf("GenerateMarkov() error = %v", err)
+	}
+	if len(poems) != 2 {
+		t.Fatalf("GenerateMarkov() returned %d poems, want 2", len(poems))
+	}
+	for _, p := range poems {
+		if !p.Metrics.Valid575 {
+			t.Errorf("GenerateMarkov() poem %q is not 5-7-5", p.Lines)
+		}
+	}
+
+	again, _ := a.GenerateMarkov(m, MarkovOptions{Seed: 3, Count: 2})
+	if !reflect.DeepEqual(poems[0].Lines, again[0].Lines) {
+		t.Errorf("GenerateMarkov() with the same seed gave %q, then %q", poems[0].Lines, again[0].Lines)
+	}
+
+	if _, err := TrainMarkov(corpus, MaxMarkovOrder+1); err == nil {
+		t.Error("TrainMarkov() with too high an order: error = nil")
+	}
+}
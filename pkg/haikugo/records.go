@@ -0,0 +1,81 @@
+package haikugo
+
+import (
+	"io"
+
+	"github.com/thornzero/haikugo/internal/input"
+)
+
+// FieldMapping names the CSV columns or JSON fields that hold each record field.
+// Empty entries default to "id", "author" and "poem".
+type FieldMapping = input.FieldMapping
+
+// Record is a single poem read from a CSV or JSONL batch.
+// Err is set when this record could not be parsed.
+type Record struct {
+	ID     string
+	Author string
+	Haiku  *Haiku
+	Err    error
+}
+
+// RecordResult is the analysis outcome for one batch record, keyed by its ID.
+type RecordResult struct {
+	ID      string   `json:"id"`
+	Author  string   `json:"author,omitempty"`
+	Metrics *Metrics `json:"metrics,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// ParseCSV reads poem records from CSV data using the given column mapping.
+func ParseCSV(r io.Reader, m FieldMapping) ([]Record, error) {
+	records, err := input.New(false).ParseCSV(r, m)
+	return wrapRecords(records), err
+}
+
+// ParseJSONL reads poem records from newline-delimited JSON using the given field mapping.
+func ParseJSONL(r io.Reader, m FieldMapping) ([]Record, error) {
+	records, err := input.New(false).ParseJSONL(r, m)
+	return wrapRecords(records), err
+}
+
+// ReadCorpus reads every poem in a file or directory. Files ending in
+// .jsonl or .ndjson are read as JSONL, .csv as CSV and others as
+// blank-line separated stanzas. Directories are read recursively, skipping
+// hidden entries, and record IDs are prefixed with the file's relative
+// path, as in "spring/ponds.txt:2".
+func ReadCorpus(path string, m FieldMapping) ([]Record, error) {
+	records, err := input.New(false).ReadCorpus(path, m)
+	return wrapRecords(records), err
+}
+
+// AnalyzeRecords analyzes each record independently and returns results in record order.
+// Records that failed to parse are reported with their error instead of metrics.
+func (a *Analyzer) AnalyzeRecords(records []Record) []RecordResult {
+	results := make([]RecordResult, len(records))
+	for i, rec := range records {
+		results[i] = RecordResult{ID: rec.ID, Author: rec.Author}
+		if rec.Err != nil {
+			results[i].Error = rec.Err.Error()
+			continue
+		}
+		results[i].Metrics = a.Analyze(rec.Haiku)
+	}
+	return results
+}
+
+// wrapRecords converts internal records to their public form.
+func wrapRecords(records []input.Record) []Record {
+	if records == nil {
+		return nil
+	}
+
+	result := make([]Record, len(records))
+	for i, rec := range records {
+		result[i] = Record{ID: rec.ID, Author: rec.Author, Err: rec.Err}
+		if rec.Haiku != nil {
+			result[i].Haiku = &Haiku{haiku: rec.Haiku}
+		}
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package haikugo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeRecords_CSV(t *testing.T) {
+	data := "id,author,poem\n" +
+		"b,Basho,\"an old silent pond\na frog jumps into the pond\nsplash silence again\"\n" +
+		"a,Anon,\"broken\"\n"
+
+	records, err := ParseCSV(strings.NewReader(data), FieldMapping{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	results := NewAnalyzer(0).AnalyzeRecords(records)
+	if len(results) != 2 {
+		t.Fatalf("Got %d results, want 2", len(results))
+	}
+
+	if results[0].ID != "b" || results[1].ID != "a" {
+		t.Errorf("Results out of record order: %q, %q", results[0].ID, results[1].ID)
+	}
+
+	if results[0].Metrics == nil || !results[0].Metrics.Valid575 {
+		t.Error("Expected first record to be a valid 5-7-5 haiku")
+	}
+	if results[0].Author != "Basho" {
+		t.Errorf("Author = %q, want %q", results[0].Author, "Basho")
+	}
+
+	if results[1].Metrics != nil || results[1].Error == "" {
+		t.Error("Expected second record to carry an error and no metrics")
+	}
+}
+
+func TestAnalyzeRecords_JSONL(t *testing.T) {
+	data := `{"id": 1, "poem": ["old pond", "frog jumps in", "splash"]}
+{"id": 2, "poem": "too short"}`
+
+	records, err := ParseJSONL(strings.NewReader(data), FieldMapping{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	results := NewAnalyzer(0).AnalyzeRecords(records)
+	if len(results) != 2 {
+		t.Fatalf("Got %d results, want 2", len(results))
+	}
+
+	if results[0].Error != "" || results[0].Metrics == nil {
+		t.Errorf("Record 1 unexpected error: %s", results[0].Error)
+	}
+	if results[1].Error == "" {
+		t.Error("Record 2 should report a parse error")
+	}
+}
+
+func TestReadCorpus(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "pond.txt"), []byte("an old silent pond\na frog jumps into the pond\nsplash silence again\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "more.jsonl"), []byte(`{"id": "m1", "poem": "too short"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := ReadCorpus(dir, FieldMapping{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Got %d records, want 2", len(records))
+	}
+	if records[0].ID != "more.jsonl:m1" || records[0].Err == nil {
+		t.Errorf("Record 0 = %q (error %v), want a parse error for more.jsonl:m1", records[0].ID, records[0].Err)
+	}
+	if records[1].ID != "pond.txt:1" || records[1].Haiku == nil {
+		t.Errorf("Record 1 = %q, want pond.txt:1 parsed", records[1].ID)
+	}
+}
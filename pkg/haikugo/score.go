@@ -0,0 +1,63 @@
+// Package haikugo provides quality scoring rubrics.
+package haikugo
+
+import (
+	"io"
+
+	"github.com/thornzero/haikugo/internal/haiku"
+	"github.com/thornzero/haikugo/internal/score"
+)
+
+// Score grades a haiku against a weighted rubric, reported in Metrics.Score.
+type Score = haiku.Score
+
+// SubScore is one criterion's score from 0 to 1 with its weight and reason.
+type SubScore = haiku.SubScore
+
+// Rubric weights each criterion's sub-score in the overall score.
+type Rubric = score.Rubric
+
+// Criteria scored by the rubric, reported in SubScore.Criterion.
+const (
+	CriterionStructure   = score.CriterionStructure
+	CriterionKigo        = score.CriterionKigo
+	CriterionCut         = score.CriterionCut
+	CriterionConcrete    = score.CriterionConcrete
+	CriterionEconomy     = score.CriterionEconomy
+	CriterionSound       = score.CriterionSound
+	CriterionOriginality = score.CriterionOriginality
+)
+
+// DefaultRubricPath is the project-relative location of the rubric file.
+const DefaultRubricPath = score.DefaultPath
+
+// DefaultRubric creates a rubric with the default weights.
+func DefaultRubric() *Rubric {
+	return score.Default()
+}
+
+// ReadRubric parses weights in TSV form, one "criterion<TAB>weight" per line.
+func ReadRubric(r io.Reader) (*Rubric, error) {
+	return score.Read(r)
+}
+
+// LoadRubric reads a rubric from a TSV file.
+func LoadRubric(path string) (*Rubric, error) {
+	return score.Load(path)
+}
+
+// FindRubric looks for DefaultRubricPath in dir and its parents.
+func FindRubric(dir string) (string, bool) {
+	return score.Find(dir)
+}
+
+// SetRubric sets the rubric weighting the sub-scores of Metrics.Score.
+// A nil rubric uses the default weights.
+func (a *Analyzer) SetRubric(r *Rubric) {
+	a.analyzer.SetRubric(r)
+}
+
+// GetRubric returns the scoring rubric, or nil if the default weights are used.
+func (a *Analyzer) GetRubric() *Rubric {
+	return a.analyzer.GetRubric()
+}
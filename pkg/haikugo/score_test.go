@@ -0,0 +1,33 @@
+package haikugo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnalyzer_SetRubric(t *testing.T) {
+	h, err := ParseHaiku("an old silent pond\na frog jumps into the pond\nsplash! silence again")
+	if err != nil {
+		t.Fatalf("ParseHaiku() error = %v", err)
+	}
+
+	a := NewAnalyzer(0)
+	base := a.Analyze(h).Score
+	if len(base.Parts) != 7 || base.Parts[0].Criterion != CriterionStructure {
+		t.Fatalf("Score.Parts = %+v, want 7 criteria starting with structure", base.Parts)
+	}
+
+	r, err := ReadRubric(strings.NewReader("kigo\t10\n"))
+	if err != nil {
+		t.Fatalf("ReadRubric() error = %v", err)
+	}
+	a.SetRubric(r)
+	if a.GetRubric() != r {
+		t.Error("GetRubric() did not return the rubric set")
+	}
+
+	// The poem has no season word, so weighting kigo heavily lowers its score.
+	if got := a.Analyze(h).Score.Overall; got >= base.Overall {
+		t.Errorf("Score.Overall with heavy kigo weight = %g, want below %g", got, base.Overall)
+	}
+}
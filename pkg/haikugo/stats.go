@@ -0,0 +1,54 @@
+// Package haikugo provides corpus-level statistics over many haiku.
+package haikugo
+
+import "github.com/thornzero/haikugo/internal/stats"
+
+// StatsCollector accumulates corpus statistics over analyzed poems.
+type StatsCollector = stats.Collector
+
+// StatsOptions configures a StatsCollector: the largest syllable tolerance
+// validity is reported for and how many kigo, kireji and words are listed.
+type StatsOptions = stats.Options
+
+// CorpusReport is the statistics of a corpus: syllable patterns, validity
+// per tolerance, seasons, top kigo, kireji and words, and authors.
+type CorpusReport = stats.Report
+
+// CorpusCount is how often a value occurs in a corpus.
+type CorpusCount = stats.Count
+
+// ToleranceValidity is how many poems are valid 5-7-5 within a tolerance.
+type ToleranceValidity = stats.Validity
+
+// AuthorStats summarizes the poems of one author.
+type AuthorStats = stats.Author
+
+// Statistics defaults and labels.
+const (
+	DefaultStatsMaxTolerance = stats.DefaultMaxTolerance
+	DefaultStatsTop          = stats.DefaultTop
+	NoSeason                 = stats.NoSeason
+	UnknownAuthor            = stats.UnknownAuthor
+)
+
+// NewStatsCollector creates an empty collector.
+func NewStatsCollector(opts StatsOptions) (*StatsCollector, error) {
+	return stats.New(opts)
+}
+
+// CorpusStats analyzes each record and returns the statistics of the
+// corpus. Records that failed to parse are counted as errors.
+func (a *Analyzer) CorpusStats(records []Record, opts StatsOptions) (CorpusReport, error) {
+	c, err := stats.New(opts)
+	if err != nil {
+		return CorpusReport{}, err
+	}
+	for _, rec := range records {
+		if rec.Err != nil {
+			c.AddError()
+			continue
+		}
+		c.Add(rec.Author, a.Analyze(rec.Haiku))
+	}
+	return c.Report(), nil
+}
@@ -0,0 +1,47 @@
+package haikugo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAnalyzer_CorpusStats(t *testing.T) {
+	var records []Record
+	for i, text := range []string{
+		"an old silent pond\na frog jumps into the pond\nsplash silence again",
+		"a cold winter night\nthe snow drifts into the pond\nsilence in the pines",
+		"snow\non the frog\nsilent",
+	} {
+		h, err := ParseHaiku(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, Record{ID: string(rune('a' + i)), Author: "Basho", Haiku: h})
+	}
+	records = append(records, Record{ID: "broken", Err: errors.New("not a haiku")})
+
+	r, err := NewAnalyzer(0).CorpusStats(records, StatsOptions{Top: 3})
+	if err != nil {
+		t.Fatalf("CorpusStats() error = %v", err)
+	}
+	if r.Poems != 3 || r.Errors != 1 {
+		t.Errorf("Poems, Errors = %d, %d, want 3, 1", r.Poems, r.Errors)
+	}
+	if r.Patterns[0] != (CorpusCount{Value: "5-7-5", Count: 2, Fraction: 0.667}) {
+		t.Errorf("Patterns = %v, want 5-7-5 first", r.Patterns)
+	}
+	if len(r.Validity) != DefaultStatsMaxTolerance+1 || r.Validity[0] != (ToleranceValidity{Tolerance: 0, Valid: 2, Fraction: 0.667}) {
+		t.Errorf("Validity = %v", r.Validity)
+	}
+	if len(r.Authors) != 1 || r.Authors[0].Author != "Basho" || r.Authors[0].Poems != 3 {
+		t.Errorf("Authors = %v, want Basho with 3 poems", r.Authors)
+	}
+	if len(r.Vocabulary) != 3 {
+		t.Errorf("Vocabulary = %v, want the top 3 words", r.Vocabulary)
+	}
+
+	negative := -1
+	if _, err := NewAnalyzer(0).CorpusStats(records, StatsOptions{MaxTolerance: &negative}); err == nil {
+		t.Error("CorpusStats() with a negative tolerance should fail")
+	}
+}
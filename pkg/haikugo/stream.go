@@ -0,0 +1,171 @@
+package haikugo
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/thornzero/haikugo/internal/input"
+)
+
+// Format identifies how poems are laid out in a batch stream.
+type Format = input.Format
+
+// Supported batch stream formats.
+const (
+	FormatStanza = input.FormatStanza
+	FormatLines  = input.FormatLines
+	FormatJSONL  = input.FormatJSONL
+	FormatCSV    = input.FormatCSV
+)
+
+// ParseFormat converts a format name ("stanza", "lines", "jsonl" or "csv") into a Format.
+func ParseFormat(name string) (Format, error) {
+	return input.ParseFormat(name)
+}
+
+// FormatForPath guesses a file's batch format from its extension: JSONL for
+// .jsonl and .ndjson, CSV for .csv and stanzas otherwise.
+func FormatForPath(path string) Format {
+	return input.FormatForPath(path)
+}
+
+// StreamOptions configures AnalyzeStream.
+type StreamOptions struct {
+	// Format is the layout of the input stream. Defaults to FormatStanza.
+	Format Format
+	// Mapping names the record fields for the JSONL and CSV formats.
+	Mapping FieldMapping
+	// Autosplit splits single-line poems on common separators.
+	Autosplit bool
+	// Normalization is the Unicode normalization applied to each poem.
+	Normalization Normalization
+	// Workers is the number of concurrent analyzers. Defaults to runtime.NumCPU().
+	Workers int
+	// Buffer caps the number of records read but not yet emitted.
+	// Zero defaults to four times the number of workers.
+	Buffer int
+}
+
+// AnalyzeStream reads poems incrementally from r, analyzes them on a bounded
+// worker pool and calls emit for each result in input order. Reading pauses
+// while Buffer records are in flight, so memory stays bounded regardless of
+// input size. A line too long to read is reported as an error on its record.
+// It stops at the end of input, when ctx is cancelled, or when emit returns
+// an error, and returns the first error encountered. On cancellation it
+// returns without waiting for a Read of r that is blocked; the reading
+// goroutine exits once that Read returns.
+func (a *Analyzer) AnalyzeStream(ctx context.Context, r io.Reader, opts StreamOptions, emit func(RecordResult) error) error {
+	if opts.Format == "" {
+		opts.Format = FormatStanza
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	if opts.Buffer <= 0 {
+		opts.Buffer = 4 * opts.Workers
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type job struct {
+		seq int
+		rec input.Record
+	}
+	type result struct {
+		seq int
+		res RecordResult
+	}
+
+	jobs := make(chan job, opts.Workers)
+	results := make(chan result, opts.Buffer)
+	slots := make(chan struct{}, opts.Buffer)
+
+	var readErr error
+	go func() {
+		defer close(jobs)
+		parser := input.New(opts.Autosplit)
+		parser.SetNormalization(opts.Normalization)
+		scanner := parser.NewScanner(r, opts.Format, opts.Mapping)
+		for seq := 0; scanner.Scan(); seq++ {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- job{seq: seq, rec: scanner.Record()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr = scanner.Err()
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				res := a.analyzeRecord(j.rec)
+				select {
+				case results <- result{seq: j.seq, res: res}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Reorder results so they are emitted in input order.
+	pending := make(map[int]RecordResult)
+	next := 0
+	for {
+		var res result
+		var ok bool
+		select {
+		case res, ok = <-results:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if !ok {
+			break
+		}
+		pending[res.seq] = res.res
+		for ctx.Err() == nil {
+			out, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			<-slots
+			if err := emit(out); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return readErr
+}
+
+// analyzeRecord analyzes a single internal record into its public result.
+func (a *Analyzer) analyzeRecord(rec input.Record) RecordResult {
+	res := RecordResult{ID: rec.ID, Author: rec.Author}
+	if rec.Err != nil {
+		res.Error = rec.Err.Error()
+		return res
+	}
+	res.Metrics = a.analyzer.Analyze(rec.Haiku)
+	return res
+}
@@ -0,0 +1,177 @@
+package haikugo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnalyzeStream_Order(t *testing.T) {
+	var sb strings.Builder
+	const n = 500
+	for i := 0; i < n; i++ {
+		if i%7 == 0 {
+			sb.WriteString("broken line\n\n")
+			continue
+		}
+		sb.WriteString("an old silent pond\na frog jumps into the pond\nsplash silence again\n\n")
+	}
+
+	analyzer := NewAnalyzer(0)
+	var got []RecordResult
+	err := analyzer.AnalyzeStream(context.Background(), strings.NewReader(sb.String()),
+		StreamOptions{Workers: 8, Buffer: 16},
+		func(r RecordResult) error {
+			got = append(got, r)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(got) != n {
+		t.Fatalf("Got %d results, want %d", len(got), n)
+	}
+
+	for i, r := range got {
+		if r.ID != strconv.Itoa(i+1) {
+			t.Fatalf("Result %d has ID %q, want %d (out of order)", i, r.ID, i+1)
+		}
+		wantErr := i%7 == 0
+		if (r.Error != "") != wantErr {
+			t.Errorf("Result %s error = %q, wantErr %t", r.ID, r.Error, wantErr)
+		}
+		if !wantErr && (r.Metrics == nil || !r.Metrics.Valid575) {
+			t.Errorf("Result %s should be a valid haiku", r.ID)
+		}
+	}
+}
+
+func TestAnalyzeStream_Formats(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  StreamOptions
+		want  []string
+	}{
+		{
+			name:  "lines with autosplit",
+			input: "old pond / frog jumps in / splash\nleaves / fall / down\n",
+			opts:  StreamOptions{Format: FormatLines, Autosplit: true},
+			want:  []string{"1", "2"},
+		},
+		{
+			name:  "jsonl",
+			input: `{"id":"a","poem":"x\ny\nz"}` + "\n" + `{"id":"b","poem":"x\ny\nz"}` + "\n",
+			opts:  StreamOptions{Format: FormatJSONL, Workers: 2},
+			want:  []string{"a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []string
+			err := NewAnalyzer(0).AnalyzeStream(context.Background(), strings.NewReader(tt.input), tt.opts,
+				func(r RecordResult) error {
+					if r.Error != "" {
+						t.Errorf("Record %s unexpected error: %s", r.ID, r.Error)
+					}
+					ids = append(ids, r.ID)
+					return nil
+				})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.want) {
+				t.Errorf("Got IDs %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeStream_StopsOnEmitError(t *testing.T) {
+	input := strings.Repeat("a\nb\nc\n\n", 1000)
+	stop := errors.New("stop")
+
+	count := 0
+	err := NewAnalyzer(0).AnalyzeStream(context.Background(), strings.NewReader(input),
+		StreamOptions{Workers: 4},
+		func(RecordResult) error {
+			count++
+			if count == 10 {
+				return stop
+			}
+			return nil
+		})
+
+	if !errors.Is(err, stop) {
+		t.Errorf("Got error %v, want %v", err, stop)
+	}
+	if count != 10 {
+		t.Errorf("emit called %d times after stopping, want 10", count)
+	}
+}
+
+func TestAnalyzeStream_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	input := strings.Repeat("a\nb\nc\n\n", 1000)
+
+	err := NewAnalyzer(0).AnalyzeStream(ctx, strings.NewReader(input),
+		StreamOptions{Workers: 2},
+		func(RecordResult) error {
+			cancel()
+			return nil
+		})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestAnalyzeStream_CancelBlockedReader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r, w := io.Pipe()
+	defer w.Close()
+	go func() {
+		io.WriteString(w, "a\nb\nc\n\n")
+	}()
+
+	done := make(chan error)
+	go func() {
+		done <- NewAnalyzer(0).AnalyzeStream(ctx, r, StreamOptions{Workers: 2}, func(RecordResult) error {
+			cancel()
+			return nil
+		})
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Got error %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("AnalyzeStream did not return while its reader was blocked")
+	}
+}
+
+func TestAnalyzeStream_SmallBuffer(t *testing.T) {
+	input := strings.Repeat("a\nb\nc\n\n", 50)
+	count := 0
+	err := NewAnalyzer(0).AnalyzeStream(context.Background(), strings.NewReader(input),
+		StreamOptions{Workers: 8, Buffer: 1},
+		func(res RecordResult) error {
+			count++
+			if res.ID != strconv.Itoa(count) {
+				t.Errorf("Result %d has ID %q", count, res.ID)
+			}
+			return nil
+		})
+	if err != nil || count != 50 {
+		t.Errorf("Got %d results and error %v, want 50 and none", count, err)
+	}
+}
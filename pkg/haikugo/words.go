@@ -0,0 +1,42 @@
+// Package haikugo provides the word finder.
+package haikugo
+
+import "github.com/thornzero/haikugo/internal/analyzer"
+
+// WordQuery selects words from the lexicon by syllable count, stress
+// pattern, rhyme, season, starting sound, part of speech or synonym.
+// Zero fields match any word.
+type WordQuery = analyzer.WordQuery
+
+// WordEntry describes a word found in the lexicon.
+type WordEntry = analyzer.WordEntry
+
+// Seasons of the built-in season words, used in WordQuery.Season.
+const (
+	SeasonSpring = analyzer.SeasonSpring
+	SeasonSummer = analyzer.SeasonSummer
+	SeasonAutumn = analyzer.SeasonAutumn
+	SeasonWinter = analyzer.SeasonWinter
+	SeasonAll    = analyzer.SeasonAll
+)
+
+// Seasons returns the seasons of the built-in season words.
+func Seasons() []string {
+	return analyzer.Seasons()
+}
+
+// ParseSeason returns the season with the given name, accepting "fall" for autumn.
+func ParseSeason(name string) (string, error) {
+	return analyzer.ParseSeason(name)
+}
+
+// SeasonOf returns the season of a built-in season word, or "" for other words.
+func SeasonOf(word string) string {
+	return analyzer.SeasonOf(word)
+}
+
+// FindWords returns the lexicon words matching q in alphabetical order,
+// counted with the analyzer's dialect and global overrides.
+func (a *Analyzer) FindWords(q WordQuery) ([]WordEntry, error) {
+	return a.analyzer.FindWords(q)
+}
@@ -0,0 +1,30 @@
+package haikugo
+
+import "testing"
+
+func TestAnalyzer_FindWords(t *testing.T) {
+	a := NewAnalyzer(0)
+	entries, err := a.FindWords(WordQuery{RhymesWith: "moon", Syllables: 1, POS: TagNoun, Limit: 1})
+	if err != nil {
+		t.Fatalf("FindWords() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Word != "dune" || entries[0].Stress != "/" {
+		t.Errorf("FindWords() = %+v, want dune", entries)
+	}
+
+	if _, err := a.FindWords(WordQuery{Season: "monsoon"}); err == nil {
+		t.Error("FindWords() with an unknown season: error = nil")
+	}
+}
+
+func TestSeasonOf(t *testing.T) {
+	if s := SeasonOf("blizzard"); s != SeasonWinter {
+		t.Errorf("SeasonOf(%q) = %q, want %q", "blizzard", s, SeasonWinter)
+	}
+	if s, err := ParseSeason("fall"); err != nil || s != SeasonAutumn {
+		t.Errorf("ParseSeason(%q) = %q, %v, want %q", "fall", s, err, SeasonAutumn)
+	}
+	if n := len(Seasons()); n != 5 {
+		t.Errorf("len(Seasons()) = %d, want 5", n)
+	}
+}